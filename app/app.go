@@ -121,7 +121,9 @@ import (
 
 	consensusparamkeeper "github.com/cosmos/cosmos-sdk/x/consensus/keeper"
 	consensusparamtypes "github.com/cosmos/cosmos-sdk/x/consensus/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/evmos/ethermint/app/ante"
+	"github.com/evmos/ethermint/blocktrace"
 	"github.com/evmos/ethermint/ethereum/eip712"
 	srvflags "github.com/evmos/ethermint/server/flags"
 	ethermint "github.com/evmos/ethermint/types"
@@ -257,6 +259,9 @@ type EthermintApp struct {
 
 	// the configurator
 	configurator module.Configurator
+
+	// writes the traces of the delivered transactions once their block is committed, nil if disabled
+	blockTraceWriter *blocktrace.Writer
 }
 
 // NewEthermintApp returns a reference to a new initialized Ethermint application.
@@ -445,6 +450,32 @@ func NewEthermintApp(
 		app.AccountKeeper, app.BankKeeper, app.StakingKeeper, app.FeeMarketKeeper,
 		nil, geth.NewEVM, tracer, evmSs,
 	)
+	app.EvmKeeper.SetQueryLimits(
+		cast.ToDuration(appOpts.Get(srvflags.EVMQueryTimeout)),
+		cast.ToUint64(appOpts.Get(srvflags.EVMQueryGasCap)),
+	)
+	app.EvmKeeper.SetQueryContextCreator(bApp.CreateQueryContext)
+
+	// trace the delivered transactions to the block trace files
+	if dir := cast.ToString(appOpts.Get(srvflags.EVMBlockTraceDir)); dir != "" {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(homePath, dir)
+		}
+		writer, err := blocktrace.NewWriter(
+			dir,
+			cast.ToInt64(appOpts.Get(srvflags.EVMBlockTraceBlocksPerFile)),
+			cast.ToInt(appOpts.Get(srvflags.EVMBlockTraceMaxFiles)),
+		)
+		if err != nil {
+			panic(err)
+		}
+		var addresses []common.Address
+		for _, addr := range cast.ToStringSlice(appOpts.Get(srvflags.EVMBlockTraceAddresses)) {
+			addresses = append(addresses, common.HexToAddress(addr))
+		}
+		app.blockTraceWriter = writer
+		app.EvmKeeper.SetBlockTracer(cast.ToString(appOpts.Get(srvflags.EVMBlockTracer)), addresses, writer)
+	}
 
 	// Create IBC Keeper
 	app.IBCKeeper = ibckeeper.NewKeeper(
@@ -683,7 +714,7 @@ func NewEthermintApp(
 // use Ethermint's custom AnteHandler
 func (app *EthermintApp) setAnteHandler(txConfig client.TxConfig, maxGasWanted uint64) {
 	anteHandler, err := ante.NewAnteHandler(ante.HandlerOptions{
-		AccountKeeper:          app.AccountKeeper,
+		AccountKeeper:          app.EvmKeeper.AccountKeeper(),
 		BankKeeper:             app.BankKeeper,
 		SignModeHandler:        txConfig.SignModeHandler(),
 		FeegrantKeeper:         app.FeeGrantKeeper,
@@ -730,6 +761,28 @@ func (app *EthermintApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) a
 	return app.mm.EndBlock(ctx, req)
 }
 
+// Commit commits the block, then writes the block trace files if enabled
+func (app *EthermintApp) Commit() abci.ResponseCommit {
+	res := app.BaseApp.Commit()
+	if app.blockTraceWriter != nil {
+		height := app.LastBlockHeight()
+		if err := app.blockTraceWriter.Commit(height); err != nil {
+			app.Logger().Error("failed to write the block traces", "height", height, "error", err.Error())
+		}
+	}
+	return res
+}
+
+// Close closes the block trace files and the underlying BaseApp
+func (app *EthermintApp) Close() error {
+	if app.blockTraceWriter != nil {
+		if err := app.blockTraceWriter.Close(); err != nil {
+			return err
+		}
+	}
+	return app.BaseApp.Close()
+}
+
 // InitChainer updates at chain initialization
 func (app *EthermintApp) InitChainer(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
 	var genesisState simapp.GenesisState
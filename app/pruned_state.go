@@ -0,0 +1,129 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package app
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	errorsmod "cosmossdk.io/errors"
+	abci "github.com/cometbft/cometbft/abci/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	evmtypes "github.com/evmos/ethermint/x/evm/types"
+)
+
+// evmQueryService is the name of the gRPC query service of the evm module
+const evmQueryService = "ethermint.evm.v1.Query"
+
+// Query implements the ABCI Query method, the evm queries at a height whose state has been pruned
+// fail with ErrStatePruned instead of the generic invalid request of the query router.
+func (app *EthermintApp) Query(req abci.RequestQuery) abci.ResponseQuery {
+	if app.isEvmQuery(req.Path) && app.isStatePruned(req.Height) {
+		return sdkerrors.QueryResult(errorsmod.Wrapf(evmtypes.ErrStatePruned, "height %d", req.Height), false)
+	}
+	return app.BaseApp.Query(req)
+}
+
+// RegisterGRPCServer registers the gRPC services of the app on the server, the evm queries at a height whose
+// state has been pruned fail with the status error of evmtypes.NewStatePrunedStatusError.
+func (app *EthermintApp) RegisterGRPCServer(server gogogrpc.Server) {
+	app.BaseApp.RegisterGRPCServer(prunedStateServer{Server: server, app: app})
+}
+
+// isEvmQuery returns true for the gRPC queries of the evm module and the raw queries of its store.
+func (app *EthermintApp) isEvmQuery(path string) bool {
+	path = strings.TrimPrefix(path, "/")
+	return strings.HasPrefix(path, evmQueryService+"/") || strings.HasPrefix(path, "store/"+evmtypes.StoreKey+"/")
+}
+
+// isStatePruned returns true if the state of the evm store at the given height has been pruned, the heights
+// above the latest one are left to the query router which rejects them.
+func (app *EthermintApp) isStatePruned(height int64) bool {
+	if height <= 0 || height > app.LastBlockHeight() {
+		return false
+	}
+	store, ok := app.CommitMultiStore().GetCommitKVStore(app.keys[evmtypes.StoreKey]).(interface {
+		VersionExists(version int64) bool
+	})
+	return ok && !store.VersionExists(height)
+}
+
+// prunedStateServer wraps the handlers of the evm query service registered on the gRPC server to check
+// the queried height before the query router creates its context.
+type prunedStateServer struct {
+	gogogrpc.Server
+	app *EthermintApp
+}
+
+// RegisterService implements gogogrpc.Server
+func (s prunedStateServer) RegisterService(desc *grpc.ServiceDesc, impl interface{}) {
+	if desc.ServiceName != evmQueryService {
+		s.Server.RegisterService(desc, impl)
+		return
+	}
+
+	methods := make([]grpc.MethodDesc, len(desc.Methods))
+	for i, method := range desc.Methods {
+		handler := method.Handler
+		methods[i] = grpc.MethodDesc{
+			MethodName: method.MethodName,
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				if err := s.checkHeight(ctx); err != nil {
+					return nil, err
+				}
+				return handler(srv, ctx, dec, interceptor)
+			},
+		}
+	}
+
+	streams := make([]grpc.StreamDesc, len(desc.Streams))
+	for i, stream := range desc.Streams {
+		handler := stream.Handler
+		streams[i] = stream
+		streams[i].Handler = func(srv interface{}, serverStream grpc.ServerStream) error {
+			if err := s.checkHeight(serverStream.Context()); err != nil {
+				return err
+			}
+			return handler(srv, serverStream)
+		}
+	}
+
+	newDesc := *desc
+	newDesc.Methods = methods
+	newDesc.Streams = streams
+	s.Server.RegisterService(&newDesc, impl)
+}
+
+// checkHeight returns the pruned state error if the state of the height in the request header has been pruned.
+func (s prunedStateServer) checkHeight(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	heights := md.Get(grpctypes.GRPCBlockHeightHeader)
+	if len(heights) != 1 {
+		return nil
+	}
+	// the invalid headers are rejected by the query router
+	height, err := strconv.ParseInt(heights[0], 10, 64)
+	if err != nil || !s.app.isStatePruned(height) {
+		return nil
+	}
+	return evmtypes.NewStatePrunedStatusError(height)
+}
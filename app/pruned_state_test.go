@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cosmos/cosmos-sdk/store/iavl"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	evmtypes "github.com/evmos/ethermint/x/evm/types"
+)
+
+func TestQueryPrunedState(t *testing.T) {
+	app := Setup(false, nil)
+	app.Commit()
+	for height := int64(2); height <= 3; height++ {
+		app.BeginBlock(abci.RequestBeginBlock{Header: tmproto.Header{ChainID: "ethermint_9000-1", Height: height}})
+		app.EndBlock(abci.RequestEndBlock{Height: height})
+		app.Commit()
+	}
+
+	store := app.CommitMultiStore().GetCommitKVStore(app.keys[evmtypes.StoreKey]).(*iavl.Store)
+	require.NoError(t, store.DeleteVersions(1))
+
+	testCases := []struct {
+		name   string
+		path   string
+		height int64
+		pruned bool
+	}{
+		{"evm query at pruned height", "/ethermint.evm.v1.Query/Params", 1, true},
+		{"evm store query at pruned height", "/store/evm/key", 1, true},
+		{"evm query at available height", "/ethermint.evm.v1.Query/Params", 2, false},
+		{"evm query at latest height", "/ethermint.evm.v1.Query/Params", 0, false},
+		{"evm query at future height", "/ethermint.evm.v1.Query/Params", 10, false},
+		{"other query at pruned height", "/cosmos.bank.v1beta1.Query/Params", 1, false},
+	}
+
+	for _, tc := range testCases {
+		res := app.Query(abci.RequestQuery{Path: tc.path, Data: []byte{}, Height: tc.height})
+		require.Equal(t, tc.pruned, res.Codespace == evmtypes.ModuleName && res.Code == evmtypes.ErrStatePruned.ABCICode(), tc.name)
+	}
+
+	// the gRPC server checks the height header of the evm queries
+	for height, pruned := range map[int64]bool{1: true, 2: false, 3: false, 10: false} {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(height, 10),
+		))
+		err := prunedStateServer{app: app}.checkHeight(ctx)
+		require.Equal(t, pruned, evmtypes.IsPrunedStateError(evmtypes.ConvertPrunedStateError(err)), height)
+	}
+	require.NoError(t, prunedStateServer{app: app}.checkHeight(context.Background()))
+}
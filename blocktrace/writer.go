@@ -0,0 +1,172 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package blocktrace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	errorsmod "cosmossdk.io/errors"
+
+	evmtypes "github.com/evmos/ethermint/x/evm/types"
+)
+
+// FileExt is the extension of the block trace files
+const FileExt = ".jsonl"
+
+var _ evmtypes.BlockTraceWriter = &Writer{}
+
+// Writer writes the execution traces of the delivered transactions to JSONL files, one trace per line,
+// once their block is committed. The files are rotated every blocksPerFile blocks and named after the
+// first height they cover, only the maxFiles most recent files are kept.
+type Writer struct {
+	mtx sync.Mutex
+
+	dir           string
+	blocksPerFile int64
+	// 0 means the files are never pruned
+	maxFiles int
+
+	// traces of the block being delivered
+	pendingHeight int64
+	pending       [][]byte
+
+	// file of the current rotation
+	file      *os.File
+	fileStart int64
+}
+
+// NewWriter creates the writer, the directory is created if it doesn't exist.
+func NewWriter(dir string, blocksPerFile int64, maxFiles int) (*Writer, error) {
+	if blocksPerFile <= 0 {
+		return nil, fmt.Errorf("invalid number of blocks per block trace file %d", blocksPerFile)
+	}
+	if maxFiles < 0 {
+		return nil, fmt.Errorf("invalid max number of block trace files %d", maxFiles)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errorsmod.Wrapf(err, "failed to create the block trace directory %s", dir)
+	}
+	return &Writer{
+		dir:           dir,
+		blocksPerFile: blocksPerFile,
+		maxFiles:      maxFiles,
+	}, nil
+}
+
+// AddBlock implements evmtypes.BlockTraceWriter interface.
+func (w *Writer) AddBlock(height int64, traces [][]byte) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	w.pendingHeight = height
+	w.pending = traces
+}
+
+// Commit writes the traces of the committed block, the traces of other heights are discarded.
+func (w *Writer) Commit(height int64) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	traces := w.pending
+	pendingHeight := w.pendingHeight
+	w.pending = nil
+	w.pendingHeight = 0
+	if pendingHeight != height || len(traces) == 0 {
+		return nil
+	}
+
+	if err := w.rotate(height); err != nil {
+		return err
+	}
+
+	var buf strings.Builder
+	for _, trace := range traces {
+		buf.Write(trace)
+		buf.WriteByte('\n')
+	}
+	if _, err := w.file.WriteString(buf.String()); err != nil {
+		return errorsmod.Wrapf(err, "failed to write the block traces of height %d", height)
+	}
+	return w.file.Sync()
+}
+
+// Close closes the current file.
+func (w *Writer) Close() error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// rotate opens the file covering the height, and prunes the oldest files.
+func (w *Writer) rotate(height int64) error {
+	start := (height-1)/w.blocksPerFile*w.blocksPerFile + 1
+	if w.file != nil && w.fileStart == start {
+		return nil
+	}
+	if w.file != nil {
+		if err := w.file.Close(); err != nil {
+			return err
+		}
+		w.file = nil
+	}
+
+	file, err := os.OpenFile(filepath.Join(w.dir, FileName(start)), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return errorsmod.Wrapf(err, "failed to open the block trace file of height %d", height)
+	}
+	w.file = file
+	w.fileStart = start
+
+	return w.prune()
+}
+
+// prune removes the oldest files beyond the max number of files.
+func (w *Writer) prune() error {
+	if w.maxFiles == 0 {
+		return nil
+	}
+	files, err := filepath.Glob(filepath.Join(w.dir, "*"+FileExt))
+	if err != nil {
+		return err
+	}
+	if len(files) <= w.maxFiles {
+		return nil
+	}
+	// the names are zero padded heights
+	sort.Strings(files)
+	for _, file := range files[:len(files)-w.maxFiles] {
+		if err := os.Remove(file); err != nil {
+			return errorsmod.Wrapf(err, "failed to prune the block trace file %s", file)
+		}
+	}
+	return nil
+}
+
+// FileName returns the name of the block trace file starting at the height.
+func FileName(start int64) string {
+	return fmt.Sprintf("%020d%s", start, FileExt)
+}
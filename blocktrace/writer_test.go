@@ -0,0 +1,72 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package blocktrace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	_, err := NewWriter(t.TempDir(), 0, 0)
+	require.Error(t, err)
+	_, err = NewWriter(t.TempDir(), 1, -1)
+	require.Error(t, err)
+
+	dir := filepath.Join(t.TempDir(), "traces")
+	w, err := NewWriter(dir, 2, 2)
+	require.NoError(t, err)
+	defer w.Close()
+
+	readFile := func(start int64) string {
+		bz, err := os.ReadFile(filepath.Join(dir, FileName(start)))
+		require.NoError(t, err)
+		return string(bz)
+	}
+
+	// the traces of a block are written once committed
+	w.AddBlock(1, [][]byte{[]byte(`{"txIndex":0}`), []byte(`{"txIndex":1}`)})
+	_, err = os.Stat(filepath.Join(dir, FileName(1)))
+	require.True(t, os.IsNotExist(err))
+	require.NoError(t, w.Commit(1))
+	require.Equal(t, "{\"txIndex\":0}\n{\"txIndex\":1}\n", readFile(1))
+
+	// the traces of another height are discarded
+	w.AddBlock(3, [][]byte{[]byte(`{"txIndex":0}`)})
+	require.NoError(t, w.Commit(2))
+	require.NoError(t, w.Commit(3))
+	_, err = os.Stat(filepath.Join(dir, FileName(3)))
+	require.True(t, os.IsNotExist(err))
+
+	// a re-executed block replaces the pending traces
+	w.AddBlock(2, [][]byte{[]byte(`{"txIndex":5}`)})
+	w.AddBlock(2, [][]byte{[]byte(`{"txIndex":0}`)})
+	require.NoError(t, w.Commit(2))
+	require.Equal(t, "{\"txIndex\":0}\n{\"txIndex\":1}\n{\"txIndex\":0}\n", readFile(1))
+
+	// the files are rotated and the oldest pruned
+	for _, height := range []int64{3, 5} {
+		w.AddBlock(height, [][]byte{[]byte(`{}`)})
+		require.NoError(t, w.Commit(height))
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*"+FileExt))
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(dir, FileName(3)), filepath.Join(dir, FileName(5))}, files)
+	require.Equal(t, "{}\n", readFile(5))
+}
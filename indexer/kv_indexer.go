@@ -35,14 +35,18 @@ import (
 )
 
 const (
-	KeyPrefixTxHash  = 1
-	KeyPrefixTxIndex = 2
+	KeyPrefixTxHash   = 1
+	KeyPrefixTxIndex  = 2
+	KeyPrefixBadBlock = 3
 
 	// TxIndexKeyLength is the length of tx-index key
 	TxIndexKeyLength = 1 + 8 + 8
 )
 
-var _ ethermint.EVMTxIndexer = &KVIndexer{}
+var (
+	_ ethermint.EVMTxIndexer    = &KVIndexer{}
+	_ ethermint.BadBlockIndexer = &KVIndexer{}
+)
 
 // KVIndexer implements a eth tx indexer on a KV db.
 type KVIndexer struct {
@@ -61,6 +65,7 @@ func NewKVIndexer(db dbm.DB, logger log.Logger, clientCtx client.Context) *KVInd
 // - Parses eth Tx infos from cosmos-sdk events for every TxResult
 // - Iterates over all the messages of the Tx
 // - Builds and stores a indexer.TxResult based on parsed events for every message
+// - Records the block as a bad block if an eth tx was rejected in DeliverTx
 func (kv *KVIndexer) IndexBlock(block *tmtypes.Block, txResults []*abci.ResponseDeliverTx) error {
 	height := block.Header.Height
 
@@ -69,9 +74,12 @@ func (kv *KVIndexer) IndexBlock(block *tmtypes.Block, txResults []*abci.Response
 
 	// record index of valid eth tx during the iteration
 	var ethTxIndex int32
+	var badBlock bool
 	for txIndex, tx := range block.Txs {
 		result := txResults[txIndex]
-		if !rpctypes.TxSuccessOrExceedsBlockGasLimit(result) {
+		delivered := rpctypes.TxSuccessOrExceedsBlockGasLimit(result)
+		if !delivered && badBlock {
+			// the block is already recorded as a bad block
 			continue
 		}
 
@@ -84,6 +92,10 @@ func (kv *KVIndexer) IndexBlock(block *tmtypes.Block, txResults []*abci.Response
 		if !isEthTx(tx) {
 			continue
 		}
+		if !delivered {
+			badBlock = true
+			continue
+		}
 
 		txs, err := rpctypes.ParseTxResult(result, tx)
 		if err != nil {
@@ -129,6 +141,11 @@ func (kv *KVIndexer) IndexBlock(block *tmtypes.Block, txResults []*abci.Response
 			}
 		}
 	}
+	if badBlock {
+		if err := batch.Set(BadBlockKey(common.BytesToHash(block.Hash())), sdk.Uint64ToBigEndian(uint64(height))); err != nil {
+			return errorsmod.Wrapf(err, "IndexBlock %d, set bad-block key", height)
+		}
+	}
 	if err := batch.Write(); err != nil {
 		return errorsmod.Wrapf(err, "IndexBlock %d, write batch", block.Height)
 	}
@@ -173,6 +190,18 @@ func (kv *KVIndexer) GetByBlockAndIndex(blockNumber int64, txIndex int32) (*ethe
 	return kv.GetByTxHash(common.BytesToHash(bz))
 }
 
+// GetBadBlockHeight returns the height of a recorded bad block, returns -1 if the block is not recorded
+func (kv *KVIndexer) GetBadBlockHeight(hash common.Hash) (int64, error) {
+	bz, err := kv.db.Get(BadBlockKey(hash))
+	if err != nil {
+		return 0, errorsmod.Wrapf(err, "GetBadBlockHeight %s", hash.Hex())
+	}
+	if len(bz) == 0 {
+		return -1, nil
+	}
+	return int64(sdk.BigEndianToUint64(bz)), nil
+}
+
 // TxHashKey returns the key for db entry: `tx hash -> tx result struct`
 func TxHashKey(hash common.Hash) []byte {
 	return append([]byte{KeyPrefixTxHash}, hash.Bytes()...)
@@ -185,6 +214,11 @@ func TxIndexKey(blockNumber int64, txIndex int32) []byte {
 	return append(append([]byte{KeyPrefixTxIndex}, bz1...), bz2...)
 }
 
+// BadBlockKey returns the key for db entry: `block hash -> block number` of the bad blocks
+func BadBlockKey(hash common.Hash) []byte {
+	return append([]byte{KeyPrefixBadBlock}, hash.Bytes()...)
+}
+
 // LoadLastBlock returns the latest indexed block number, returns -1 if db is empty
 func LoadLastBlock(db dbm.DB) (int64, error) {
 	it, err := db.ReverseIterator([]byte{KeyPrefixTxIndex}, []byte{KeyPrefixTxIndex + 1})
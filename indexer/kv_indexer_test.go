@@ -57,6 +57,7 @@ func TestKVIndexer(t *testing.T) {
 		block       *tmtypes.Block
 		blockResult []*abci.ResponseDeliverTx
 		expSuccess  bool
+		expBadBlock bool
 	}{
 		{
 			"success, format 1",
@@ -77,6 +78,7 @@ func TestKVIndexer(t *testing.T) {
 				},
 			},
 			true,
+			false,
 		},
 		{
 			"success, format 2",
@@ -99,6 +101,7 @@ func TestKVIndexer(t *testing.T) {
 				},
 			},
 			true,
+			false,
 		},
 		{
 			"success, exceed block gas limit",
@@ -111,6 +114,7 @@ func TestKVIndexer(t *testing.T) {
 				},
 			},
 			true,
+			false,
 		},
 		{
 			"fail, failed eth tx",
@@ -123,6 +127,7 @@ func TestKVIndexer(t *testing.T) {
 				},
 			},
 			false,
+			true,
 		},
 		{
 			"fail, invalid events",
@@ -134,6 +139,7 @@ func TestKVIndexer(t *testing.T) {
 				},
 			},
 			false,
+			false,
 		},
 		{
 			"fail, not eth tx",
@@ -145,6 +151,7 @@ func TestKVIndexer(t *testing.T) {
 				},
 			},
 			false,
+			false,
 		},
 	}
 
@@ -155,6 +162,14 @@ func TestKVIndexer(t *testing.T) {
 
 			err = idxer.IndexBlock(tc.block, tc.blockResult)
 			require.NoError(t, err)
+
+			badBlockHeight, err := idxer.GetBadBlockHeight(common.BytesToHash(tc.block.Hash()))
+			require.NoError(t, err)
+			if tc.expBadBlock {
+				require.Equal(t, tc.block.Header.Height, badBlockHeight)
+			} else {
+				require.Equal(t, int64(-1), badBlockHeight)
+			}
 			if !tc.expSuccess {
 				first, err := idxer.FirstIndexedBlock()
 				require.NoError(t, err)
@@ -0,0 +1,306 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package indexer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	errorsmod "cosmossdk.io/errors"
+	dbm "github.com/cometbft/cometbft-db"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+)
+
+const (
+	KeyPrefixBlockTraces = 3
+	KeyPrefixTraceFrom   = 4
+	KeyPrefixTraceTo     = 5
+	// KeyPrefixFailedTraceBlock marks the blocks skipped by the trace indexer after failing to be traced
+	KeyPrefixFailedTraceBlock = 6
+
+	// BlockTracesKeyLength is the length of block-traces key
+	BlockTracesKeyLength = 1 + 8
+)
+
+var _ rpctypes.TraceIndexer = &KVIndexer{}
+
+var (
+	errTracesNotIndexed = errors.New("traces not indexed")
+	errTraceBlockFailed = errors.New("block failed to be traced")
+)
+
+// traceLocation locates a trace by the block number and the position in the flat traces of the block.
+type traceLocation struct {
+	height int64
+	index  uint32
+}
+
+// IndexBlockTraces stores the flat call traces of a block, and indexes them by the from and to addresses.
+func (kv *KVIndexer) IndexBlockTraces(height int64, traces []*rpctypes.ParityTrace) error {
+	if traces == nil {
+		traces = []*rpctypes.ParityTrace{}
+	}
+	bz, err := json.Marshal(traces)
+	if err != nil {
+		return errorsmod.Wrapf(err, "IndexBlockTraces %d", height)
+	}
+
+	batch := kv.db.NewBatch()
+	defer batch.Close()
+
+	if err := batch.Set(BlockTracesKey(height), bz); err != nil {
+		return errorsmod.Wrapf(err, "IndexBlockTraces %d, set block-traces key", height)
+	}
+	for i, trace := range traces {
+		if from := trace.FromAddress(); from != nil {
+			if err := batch.Set(TraceAddressKey(KeyPrefixTraceFrom, *from, height, uint32(i)), []byte{}); err != nil {
+				return errorsmod.Wrapf(err, "IndexBlockTraces %d, set trace-from key", height)
+			}
+		}
+		if to := trace.ToAddress(); to != nil {
+			if err := batch.Set(TraceAddressKey(KeyPrefixTraceTo, *to, height, uint32(i)), []byte{}); err != nil {
+				return errorsmod.Wrapf(err, "IndexBlockTraces %d, set trace-to key", height)
+			}
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return errorsmod.Wrapf(err, "IndexBlockTraces %d, write batch", height)
+	}
+	return nil
+}
+
+// IndexFailedTraceBlock records a block failed to be traced, so the indexer can move past it while
+// the queries of the block report the failure instead of missing traces.
+func (kv *KVIndexer) IndexFailedTraceBlock(height int64, reason string) error {
+	if err := kv.db.Set(FailedTraceBlockKey(height), []byte(reason)); err != nil {
+		return errorsmod.Wrapf(err, "IndexFailedTraceBlock %d", height)
+	}
+	return nil
+}
+
+// FirstIndexedTraceBlock returns the first block of the trace index, returns -1 if the index is empty
+func (kv *KVIndexer) FirstIndexedTraceBlock() (int64, error) {
+	first := int64(-1)
+	for _, prefix := range []byte{KeyPrefixBlockTraces, KeyPrefixFailedTraceBlock} {
+		it, err := kv.db.Iterator([]byte{prefix}, []byte{prefix + 1})
+		if err != nil {
+			return 0, errorsmod.Wrap(err, "FirstIndexedTraceBlock")
+		}
+		height, err := parseFirstBlockNumber(it)
+		if err != nil {
+			return 0, err
+		}
+		if height != -1 && (first == -1 || height < first) {
+			first = height
+		}
+	}
+	return first, nil
+}
+
+// LastIndexedTraceBlock returns the last block of the trace index, returns -1 if the index is empty
+func (kv *KVIndexer) LastIndexedTraceBlock() (int64, error) {
+	last := int64(-1)
+	for _, prefix := range []byte{KeyPrefixBlockTraces, KeyPrefixFailedTraceBlock} {
+		it, err := kv.db.ReverseIterator([]byte{prefix}, []byte{prefix + 1})
+		if err != nil {
+			return 0, errorsmod.Wrap(err, "LastIndexedTraceBlock")
+		}
+		height, err := parseFirstBlockNumber(it)
+		if err != nil {
+			return 0, err
+		}
+		if height > last {
+			last = height
+		}
+	}
+	return last, nil
+}
+
+// GetBlockTraces returns the flat call traces of an indexed block, fails if the block failed to be traced
+func (kv *KVIndexer) GetBlockTraces(height int64) ([]*rpctypes.ParityTrace, error) {
+	bz, err := kv.db.Get(BlockTracesKey(height))
+	if err != nil {
+		return nil, errorsmod.Wrapf(err, "GetBlockTraces %d", height)
+	}
+	if len(bz) == 0 {
+		if err := kv.checkFailedTraceBlocks(height, height); err != nil {
+			return nil, err
+		}
+		return nil, errorsmod.Wrapf(errTracesNotIndexed, "GetBlockTraces %d", height)
+	}
+	var traces []*rpctypes.ParityTrace
+	if err := json.Unmarshal(bz, &traces); err != nil {
+		return nil, errorsmod.Wrapf(err, "GetBlockTraces %d", height)
+	}
+	return traces, nil
+}
+
+// FilterTraces returns the traces of the indexed blocks in range [fromBlock, toBlock] matching the filter addresses.
+// The address index is used if any address is specified, otherwise all the traces in the range are scanned.
+// It fails if any block in the range failed to be traced, rather than returning incomplete results. The search stops
+// once the filter has Enough traces.
+func (kv *KVIndexer) FilterTraces(fromBlock, toBlock int64, args *rpctypes.TraceFilterArgs) ([]*rpctypes.ParityTrace, error) {
+	var (
+		result []*rpctypes.ParityTrace
+		blocks = make(map[int64][]*rpctypes.ParityTrace)
+	)
+	getBlockTraces := func(height int64) ([]*rpctypes.ParityTrace, error) {
+		if traces, ok := blocks[height]; ok {
+			return traces, nil
+		}
+		traces, err := kv.GetBlockTraces(height)
+		if err != nil {
+			return nil, err
+		}
+		blocks[height] = traces
+		return traces, nil
+	}
+
+	var (
+		prefix    byte
+		addresses []common.Address
+	)
+	switch {
+	case len(args.FromAddress) > 0:
+		prefix, addresses = KeyPrefixTraceFrom, args.FromAddress
+	case len(args.ToAddress) > 0:
+		prefix, addresses = KeyPrefixTraceTo, args.ToAddress
+	default:
+		for height := fromBlock; height <= toBlock; height++ {
+			traces, err := getBlockTraces(height)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, traces...)
+			if args.Enough(len(result)) {
+				break
+			}
+		}
+		return result, nil
+	}
+
+	if err := kv.checkFailedTraceBlocks(fromBlock, toBlock); err != nil {
+		return nil, err
+	}
+	locations, err := kv.traceLocations(prefix, addresses, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	for _, loc := range locations {
+		traces, err := getBlockTraces(loc.height)
+		if err != nil {
+			return nil, err
+		}
+		if int(loc.index) >= len(traces) {
+			return nil, errorsmod.Wrapf(errTracesNotIndexed, "trace %d of block %d", loc.index, loc.height)
+		}
+		if trace := traces[loc.index]; args.Match(trace) {
+			result = append(result, trace)
+			if args.Enough(len(result)) {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// traceLocations returns the sorted and deduplicated locations of the traces indexed by the addresses in the block range.
+func (kv *KVIndexer) traceLocations(prefix byte, addresses []common.Address, fromBlock, toBlock int64) ([]traceLocation, error) {
+	seen := make(map[traceLocation]bool)
+	var locations []traceLocation
+	for _, address := range addresses {
+		it, err := kv.db.Iterator(
+			TraceAddressKey(prefix, address, fromBlock, 0),
+			TraceAddressKey(prefix, address, toBlock+1, 0),
+		)
+		if err != nil {
+			return nil, errorsmod.Wrap(err, "FilterTraces")
+		}
+		for ; it.Valid(); it.Next() {
+			key := it.Key()
+			loc := traceLocation{
+				height: int64(sdk.BigEndianToUint64(key[1+common.AddressLength : 1+common.AddressLength+8])),
+				index:  uint32(sdk.BigEndianToUint64(key[1+common.AddressLength+8:])),
+			}
+			if !seen[loc] {
+				seen[loc] = true
+				locations = append(locations, loc)
+			}
+		}
+		it.Close()
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].height != locations[j].height {
+			return locations[i].height < locations[j].height
+		}
+		return locations[i].index < locations[j].index
+	})
+	return locations, nil
+}
+
+// checkFailedTraceBlocks returns an error if any block in range [fromBlock, toBlock] failed to be traced
+func (kv *KVIndexer) checkFailedTraceBlocks(fromBlock, toBlock int64) error {
+	it, err := kv.db.Iterator(FailedTraceBlockKey(fromBlock), FailedTraceBlockKey(toBlock+1))
+	if err != nil {
+		return errorsmod.Wrap(err, "checkFailedTraceBlocks")
+	}
+	defer it.Close()
+	if !it.Valid() {
+		return nil
+	}
+	height, err := parseBlockNumberFromBlockTracesKey(it.Key())
+	if err != nil {
+		return err
+	}
+	return errorsmod.Wrapf(errTraceBlockFailed, "block %d: %s", height, it.Value())
+}
+
+// BlockTracesKey returns the key for db entry: `block number -> flat call traces`
+func BlockTracesKey(height int64) []byte {
+	return append([]byte{KeyPrefixBlockTraces}, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// FailedTraceBlockKey returns the key for db entry: `block number -> trace failure reason`
+func FailedTraceBlockKey(height int64) []byte {
+	return append([]byte{KeyPrefixFailedTraceBlock}, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// TraceAddressKey returns the key for db entry: `(address, block number, trace index) -> nil`
+func TraceAddressKey(prefix byte, address common.Address, height int64, index uint32) []byte {
+	key := append([]byte{prefix}, address.Bytes()...)
+	key = append(key, sdk.Uint64ToBigEndian(uint64(height))...)
+	return append(key, sdk.Uint64ToBigEndian(uint64(index))...)
+}
+
+// parseFirstBlockNumber closes the iterator and returns the block number of its first key, returns -1 if it's empty
+func parseFirstBlockNumber(it dbm.Iterator) (int64, error) {
+	defer it.Close()
+	if !it.Valid() {
+		return -1, nil
+	}
+	return parseBlockNumberFromBlockTracesKey(it.Key())
+}
+
+func parseBlockNumberFromBlockTracesKey(key []byte) (int64, error) {
+	if len(key) != BlockTracesKeyLength {
+		return 0, fmt.Errorf("wrong block traces key length, expect: %d, got: %d", BlockTracesKeyLength, len(key))
+	}
+	return int64(sdk.BigEndianToUint64(key[1:])), nil
+}
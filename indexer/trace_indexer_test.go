@@ -0,0 +1,184 @@
+package indexer_test
+
+import (
+	"math/big"
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	tmlog "github.com/cometbft/cometbft/libs/log"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/evmos/ethermint/indexer"
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newCallTrace(height uint64, from, to common.Address) *rpctypes.ParityTrace {
+	return &rpctypes.ParityTrace{
+		Type:         rpctypes.ParityTraceTypeCall,
+		BlockNumber:  height,
+		TraceAddress: []int{},
+		Action: rpctypes.ParityTraceAction{
+			CallType: "call",
+			From:     &from,
+			To:       &to,
+		},
+		Result: &rpctypes.ParityTraceResult{},
+	}
+}
+
+func TestKVIndexerTraces(t *testing.T) {
+	addr1 := common.BigToAddress(big.NewInt(1))
+	addr2 := common.BigToAddress(big.NewInt(2))
+	addr3 := common.BigToAddress(big.NewInt(3))
+
+	idxer := indexer.NewKVIndexer(dbm.NewMemDB(), tmlog.NewNopLogger(), client.Context{})
+
+	first, err := idxer.FirstIndexedTraceBlock()
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), first)
+	last, err := idxer.LastIndexedTraceBlock()
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), last)
+
+	// block 2 has no traces, the created contract of block 3 is indexed as the receiver
+	created := &rpctypes.ParityTrace{
+		Type:         rpctypes.ParityTraceTypeCreate,
+		BlockNumber:  3,
+		TraceAddress: []int{},
+		Action:       rpctypes.ParityTraceAction{From: &addr2},
+		Result:       &rpctypes.ParityTraceResult{Address: &addr3},
+	}
+	blocks := map[int64][]*rpctypes.ParityTrace{
+		1: {newCallTrace(1, addr1, addr2), newCallTrace(1, addr2, addr3)},
+		2: nil,
+		3: {created, newCallTrace(3, addr1, addr3)},
+	}
+	for height := int64(1); height <= 3; height++ {
+		require.NoError(t, idxer.IndexBlockTraces(height, blocks[height]))
+	}
+
+	first, err = idxer.FirstIndexedTraceBlock()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), first)
+	last, err = idxer.LastIndexedTraceBlock()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), last)
+
+	traces, err := idxer.GetBlockTraces(2)
+	require.NoError(t, err)
+	require.Empty(t, traces)
+	_, err = idxer.GetBlockTraces(4)
+	require.Error(t, err)
+
+	one := uint64(1)
+	testCases := []struct {
+		name      string
+		fromBlock int64
+		toBlock   int64
+		args      rpctypes.TraceFilterArgs
+		expTraces []*rpctypes.ParityTrace
+	}{
+		{
+			"no address",
+			1, 3,
+			rpctypes.TraceFilterArgs{},
+			[]*rpctypes.ParityTrace{blocks[1][0], blocks[1][1], created, blocks[3][1]},
+		},
+		{
+			"from address",
+			1, 3,
+			rpctypes.TraceFilterArgs{FromAddress: []common.Address{addr1}},
+			[]*rpctypes.ParityTrace{blocks[1][0], blocks[3][1]},
+		},
+		{
+			"from address in block range",
+			2, 3,
+			rpctypes.TraceFilterArgs{FromAddress: []common.Address{addr1}},
+			[]*rpctypes.ParityTrace{blocks[3][1]},
+		},
+		{
+			"to address includes the created contract",
+			1, 3,
+			rpctypes.TraceFilterArgs{ToAddress: []common.Address{addr3}},
+			[]*rpctypes.ParityTrace{blocks[1][1], created, blocks[3][1]},
+		},
+		{
+			"from and to addresses",
+			1, 3,
+			rpctypes.TraceFilterArgs{FromAddress: []common.Address{addr1, addr2}, ToAddress: []common.Address{addr3}},
+			[]*rpctypes.ParityTrace{blocks[1][1], created, blocks[3][1]},
+		},
+		{
+			"stops once after and count are covered",
+			1, 3,
+			rpctypes.TraceFilterArgs{After: &one, Count: &one},
+			[]*rpctypes.ParityTrace{blocks[1][0], blocks[1][1]},
+		},
+		{
+			"from address stops once count is covered",
+			1, 3,
+			rpctypes.TraceFilterArgs{FromAddress: []common.Address{addr1}, Count: &one},
+			[]*rpctypes.ParityTrace{blocks[1][0]},
+		},
+		{
+			"no match",
+			1, 3,
+			rpctypes.TraceFilterArgs{FromAddress: []common.Address{addr3}},
+			nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			traces, err := idxer.FilterTraces(tc.fromBlock, tc.toBlock, &tc.args)
+			require.NoError(t, err)
+			require.Equal(t, tc.expTraces, traces)
+		})
+	}
+}
+
+func TestKVIndexerFailedTraceBlock(t *testing.T) {
+	addr1 := common.BigToAddress(big.NewInt(1))
+	addr2 := common.BigToAddress(big.NewInt(2))
+
+	idxer := indexer.NewKVIndexer(dbm.NewMemDB(), tmlog.NewNopLogger(), client.Context{})
+	require.NoError(t, idxer.IndexBlockTraces(1, []*rpctypes.ParityTrace{newCallTrace(1, addr1, addr2)}))
+	require.NoError(t, idxer.IndexFailedTraceBlock(2, "tracer timeout"))
+
+	// the failed block is counted as indexed, so the indexer moves past it
+	last, err := idxer.LastIndexedTraceBlock()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), last)
+
+	require.NoError(t, idxer.IndexBlockTraces(3, []*rpctypes.ParityTrace{newCallTrace(3, addr1, addr2)}))
+	last, err = idxer.LastIndexedTraceBlock()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), last)
+
+	_, err = idxer.GetBlockTraces(2)
+	require.ErrorContains(t, err, "tracer timeout")
+
+	// the queries covering the failed block fail instead of missing its traces
+	_, err = idxer.FilterTraces(1, 3, &rpctypes.TraceFilterArgs{})
+	require.ErrorContains(t, err, "block 2")
+	_, err = idxer.FilterTraces(1, 3, &rpctypes.TraceFilterArgs{FromAddress: []common.Address{addr1}})
+	require.ErrorContains(t, err, "block 2")
+
+	traces, err := idxer.FilterTraces(3, 3, &rpctypes.TraceFilterArgs{FromAddress: []common.Address{addr1}})
+	require.NoError(t, err)
+	require.Len(t, traces, 1)
+
+	// the scan stops before the failed block once the count is covered
+	count := uint64(1)
+	traces, err = idxer.FilterTraces(1, 3, &rpctypes.TraceFilterArgs{Count: &count})
+	require.NoError(t, err)
+	require.Len(t, traces, 1)
+
+	// a failed first block
+	idxer = indexer.NewKVIndexer(dbm.NewMemDB(), tmlog.NewNopLogger(), client.Context{})
+	require.NoError(t, idxer.IndexFailedTraceBlock(5, "tracer timeout"))
+	require.NoError(t, idxer.IndexBlockTraces(6, nil))
+	first, err := idxer.FirstIndexedTraceBlock()
+	require.NoError(t, err)
+	require.Equal(t, int64(5), first)
+}
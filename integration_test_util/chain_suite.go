@@ -514,7 +514,7 @@ func (suite *ChainIntegrationTestSuite) RpcBackendAt(height int64) *rpcbackend.B
 	rpcServerCtx := server.NewDefaultContext()
 	rpcServerCtx.Viper.Set("json-rpc.gas-cap", 999_999_999)
 
-	rpcBackend := rpcbackend.NewBackend(rpcServerCtx, rpcServerCtx.Logger, queryClients.ClientQueryCtx, false, suite.EvmTxIndexer)
+	rpcBackend := rpcbackend.NewBackend(rpcServerCtx, rpcServerCtx.Logger, queryClients.ClientQueryCtx, false, suite.EvmTxIndexer, nil)
 
 	// override the query client with the mock query client, for changing query context
 	getFieldQueryClient := func() reflect.Value {
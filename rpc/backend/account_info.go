@@ -24,14 +24,21 @@ import (
 	sdkmath "cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/crypto"
 	rpctypes "github.com/evmos/ethermint/rpc/types"
 	evmtypes "github.com/evmos/ethermint/x/evm/types"
 	"github.com/pkg/errors"
 )
 
+// AccountRangeMaxResults is the maximum number of accounts returned by a 'debug_accountRange' call
+const AccountRangeMaxResults = 256
+
 // GetCode returns the contract code at the given address and block number.
 func (b *Backend) GetCode(address common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (hexutil.Bytes, error) {
 	blockNum, err := b.BlockNumberFromTendermint(blockNrOrHash)
@@ -45,7 +52,7 @@ func (b *Backend) GetCode(address common.Address, blockNrOrHash rpctypes.BlockNu
 
 	res, err := b.queryClient.Code(rpctypes.ContextWithHeight(blockNum.Int64()), req)
 	if err != nil {
-		return nil, err
+		return nil, b.prunedStateError(blockNum.Int64(), err)
 	}
 
 	return res.Code, nil
@@ -82,7 +89,7 @@ func (b *Backend) GetProof(address common.Address, storageKeys []string, blockNr
 
 	clientCtx := b.clientCtx.WithHeight(height)
 
-	// query storage proofs
+	// query storage proofs, the missing slots are proven absent with a zero value
 	storageProofs := make([]rpctypes.StorageResult, len(storageKeys))
 
 	for i, key := range storageKeys {
@@ -99,7 +106,7 @@ func (b *Backend) GetProof(address common.Address, storageKeys []string, blockNr
 		}
 	}
 
-	// query EVM account
+	// query EVM account, the missing accounts are empty
 	req := &evmtypes.QueryAccountRequest{
 		Address: address.String(),
 	}
@@ -111,7 +118,24 @@ func (b *Backend) GetProof(address common.Address, storageKeys []string, blockNr
 
 	// query account proofs
 	accountKey := authtypes.AddressStoreKey(sdk.AccAddress(address.Bytes()))
-	_, proof, err := b.queryClient.GetProof(clientCtx, authtypes.StoreKey, accountKey)
+	accountValue, proof, err := b.queryClient.GetProof(clientCtx, authtypes.StoreKey, accountKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// query balance proofs of the EVM denom
+	params, err := b.queryClient.Params(ctx, &evmtypes.QueryParamsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	balanceKey := append(banktypes.CreateAccountBalancesPrefix(address.Bytes()), []byte(params.Params.EvmDenom)...)
+	_, balanceProof, err := b.queryClient.GetProof(clientCtx, banktypes.StoreKey, balanceKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// query virtual frontier contract proofs, the code hash of the non EthAccount accounts depends on them
+	vfcValue, vfcProof, err := b.queryClient.GetProof(clientCtx, evmtypes.StoreKey, evmtypes.VirtualFrontierContractKey(address))
 	if err != nil {
 		return nil, err
 	}
@@ -129,6 +153,14 @@ func (b *Backend) GetProof(address common.Address, storageKeys []string, blockNr
 		Nonce:        hexutil.Uint64(res.Nonce),
 		StorageHash:  common.Hash{}, // NOTE: Ethermint doesn't have a storage hash. TODO: implement?
 		StorageProof: storageProofs,
+		ProofType:    rpctypes.ProofTypeICS23,
+		Height:       hexutil.Uint64(height),
+		AccountValue: accountValue,
+		BalanceProof: GetHexProofs(balanceProof),
+		VirtualFrontierContract: &rpctypes.VFCProofResult{
+			Value: vfcValue,
+			Proof: GetHexProofs(vfcProof),
+		},
 	}, nil
 }
 
@@ -146,13 +178,174 @@ func (b *Backend) GetStorageAt(address common.Address, key string, blockNrOrHash
 
 	res, err := b.queryClient.Storage(rpctypes.ContextWithHeight(blockNum.Int64()), req)
 	if err != nil {
-		return nil, err
+		return nil, b.prunedStateError(blockNum.Int64(), err)
 	}
 
 	value := common.HexToHash(res.Value)
 	return value.Bytes(), nil
 }
 
+// GetStorageStats returns the number of storage slots and the storage size of the contract at the given block number.
+func (b *Backend) GetStorageStats(address common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (*rpctypes.StorageStatsResult, error) {
+	blockNum, err := b.BlockNumberFromTendermint(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &evmtypes.QueryStorageStatsRequest{
+		Address: address.String(),
+	}
+
+	res, err := b.queryClient.StorageStats(rpctypes.ContextWithHeight(blockNum.Int64()), req)
+	if err != nil {
+		return nil, b.prunedStateError(blockNum.Int64(), err)
+	}
+
+	return &rpctypes.StorageStatsResult{
+		Address: address,
+		Slots:   hexutil.Uint64(res.Stats.Slots),
+		Bytes:   hexutil.Uint64(res.Stats.Bytes),
+	}, nil
+}
+
+// StorageRangeAt returns up to maxResult storage slots of the contract, starting at the key keyStart, in the state
+// of the block right before the transaction at the index txIndex is applied. The slots are ordered by key.
+func (b *Backend) StorageRangeAt(
+	blockHash common.Hash,
+	txIndex int,
+	address common.Address,
+	keyStart hexutil.Bytes,
+	maxResult int,
+) (rpctypes.StorageRangeResult, error) {
+	resBlock, err := b.TendermintBlockByHash(blockHash)
+	if err != nil {
+		return rpctypes.StorageRangeResult{}, err
+	}
+	if resBlock == nil || resBlock.Block == nil {
+		return rpctypes.StorageRangeResult{}, fmt.Errorf("block %s not found", blockHash.Hex())
+	}
+
+	blockRes, err := b.TendermintBlockResultByNumber(&resBlock.Block.Height)
+	if err != nil {
+		return rpctypes.StorageRangeResult{}, fmt.Errorf("block result not found for height %d", resBlock.Block.Height)
+	}
+
+	msgs := b.EthMsgsFromTendermintBlock(resBlock, blockRes)
+	if txIndex < 0 || txIndex > len(msgs) {
+		return rpctypes.StorageRangeResult{}, fmt.Errorf("transaction index %d out of range", txIndex)
+	}
+	if maxResult <= 0 {
+		return rpctypes.StorageRangeResult{}, errors.New("max result must be positive")
+	}
+
+	req := &evmtypes.QueryStorageRangeRequest{
+		Address:         address.String(),
+		Pagination:      &query.PageRequest{Limit: uint64(maxResult)},
+		Predecessors:    msgs[:txIndex],
+		BlockNumber:     resBlock.Block.Height,
+		BlockHash:       common.Bytes2Hex(resBlock.BlockID.Hash),
+		BlockTime:       resBlock.Block.Time,
+		ProposerAddress: sdk.ConsAddress(resBlock.Block.ProposerAddress),
+		ChainId:         b.chainID.Int64(),
+	}
+	if len(keyStart) > 0 {
+		req.Pagination.Key = common.BytesToHash(keyStart).Bytes()
+	}
+
+	// minus one to get the context of block beginning
+	contextHeight := resBlock.Block.Height - 1
+	if contextHeight < 1 {
+		// 0 is a special value in `ContextWithHeight`
+		contextHeight = 1
+	}
+
+	res, err := b.queryClient.StorageRange(rpctypes.ContextWithHeight(contextHeight), req)
+	if err != nil {
+		return rpctypes.StorageRangeResult{}, b.prunedStateError(contextHeight, err)
+	}
+
+	result := rpctypes.StorageRangeResult{
+		Storage: make(map[common.Hash]rpctypes.StorageEntry, len(res.Storage)),
+	}
+	for _, state := range res.Storage {
+		key := common.HexToHash(state.Key)
+		result.Storage[crypto.Keccak256Hash(key.Bytes())] = rpctypes.StorageEntry{
+			Key:   &key,
+			Value: common.HexToHash(state.Value),
+		}
+	}
+	if res.Pagination != nil && len(res.Pagination.NextKey) > 0 {
+		nextKey := common.BytesToHash(res.Pagination.NextKey)
+		result.NextKey = &nextKey
+	}
+
+	return result, nil
+}
+
+// AccountRange returns up to maxResults accounts of the state at the given block number, starting at the address start.
+// The accounts are ordered by address and maxResults is capped to AccountRangeMaxResults. The storage of each
+// account is capped to a page, the rest can be queried with StorageRangeAt from the storage next key.
+func (b *Backend) AccountRange(
+	blockNrOrHash rpctypes.BlockNumberOrHash,
+	start hexutil.Bytes,
+	maxResults int,
+	noCode, noStorage bool,
+) (rpctypes.AccountRangeResult, error) {
+	blockNum, err := b.BlockNumberFromTendermint(blockNrOrHash)
+	if err != nil {
+		return rpctypes.AccountRangeResult{}, err
+	}
+
+	if maxResults <= 0 || maxResults > AccountRangeMaxResults {
+		maxResults = AccountRangeMaxResults
+	}
+
+	req := &evmtypes.QueryAccountRangeRequest{
+		Pagination: &query.PageRequest{Limit: uint64(maxResults)},
+		NoCode:     noCode,
+		NoStorage:  noStorage,
+	}
+	if len(start) > 0 {
+		req.Pagination.Key = start
+	}
+
+	res, err := b.queryClient.AccountRange(rpctypes.ContextWithHeight(blockNum.Int64()), req)
+	if err != nil {
+		return rpctypes.AccountRangeResult{}, b.prunedStateError(blockNum.Int64(), err)
+	}
+
+	dump := rpctypes.AccountRangeResult{
+		Accounts: make(map[common.Address]rpctypes.AccountRangeAccount, len(res.Accounts)),
+	}
+	for _, entry := range res.Accounts {
+		address := common.HexToAddress(entry.Address)
+		account := state.DumpAccount{
+			Balance:  entry.Balance,
+			Nonce:    entry.Nonce,
+			CodeHash: entry.CodeHash,
+			Code:     entry.Code,
+			Address:  &address,
+		}
+		if len(entry.Storage) > 0 {
+			account.Storage = make(map[common.Hash]string, len(entry.Storage))
+			for _, slot := range entry.Storage {
+				account.Storage[common.HexToHash(slot.Key)] = common.Bytes2Hex(common.TrimLeftZeroes(common.HexToHash(slot.Value).Bytes()))
+			}
+		}
+		result := rpctypes.AccountRangeAccount{DumpAccount: account}
+		if len(entry.NextStorageKey) > 0 {
+			nextKey := common.BytesToHash(entry.NextStorageKey)
+			result.StorageNextKey = &nextKey
+		}
+		dump.Accounts[address] = result
+	}
+	if res.Pagination != nil && len(res.Pagination.NextKey) > 0 {
+		dump.Next = res.Pagination.NextKey
+	}
+
+	return dump, nil
+}
+
 // GetBalance returns the provided account's balance up to the provided block number.
 func (b *Backend) GetBalance(address common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (*hexutil.Big, error) {
 	blockNum, err := b.BlockNumberFromTendermint(blockNrOrHash)
@@ -169,12 +362,25 @@ func (b *Backend) GetBalance(address common.Address, blockNrOrHash rpctypes.Bloc
 		return nil, err
 	}
 
-	res, err := b.queryClient.Balance(rpctypes.ContextWithHeight(blockNum.Int64()), req)
-	if err != nil {
-		return nil, err
+	balance := ""
+	if blockNum == rpctypes.EthPendingBlockNumber {
+		acc, err := b.PendingAccount(address)
+		if err != nil {
+			return nil, err
+		}
+		if acc != nil {
+			balance = acc.Balance
+		}
+	}
+	if balance == "" {
+		res, err := b.queryClient.Balance(rpctypes.ContextWithHeight(blockNum.Int64()), req)
+		if err != nil {
+			return nil, b.prunedStateError(blockNum.Int64(), err)
+		}
+		balance = res.Balance
 	}
 
-	val, ok := sdkmath.NewIntFromString(res.Balance)
+	val, ok := sdkmath.NewIntFromString(balance)
 	if !ok {
 		return nil, errors.New("invalid balance")
 	}
@@ -213,12 +419,26 @@ func (b *Backend) GetTransactionCount(address common.Address, blockNum rpctypes.
 		return &n, nil
 	}
 
-	includePending := blockNum == rpctypes.EthPendingBlockNumber
-	nonce, err := b.getAccountNonce(address, includePending, blockNum.Int64(), b.logger)
+	nonce, err := b.getAccountNonce(address, false, blockNum.Int64(), b.logger)
 	if err != nil {
 		return nil, err
 	}
 
+	if blockNum == rpctypes.EthPendingBlockNumber {
+		acc, err := b.PendingAccount(address)
+		switch {
+		case err != nil:
+			// count the mempool transactions of the sender when the pending state can't be executed
+			b.logger.Error("failed to query the pending account", "address", address.Hex(), "error", err.Error())
+			nonce, err = b.getAccountNonce(address, true, blockNum.Int64(), b.logger)
+			if err != nil {
+				return nil, err
+			}
+		case acc != nil:
+			nonce = acc.Nonce
+		}
+	}
+
 	n = hexutil.Uint64(nonce)
 	return &n, nil
 }
@@ -6,9 +6,13 @@ import (
 
 	tmrpcclient "github.com/cometbft/cometbft/rpc/client"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/crypto"
 	"google.golang.org/grpc/metadata"
 
 	"github.com/evmos/ethermint/rpc/backend/mocks"
@@ -76,6 +80,69 @@ func (suite *BackendTestSuite) TestGetCode() {
 	}
 }
 
+func (suite *BackendTestSuite) TestGetStorageStats() {
+	blockNr := rpctypes.NewBlockNumber(big.NewInt(1))
+	stats := evmtypes.StorageStats{Slots: 2, Bytes: 128}
+
+	testCases := []struct {
+		name          string
+		addr          common.Address
+		blockNrOrHash rpctypes.BlockNumberOrHash
+		registerMock  func(common.Address)
+		expPass       bool
+		expResult     *rpctypes.StorageStatsResult
+	}{
+		{
+			"fail - BlockHash and BlockNumber are both nil",
+			tests.GenerateAddress(),
+			rpctypes.BlockNumberOrHash{},
+			func(addr common.Address) {},
+			false,
+			nil,
+		},
+		{
+			"fail - query client errors on getting StorageStats",
+			tests.GenerateAddress(),
+			rpctypes.BlockNumberOrHash{BlockNumber: &blockNr},
+			func(addr common.Address) {
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterStorageStatsError(queryClient, addr)
+			},
+			false,
+			nil,
+		},
+		{
+			"pass",
+			common.HexToAddress("0x1"),
+			rpctypes.BlockNumberOrHash{BlockNumber: &blockNr},
+			func(addr common.Address) {
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterStorageStats(queryClient, addr, stats)
+			},
+			true,
+			&rpctypes.StorageStatsResult{
+				Address: common.HexToAddress("0x1"),
+				Slots:   hexutil.Uint64(stats.Slots),
+				Bytes:   hexutil.Uint64(stats.Bytes),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.name), func() {
+			suite.SetupTest() // reset
+			tc.registerMock(tc.addr)
+
+			res, err := suite.backend.GetStorageStats(tc.addr, tc.blockNrOrHash)
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(tc.expResult, res)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
 func (suite *BackendTestSuite) TestGetProof() {
 	blockNrInvalid := rpctypes.NewBlockNumber(big.NewInt(1))
 	blockNr := rpctypes.NewBlockNumber(big.NewInt(4))
@@ -145,6 +212,21 @@ func (suite *BackendTestSuite) TestGetProof() {
 					authtypes.AddressStoreKey(sdk.AccAddress(address1.Bytes())),
 					tmrpcclient.ABCIQueryOptions{Height: iavlHeight, Prove: true},
 				)
+				RegisterParamsWithoutHeader(queryClient, bn.Int64())
+				RegisterABCIQueryWithOptions(
+					client,
+					bn.Int64(),
+					"store/bank/key",
+					append(banktypes.CreateAccountBalancesPrefix(address1.Bytes()), []byte(evmtypes.DefaultEVMDenom)...),
+					tmrpcclient.ABCIQueryOptions{Height: iavlHeight, Prove: true},
+				)
+				RegisterABCIQueryWithOptions(
+					client,
+					bn.Int64(),
+					"store/evm/key",
+					evmtypes.VirtualFrontierContractKey(address1),
+					tmrpcclient.ABCIQueryOptions{Height: iavlHeight, Prove: true},
+				)
 			},
 			true,
 			&rpctypes.AccountResult{
@@ -161,6 +243,14 @@ func (suite *BackendTestSuite) TestGetProof() {
 						Proof: []string{""},
 					},
 				},
+				ProofType:    rpctypes.ProofTypeICS23,
+				Height:       hexutil.Uint64(blockNr.Int64()),
+				AccountValue: []byte{2},
+				BalanceProof: []string{""},
+				VirtualFrontierContract: &rpctypes.VFCProofResult{
+					Value: []byte{2},
+					Proof: []string{""},
+				},
 			},
 		},
 	}
@@ -243,6 +333,185 @@ func (suite *BackendTestSuite) TestGetStorageAt() {
 	}
 }
 
+func (suite *BackendTestSuite) TestStorageRangeAt() {
+	msgEthTx, bz := suite.buildEthereumTx()
+	addr := tests.GenerateAddress()
+	key := common.BigToHash(big.NewInt(1))
+	value := common.BigToHash(big.NewInt(10))
+	nextKey := common.BigToHash(big.NewInt(2))
+
+	testCases := []struct {
+		name         string
+		txIndex      int
+		maxResult    int
+		registerMock func()
+		expResult    rpctypes.StorageRangeResult
+		expPass      bool
+	}{
+		{
+			"fail - block not found",
+			0,
+			1,
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterBlockByHashNotFound(client, common.Hash{}, bz)
+			},
+			rpctypes.StorageRangeResult{},
+			false,
+		},
+		{
+			"fail - transaction index out of range",
+			2,
+			1,
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterBlockByHash(client, common.Hash{}, bz)
+				RegisterBlockResults(client, 1)
+			},
+			rpctypes.StorageRangeResult{},
+			false,
+		},
+		{
+			"fail - max result is not positive",
+			0,
+			0,
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterBlockByHash(client, common.Hash{}, bz)
+				RegisterBlockResults(client, 1)
+			},
+			rpctypes.StorageRangeResult{},
+			false,
+		},
+		{
+			"pass - storage after the first transaction",
+			1,
+			1,
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				resBlock, _ := RegisterBlockByHash(client, common.Hash{}, bz)
+				RegisterBlockResults(client, 1)
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterStorageRange(queryClient, 1, &evmtypes.QueryStorageRangeRequest{
+					Address:         addr.String(),
+					Pagination:      &query.PageRequest{Key: key.Bytes(), Limit: 1},
+					Predecessors:    []*evmtypes.MsgEthereumTx{msgEthTx},
+					BlockNumber:     1,
+					BlockHash:       common.Bytes2Hex(resBlock.BlockID.Hash),
+					BlockTime:       resBlock.Block.Time,
+					ProposerAddress: sdk.ConsAddress(resBlock.Block.ProposerAddress),
+					ChainId:         suite.backend.chainID.Int64(),
+				}, &evmtypes.QueryStorageRangeResponse{
+					Storage:    evmtypes.Storage{evmtypes.NewState(key, value)},
+					Pagination: &query.PageResponse{NextKey: nextKey.Bytes()},
+				})
+			},
+			rpctypes.StorageRangeResult{
+				Storage: map[common.Hash]rpctypes.StorageEntry{
+					crypto.Keccak256Hash(key.Bytes()): {Key: &key, Value: value},
+				},
+				NextKey: &nextKey,
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.name), func() {
+			suite.SetupTest() // reset test and queries
+			tc.registerMock()
+
+			result, err := suite.backend.StorageRangeAt(common.Hash{}, tc.txIndex, addr, key.Bytes(), tc.maxResult)
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(tc.expResult, result)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
+func (suite *BackendTestSuite) TestAccountRange() {
+	blockNr := rpctypes.NewBlockNumber(big.NewInt(1))
+	addr := tests.GenerateAddress()
+	start := addr.Bytes()
+	nextStorageKey := common.BigToHash(big.NewInt(2))
+
+	testCases := []struct {
+		name         string
+		maxResults   int
+		registerMock func()
+		expDump      rpctypes.AccountRangeResult
+		expPass      bool
+	}{
+		{
+			"fail - query client errors on getting the accounts",
+			1,
+			func() {
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterAccountRangeError(queryClient, 1, &evmtypes.QueryAccountRangeRequest{
+					Pagination: &query.PageRequest{Key: start, Limit: 1},
+				})
+			},
+			rpctypes.AccountRangeResult{},
+			false,
+		},
+		{
+			"pass - max results are capped",
+			0,
+			func() {
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterAccountRange(queryClient, 1, &evmtypes.QueryAccountRangeRequest{
+					Pagination: &query.PageRequest{Key: start, Limit: AccountRangeMaxResults},
+				}, &evmtypes.QueryAccountRangeResponse{
+					Accounts: []evmtypes.AccountRangeEntry{{
+						Address:  addr.Hex(),
+						Balance:  "100",
+						Nonce:    1,
+						CodeHash: evmtypes.EmptyCodeHash,
+						Storage: evmtypes.Storage{
+							evmtypes.NewState(common.BigToHash(big.NewInt(1)), common.BigToHash(big.NewInt(10))),
+						},
+						NextStorageKey: common.BigToHash(big.NewInt(2)).Bytes(),
+					}},
+					Pagination: &query.PageResponse{},
+				})
+			},
+			rpctypes.AccountRangeResult{
+				Accounts: map[common.Address]rpctypes.AccountRangeAccount{
+					addr: {
+						DumpAccount: state.DumpAccount{
+							Balance:  "100",
+							Nonce:    1,
+							CodeHash: evmtypes.EmptyCodeHash,
+							Storage:  map[common.Hash]string{common.BigToHash(big.NewInt(1)): "0a"},
+							Address:  &addr,
+						},
+						StorageNextKey: &nextStorageKey,
+					},
+				},
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.name), func() {
+			suite.SetupTest() // reset test and queries
+			tc.registerMock()
+
+			dump, err := suite.backend.AccountRange(rpctypes.BlockNumberOrHash{BlockNumber: &blockNr}, start, tc.maxResults, false, false)
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(tc.expDump, dump)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
 func (suite *BackendTestSuite) TestGetBalance() {
 	blockNr := rpctypes.NewBlockNumber(big.NewInt(1))
 
@@ -347,6 +616,29 @@ func (suite *BackendTestSuite) TestGetBalance() {
 	}
 }
 
+func (suite *BackendTestSuite) TestGetBalancePrunedState() {
+	var header metadata.MD
+	addr := tests.GenerateAddress()
+	blockNr := rpctypes.NewBlockNumber(big.NewInt(3))
+
+	client := suite.backend.clientCtx.Client.(*mocks.Client)
+	queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+	RegisterBlock(client, 1, nil)
+	RegisterBalancePruned(queryClient, addr, blockNr.Int64())
+	RegisterParamsWithLatestHeight(queryClient, &header, 10)
+	RegisterStatus(client)
+	RegisterParamsWithoutHeader(queryClient, 5)
+	RegisterParamsPruned(queryClient, 3)
+	RegisterParamsWithoutHeader(queryClient, 4)
+
+	_, err := suite.backend.GetBalance(addr, rpctypes.BlockNumberOrHash{BlockNumber: &blockNr})
+	suite.Require().Error(err)
+
+	var prunedErr *evmtypes.PrunedStateError
+	suite.Require().ErrorAs(err, &prunedErr)
+	suite.Require().Equal(map[string]interface{}{"earliestHeight": hexutil.Uint64(4)}, prunedErr.ErrorData())
+}
+
 func (suite *BackendTestSuite) TestGetTransactionCount() {
 	testCases := []struct {
 		name         string
@@ -20,6 +20,7 @@ import (
 	tmrpcclient "github.com/cometbft/cometbft/rpc/client"
 	"math/big"
 	"strconv"
+	"sync"
 
 	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -60,6 +61,77 @@ func (b *Backend) BlockNumber() (hexutil.Uint64, error) {
 	return hexutil.Uint64(height), nil
 }
 
+// earliestStateCache holds the earliest height with available state found at the latest block height.
+type earliestStateCache struct {
+	mtx      sync.Mutex
+	latest   hexutil.Uint64
+	earliest hexutil.Uint64
+}
+
+// EarliestStateBlockNumber returns the earliest block height whose state is available on the node,
+// the state of the older heights has been pruned and can only be queried from an archive node.
+// The pruned heights are assumed to be a prefix of the heights, see below. The height is searched at most
+// once per latest block, the result is cached until the next block.
+func (b *Backend) EarliestStateBlockNumber() (hexutil.Uint64, error) {
+	latest, err := b.BlockNumber()
+	if err != nil {
+		return 0, err
+	}
+
+	// the concurrent callers wait for the running search rather than repeating it
+	b.earliestState.mtx.Lock()
+	defer b.earliestState.mtx.Unlock()
+	if b.earliestState.latest == latest && latest != 0 {
+		return b.earliestState.earliest, nil
+	}
+
+	status, err := b.clientCtx.Client.Status(b.ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// the state can't be older than the earliest block kept by the node
+	low := status.SyncInfo.EarliestBlockHeight
+	if low < 1 {
+		low = 1
+	}
+	high := int64(latest)
+
+	// NOTE: the binary search assumes the pruned heights are a prefix of the heights, which holds for the pruning
+	// strategies of the SDK: they remove every version older than the kept recent ones. The only exception are the
+	// heights kept for the state sync snapshots until the snapshot is taken, which can be returned instead of the
+	// start of the recent heights while a snapshot is in progress.
+	for low < high {
+		mid := low + (high-low)/2
+		available, err := b.isStateAvailable(mid)
+		if err != nil {
+			return 0, err
+		}
+		if available {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+
+	b.earliestState.latest = latest
+	b.earliestState.earliest = hexutil.Uint64(low)
+	return b.earliestState.earliest, nil
+}
+
+// isStateAvailable returns false if the state of the given height has been pruned.
+func (b *Backend) isStateAvailable(height int64) (bool, error) {
+	_, err := b.queryClient.Params(rpctypes.ContextWithHeight(height), &evmtypes.QueryParamsRequest{})
+	switch {
+	case err == nil:
+		return true, nil
+	case evmtypes.IsPrunedStateError(err):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
 // GetBlockByNumber returns the JSON-RPC compatible Ethereum block identified by
 // block number. Depending on fullTx it either returns the full transaction
 // objects or if false only the hashes of the transactions.
@@ -86,6 +158,9 @@ func (b *Backend) GetBlockByNumber(blockNum rpctypes.BlockNumber, fullTx bool) (
 		return nil, err
 	}
 
+	if blockNum == rpctypes.EthPendingBlockNumber {
+		return b.pendingBlock(res, fullTx)
+	}
 	return res, nil
 }
 
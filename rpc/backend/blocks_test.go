@@ -78,6 +78,108 @@ func (suite *BackendTestSuite) TestBlockNumber() {
 	}
 }
 
+func (suite *BackendTestSuite) TestEarliestStateBlockNumber() {
+	testCases := []struct {
+		name           string
+		registerMock   func()
+		expBlockNumber hexutil.Uint64
+		expPass        bool
+	}{
+		{
+			"fail - tendermint client failed to get status",
+			func() {
+				var header metadata.MD
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterParamsWithLatestHeight(queryClient, &header, 10)
+				RegisterStatusError(client)
+			},
+			0x0,
+			false,
+		},
+		{
+			"fail - query client failed to query params",
+			func() {
+				var header metadata.MD
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterParamsWithLatestHeight(queryClient, &header, 10)
+				RegisterStatus(client)
+				RegisterParamsWithoutHeaderError(queryClient, 5)
+			},
+			0x0,
+			false,
+		},
+		{
+			"pass - state is not pruned",
+			func() {
+				var header metadata.MD
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterParamsWithLatestHeight(queryClient, &header, 4)
+				RegisterStatus(client)
+				RegisterParamsWithoutHeader(queryClient, 2)
+				RegisterParamsWithoutHeader(queryClient, 1)
+			},
+			0x1,
+			true,
+		},
+		{
+			"pass - state below height 4 is pruned",
+			func() {
+				var header metadata.MD
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterParamsWithLatestHeight(queryClient, &header, 10)
+				RegisterStatus(client)
+				RegisterParamsWithoutHeader(queryClient, 5)
+				RegisterParamsPruned(queryClient, 3)
+				RegisterParamsWithoutHeader(queryClient, 4)
+			},
+			0x4,
+			true,
+		},
+	}
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.name), func() {
+			suite.SetupTest() // reset test and queries
+			tc.registerMock()
+
+			blockNumber, err := suite.backend.EarliestStateBlockNumber()
+
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(tc.expBlockNumber, blockNumber)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
+func (suite *BackendTestSuite) TestEarliestStateBlockNumberCache() {
+	var header metadata.MD
+	queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+	client := suite.backend.clientCtx.Client.(*mocks.Client)
+	RegisterParamsWithLatestHeight(queryClient, &header, 10)
+	RegisterStatus(client)
+	RegisterParamsWithoutHeader(queryClient, 5)
+	RegisterParamsPruned(queryClient, 3)
+	RegisterParamsWithoutHeader(queryClient, 4)
+
+	blockNumber, err := suite.backend.EarliestStateBlockNumber()
+	suite.Require().NoError(err)
+	suite.Require().Equal(hexutil.Uint64(4), blockNumber)
+	queryClient.AssertNumberOfCalls(suite.T(), "Params", 4)
+
+	// the height is not searched again until the next block
+	blockNumber, err = suite.backend.EarliestStateBlockNumber()
+	suite.Require().NoError(err)
+	suite.Require().Equal(hexutil.Uint64(4), blockNumber)
+	queryClient.AssertNumberOfCalls(suite.T(), "Params", 5)
+	client.AssertNumberOfCalls(suite.T(), "Status", 1)
+}
+
 func (suite *BackendTestSuite) TestGetBlockByNumber() {
 	var (
 		blockRes *tmrpctypes.ResultBlockResults
@@ -21,8 +21,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"time"
 
 	errorsmod "cosmossdk.io/errors"
+	tmtypes "github.com/cometbft/cometbft/types"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -176,6 +178,97 @@ func (b *Backend) SendRawTransaction(data hexutil.Bytes) (common.Hash, error) {
 	return txHash, nil
 }
 
+// txSyncTimeoutError is returned by `eth_sendRawTransactionSync` when the transaction isn't included before
+// the timeout, the transaction hash is passed as the error data so it can still be tracked.
+type txSyncTimeoutError struct {
+	hash common.Hash
+}
+
+func (e *txSyncTimeoutError) Error() string {
+	return fmt.Sprintf("transaction %s was not included before the timeout", e.hash.Hex())
+}
+
+// ErrorCode returns the JSON-RPC error code of the timeout.
+func (e *txSyncTimeoutError) ErrorCode() int {
+	return 4
+}
+
+// ErrorData returns the hash of the transaction.
+func (e *txSyncTimeoutError) ErrorData() interface{} {
+	return e.hash
+}
+
+// SendRawTransactionSync submits a raw ethereum transaction and waits for its inclusion in a block, it returns
+// the receipt of the transaction. The inclusion is detected from the events of txSub, which must be subscribed
+// before the call so the event can't be missed, instead of polling the receipt. The wait lasts up to the timeout
+// in milliseconds, capped by the node configuration which is also the default, after which an error carrying
+// the transaction hash is returned.
+func (b *Backend) SendRawTransactionSync(
+	ctx context.Context,
+	data hexutil.Bytes,
+	timeoutMs *hexutil.Uint64,
+	txSub rpctypes.TxEventSubscription,
+) (map[string]interface{}, error) {
+	tx := &ethtypes.Transaction{}
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	hash := tx.Hash()
+
+	timeout := b.RPCSendTxSyncTimeout()
+	if timeoutMs != nil {
+		if requested := time.Duration(*timeoutMs) * time.Millisecond; requested < timeout {
+			timeout = requested
+		}
+	}
+
+	// the events are received while the transaction is broadcasted
+	sendErrCh := make(chan error, 1)
+	go func() {
+		_, err := b.SendRawTransaction(data)
+		sendErrCh <- err
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case err := <-sendErrCh:
+			if err != nil {
+				return nil, err
+			}
+			sendErrCh = nil
+		case ev, ok := <-txSub.Event():
+			if !ok {
+				return nil, fmt.Errorf("tx event subscription closed while waiting for %s", hash.Hex())
+			}
+			eventData, ok := ev.Data.(tmtypes.EventDataTx)
+			if !ok {
+				b.logger.Debug("event data type mismatch", "type", fmt.Sprintf("%T", ev.Data))
+				continue
+			}
+			receipt, err := b.GetTransactionReceiptFromEvent(eventData, hash)
+			if err != nil {
+				return nil, err
+			}
+			if receipt != nil {
+				return receipt, nil
+			}
+		case err := <-txSub.Err():
+			return nil, err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			// the event could have been dropped by a lagging subscription
+			if receipt, err := b.GetTransactionReceipt(hash); err == nil && receipt != nil {
+				return receipt, nil
+			}
+			return nil, &txSyncTimeoutError{hash: hash}
+		}
+	}
+}
+
 // SetTxDefaults populates tx message with default values in case they are not
 // provided on the args
 func (b *Backend) SetTxDefaults(args evmtypes.TransactionArgs) (evmtypes.TransactionArgs, error) {
@@ -360,6 +453,11 @@ func (b *Backend) DoCall(
 		ProposerAddress: sdk.ConsAddress(header.Block.ProposerAddress),
 		ChainId:         b.chainID.Int64(),
 	}
+	if blockNr == rpctypes.EthPendingBlockNumber {
+		if req.PendingTxs, _, err = b.pendingTxs(); err != nil {
+			return nil, err
+		}
+	}
 
 	// From ContextWithHeight: if the provided height is 0,
 	// it will return an empty context and the gRPC query will use
@@ -382,7 +480,7 @@ func (b *Backend) DoCall(
 
 	res, err := b.queryClient.EthCall(ctx, &req)
 	if err != nil {
-		return nil, err
+		return nil, b.prunedStateError(blockNr.Int64(), err)
 	}
 
 	if res.Failed() {
@@ -1,10 +1,15 @@
 package backend
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 
+	abci "github.com/cometbft/cometbft/abci/types"
+	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -13,6 +18,7 @@ import (
 	rpctypes "github.com/evmos/ethermint/rpc/types"
 	"github.com/evmos/ethermint/tests"
 	evmtypes "github.com/evmos/ethermint/x/evm/types"
+	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -207,7 +213,7 @@ func (suite *BackendTestSuite) TestResend() {
 				RegisterEstimateGas(queryClient, callArgs)
 				RegisterParams(queryClient, &header, 1)
 				RegisterParamsWithoutHeader(queryClient, 1)
-				RegisterUnconfirmedTxsError(client, nil)
+				RegisterUnconfirmedTxsError(client, pendingTxsLimit())
 			},
 			evmtypes.TransactionArgs{
 				Nonce:                &txNonce,
@@ -235,7 +241,7 @@ func (suite *BackendTestSuite) TestResend() {
 				RegisterEstimateGas(queryClient, callArgs)
 				RegisterParams(queryClient, &header, 1)
 				RegisterParamsWithoutHeader(queryClient, 1)
-				RegisterUnconfirmedTxsEmpty(client, nil)
+				RegisterUnconfirmedTxsEmpty(client, pendingTxsLimit())
 			},
 			evmtypes.TransactionArgs{
 				Nonce:                &txNonce,
@@ -360,6 +366,118 @@ func (suite *BackendTestSuite) TestSendRawTransaction() {
 	}
 }
 
+// txEventSub is a tx event subscription fed by the test
+type txEventSub struct {
+	events chan tmrpctypes.ResultEvent
+	errs   chan error
+}
+
+func (s txEventSub) Event() <-chan tmrpctypes.ResultEvent { return s.events }
+func (s txEventSub) Err() <-chan error                    { return s.errs }
+
+func (suite *BackendTestSuite) TestSendRawTransactionSync() {
+	ethTx, _ := suite.buildEthereumTx()
+	txBytes := suite.signAndEncodeEthTx(ethTx)
+	rlpEncodedBz, _ := ethTx.AsTransaction().MarshalBinary()
+	txHash := common.HexToHash(ethTx.Hash)
+	timeoutMs := hexutil.Uint64(10)
+
+	includedEvent := tmrpctypes.ResultEvent{Data: tmtypes.EventDataTx{TxResult: abci.TxResult{
+		Height: 1,
+		Tx:     txBytes,
+		Result: abci.ResponseDeliverTx{
+			Events: []abci.Event{
+				{Type: evmtypes.EventTypeEthereumTx, Attributes: []abci.EventAttribute{
+					{Key: "ethereumTxHash", Value: txHash.Hex()},
+					{Key: "txIndex", Value: "0"},
+					{Key: "amount", Value: "1000"},
+					{Key: "txGasUsed", Value: "21000"},
+					{Key: "txHash", Value: ""},
+					{Key: "recipient", Value: ""},
+				}},
+			},
+		},
+	}}}
+
+	// the events are published once the transaction is broadcasted
+	registerBroadcastTx := func(sub txEventSub, events ...tmrpctypes.ResultEvent) {
+		client := suite.backend.clientCtx.Client.(*mocks.Client)
+		client.On("BroadcastTxSync", context.Background(), tmtypes.Tx(txBytes)).
+			Run(func(mock.Arguments) {
+				for _, ev := range events {
+					sub.events <- ev
+				}
+			}).
+			Return(&tmrpctypes.ResultBroadcastTx{}, nil)
+	}
+
+	testCases := []struct {
+		name         string
+		registerMock func(sub txEventSub)
+		expReceipt   bool
+		expTimeout   bool
+	}{
+		{
+			"fail - failed to broadcast transaction",
+			func(txEventSub) {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterParamsWithoutHeader(queryClient, 1)
+				RegisterBroadcastTxError(client, txBytes)
+			},
+			false,
+			false,
+		},
+		{
+			"fail - transaction not included before the timeout",
+			func(sub txEventSub) {
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterParamsWithoutHeader(queryClient, 1)
+				registerBroadcastTx(sub, tmrpctypes.ResultEvent{Data: "other event"})
+			},
+			false,
+			true,
+		},
+		{
+			"pass - receipt from the tx event",
+			func(sub txEventSub) {
+				var header metadata.MD
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterParamsWithoutHeader(queryClient, 1)
+				registerBroadcastTx(sub, includedEvent)
+				RegisterParams(queryClient, &header, 1)
+				RegisterBlock(client, 1, txBytes)
+				RegisterBlockResults(client, 1)
+			},
+			true,
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("case %s", tc.name), func() {
+			suite.SetupTest() // reset test and queries
+			suite.backend.allowUnprotectedTxs = true
+			sub := txEventSub{events: make(chan tmrpctypes.ResultEvent, 1), errs: make(chan error)}
+			tc.registerMock(sub)
+
+			receipt, err := suite.backend.SendRawTransactionSync(context.Background(), rlpEncodedBz, &timeoutMs, sub)
+			if tc.expReceipt {
+				suite.Require().NoError(err)
+				suite.Require().Equal(txHash, receipt["transactionHash"])
+				return
+			}
+			suite.Require().Error(err)
+			var timeoutErr *txSyncTimeoutError
+			suite.Require().Equal(tc.expTimeout, errors.As(err, &timeoutErr))
+			if tc.expTimeout {
+				suite.Require().Equal(txHash, timeoutErr.ErrorData())
+			}
+		})
+	}
+}
+
 func (suite *BackendTestSuite) TestDoCall() {
 	_, bz := suite.buildEthereumTx()
 	gasPrice := (*hexutil.Big)(big.NewInt(1))
@@ -112,12 +112,14 @@ func (b *Backend) CurrentHeader() *ethtypes.Header {
 
 // PendingTransactions returns the transactions that are in the transaction pool
 // and have a from address that is one of the accounts this node manages.
+// At most PendingTransactionsLimit transactions are returned, in the order of the mempool.
 func (b *Backend) PendingTransactions() ([]*sdk.Tx, error) {
 	mc, ok := b.clientCtx.Client.(tmrpcclient.MempoolClient)
 	if !ok {
 		return nil, errors.New("invalid rpc client")
 	}
-	res, err := mc.UnconfirmedTxs(b.ctx, nil)
+	limit := PendingTransactionsLimit
+	res, err := mc.UnconfirmedTxs(b.ctx, &limit)
 	if err != nil {
 		return nil, err
 	}
@@ -58,6 +58,13 @@ func RegisterBroadcastTxError(client *mocks.Client, tx types.Tx) {
 }
 
 // Unconfirmed Transactions
+
+// pendingTxsLimit returns the limit of the unconfirmed txs queried by PendingTransactions
+func pendingTxsLimit() *int {
+	limit := PendingTransactionsLimit
+	return &limit
+}
+
 func RegisterUnconfirmedTxs(client *mocks.Client, limit *int, txs []types.Tx) {
 	client.On("UnconfirmedTxs", rpc.ContextWithHeight(1), limit).
 		Return(&tmrpctypes.ResultUnconfirmedTxs{Txs: txs}, nil)
@@ -210,6 +217,18 @@ func RegisterBlockResults(
 	return res, nil
 }
 
+// RegisterBlockResultsRejected registers the block results of a block whose only tx was rejected in DeliverTx
+func RegisterBlockResultsRejected(client *mocks.Client, height int64, log string) *tmrpctypes.ResultBlockResults {
+	res := &tmrpctypes.ResultBlockResults{
+		Height:     height,
+		TxsResults: []*abci.ResponseDeliverTx{{Code: 11, Log: log}},
+	}
+
+	client.On("BlockResults", rpc.ContextWithHeight(height), mock.AnythingOfType("*int64")).
+		Return(res, nil)
+	return res
+}
+
 func RegisterBlockResultsError(client *mocks.Client, height int64) {
 	client.On("BlockResults", rpc.ContextWithHeight(height), mock.AnythingOfType("*int64")).
 		Return(nil, errortypes.ErrInvalidRequest)
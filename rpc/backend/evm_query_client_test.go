@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strconv"
 	"testing"
 
@@ -44,6 +45,12 @@ func RegisterTraceTransaction(queryClient *mocks.EVMQueryClient, msgEthTx *evmty
 		Return(&evmtypes.QueryTraceTxResponse{Data: data}, nil)
 }
 
+func RegisterTraceTransactionWithReplay(queryClient *mocks.EVMQueryClient, msgEthTx *evmtypes.MsgEthereumTx, replay *evmtypes.TraceReplayResult) {
+	data := []byte{0x7b, 0x22, 0x74, 0x65, 0x73, 0x74, 0x22, 0x3a, 0x20, 0x22, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x22, 0x7d}
+	queryClient.On("TraceTx", rpc.ContextWithHeight(1), &evmtypes.QueryTraceTxRequest{Msg: msgEthTx, BlockNumber: 1, ChainId: 9000}).
+		Return(&evmtypes.QueryTraceTxResponse{Data: data, Replay: replay}, nil)
+}
+
 func RegisterTraceTransactionError(queryClient *mocks.EVMQueryClient, msgEthTx *evmtypes.MsgEthereumTx) {
 	queryClient.On("TraceTx", rpc.ContextWithHeight(1), &evmtypes.QueryTraceTxRequest{Msg: msgEthTx, BlockNumber: 1, ChainId: 9000}).
 		Return(nil, errortypes.ErrInvalidRequest)
@@ -57,11 +64,59 @@ func RegisterTraceBlock(queryClient *mocks.EVMQueryClient, txs []*evmtypes.MsgEt
 		Return(&evmtypes.QueryTraceBlockResponse{Data: data}, nil)
 }
 
+// RegisterTraceBlockRequest registers a TraceBlock query returning the given trace results
+func RegisterTraceBlockRequest(queryClient *mocks.EVMQueryClient, height int64, request *evmtypes.QueryTraceBlockRequest, data []byte) {
+	queryClient.On("TraceBlock", rpc.ContextWithHeight(height), request).
+		Return(&evmtypes.QueryTraceBlockResponse{Data: data}, nil)
+}
+
+// traceBlockStreamClient replays the given responses of a TraceBlockStream query
+type traceBlockStreamClient struct {
+	grpc.ClientStream
+	responses []*evmtypes.QueryTraceBlockStreamResponse
+	err       error
+}
+
+func (s *traceBlockStreamClient) Recv() (*evmtypes.QueryTraceBlockStreamResponse, error) {
+	if len(s.responses) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	res := s.responses[0]
+	s.responses = s.responses[1:]
+	return res, nil
+}
+
+// RegisterTraceBlockStream registers a TraceBlockStream query streaming the given responses, followed by err if not nil
+func RegisterTraceBlockStream(
+	queryClient *mocks.EVMQueryClient,
+	request *evmtypes.QueryTraceBlockRequest,
+	responses []*evmtypes.QueryTraceBlockStreamResponse,
+	err error,
+) {
+	queryClient.On("TraceBlockStream", mock.Anything, request).
+		Return(&traceBlockStreamClient{responses: responses, err: err}, nil)
+}
+
 func RegisterTraceBlockError(queryClient *mocks.EVMQueryClient) {
 	queryClient.On("TraceBlock", rpc.ContextWithHeight(1), &evmtypes.QueryTraceBlockRequest{}).
 		Return(nil, errortypes.ErrInvalidRequest)
 }
 
+// TraceCall
+func RegisterTraceCall(queryClient *mocks.EVMQueryClient, request *evmtypes.QueryTraceCallRequest) {
+	data := []byte{0x7b, 0x22, 0x74, 0x65, 0x73, 0x74, 0x22, 0x3a, 0x20, 0x22, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x22, 0x7d}
+	queryClient.On("TraceCall", rpc.ContextWithHeight(1), request).
+		Return(&evmtypes.QueryTraceCallResponse{Data: data}, nil)
+}
+
+func RegisterTraceCallError(queryClient *mocks.EVMQueryClient, request *evmtypes.QueryTraceCallRequest) {
+	queryClient.On("TraceCall", rpc.ContextWithHeight(1), request).
+		Return(nil, errortypes.ErrInvalidRequest)
+}
+
 // Params
 func RegisterParams(queryClient *mocks.EVMQueryClient, header *metadata.MD, height int64) {
 	queryClient.On("Params", rpc.ContextWithHeight(height), &evmtypes.QueryParamsRequest{}, grpc.Header(header)).
@@ -103,6 +158,29 @@ func RegisterParamsInvalidHeight(queryClient *mocks.EVMQueryClient, header *meta
 		})
 }
 
+// RegisterParamsWithLatestHeight registers a Params query on the latest height returning the given height in the header
+func RegisterParamsWithLatestHeight(queryClient *mocks.EVMQueryClient, header *metadata.MD, height int64) {
+	queryClient.On("Params", rpc.ContextWithHeight(1), &evmtypes.QueryParamsRequest{}, grpc.Header(header)).
+		Return(&evmtypes.QueryParamsResponse{}, nil).
+		Run(func(args mock.Arguments) {
+			arg := args.Get(2).(grpc.HeaderCallOption)
+			h := metadata.MD{}
+			h.Set(grpctypes.GRPCBlockHeightHeader, fmt.Sprint(height))
+			*arg.HeaderAddr = h
+		})
+}
+
+// RegisterParamsPruned registers a Params query on a height whose state has been pruned
+func RegisterParamsPruned(queryClient *mocks.EVMQueryClient, height int64) {
+	queryClient.On("Params", rpc.ContextWithHeight(height), &evmtypes.QueryParamsRequest{}).
+		Return(nil, prunedStateError(height))
+}
+
+// prunedStateError returns the error of a query on a pruned height as converted by the query client
+func prunedStateError(height int64) error {
+	return evmtypes.ConvertPrunedStateError(evmtypes.NewStatePrunedStatusError(height))
+}
+
 func RegisterParamsWithoutHeaderError(queryClient *mocks.EVMQueryClient, height int64) {
 	queryClient.On("Params", rpc.ContextWithHeight(height), &evmtypes.QueryParamsRequest{}).
 		Return(nil, errortypes.ErrInvalidRequest)
@@ -231,6 +309,17 @@ func RegisterCodeError(queryClient *mocks.EVMQueryClient, addr common.Address) {
 		Return(nil, errortypes.ErrInvalidRequest)
 }
 
+// StorageStats
+func RegisterStorageStats(queryClient *mocks.EVMQueryClient, addr common.Address, stats evmtypes.StorageStats) {
+	queryClient.On("StorageStats", rpc.ContextWithHeight(1), &evmtypes.QueryStorageStatsRequest{Address: addr.String()}).
+		Return(&evmtypes.QueryStorageStatsResponse{Stats: stats}, nil)
+}
+
+func RegisterStorageStatsError(queryClient *mocks.EVMQueryClient, addr common.Address) {
+	queryClient.On("StorageStats", rpc.ContextWithHeight(1), &evmtypes.QueryStorageStatsRequest{Address: addr.String()}).
+		Return(nil, errortypes.ErrInvalidRequest)
+}
+
 // Storage
 func RegisterStorageAt(queryClient *mocks.EVMQueryClient, addr common.Address, key string, storage string) {
 	queryClient.On("Storage", rpc.ContextWithHeight(1), &evmtypes.QueryStorageRequest{Address: addr.String(), Key: key}).
@@ -273,3 +362,36 @@ func RegisterBalanceError(queryClient *mocks.EVMQueryClient, addr common.Address
 	queryClient.On("Balance", rpc.ContextWithHeight(height), &evmtypes.QueryBalanceRequest{Address: addr.String()}).
 		Return(nil, errortypes.ErrInvalidRequest)
 }
+
+func RegisterBalancePruned(queryClient *mocks.EVMQueryClient, addr common.Address, height int64) {
+	queryClient.On("Balance", rpc.ContextWithHeight(height), &evmtypes.QueryBalanceRequest{Address: addr.String()}).
+		Return(nil, prunedStateError(height))
+}
+
+// StorageRange
+func RegisterStorageRange(queryClient *mocks.EVMQueryClient, height int64, request *evmtypes.QueryStorageRangeRequest, res *evmtypes.QueryStorageRangeResponse) {
+	queryClient.On("StorageRange", rpc.ContextWithHeight(height), request).
+		Return(res, nil)
+}
+
+// AccountRange
+func RegisterAccountRange(queryClient *mocks.EVMQueryClient, height int64, request *evmtypes.QueryAccountRangeRequest, res *evmtypes.QueryAccountRangeResponse) {
+	queryClient.On("AccountRange", rpc.ContextWithHeight(height), request).
+		Return(res, nil)
+}
+
+func RegisterAccountRangeError(queryClient *mocks.EVMQueryClient, height int64, request *evmtypes.QueryAccountRangeRequest) {
+	queryClient.On("AccountRange", rpc.ContextWithHeight(height), request).
+		Return(nil, errortypes.ErrInvalidRequest)
+}
+
+// PendingState
+func RegisterPendingState(queryClient *mocks.EVMQueryClient, height int64, res *evmtypes.QueryPendingStateResponse) {
+	queryClient.On("PendingState", rpc.ContextWithHeight(height), mock.AnythingOfType("*types.QueryPendingStateRequest")).
+		Return(res, nil).Once()
+}
+
+func RegisterPendingStateError(queryClient *mocks.EVMQueryClient, height int64) {
+	queryClient.On("PendingState", rpc.ContextWithHeight(height), mock.AnythingOfType("*types.QueryPendingStateRequest")).
+		Return(nil, errortypes.ErrInvalidRequest)
+}
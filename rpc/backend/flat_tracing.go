@@ -0,0 +1,306 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+	evmtypes "github.com/evmos/ethermint/x/evm/types"
+	"github.com/pkg/errors"
+)
+
+// callTracer is the name of the native tracer the flat call traces are built from.
+const callTracer = "callTracer"
+
+// callFrame is the output of the callTracer.
+type callFrame struct {
+	Type    string         `json:"type"`
+	From    common.Address `json:"from"`
+	To      string         `json:"to,omitempty"`
+	Value   *hexutil.Big   `json:"value,omitempty"`
+	Gas     hexutil.Uint64 `json:"gas"`
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+	Input   hexutil.Bytes  `json:"input"`
+	Output  hexutil.Bytes  `json:"output,omitempty"`
+	Error   string         `json:"error,omitempty"`
+	Calls   []callFrame    `json:"calls,omitempty"`
+}
+
+// TraceBlockFlat returns the flat call traces of all the transactions in the block, it reads the trace index
+// if the block is indexed, otherwise the block is replayed.
+func (b *Backend) TraceBlockFlat(blockNum rpctypes.BlockNumber) ([]*rpctypes.ParityTrace, error) {
+	resBlock, err := b.TendermintBlockByNumber(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if resBlock == nil {
+		return nil, fmt.Errorf("block %d not found", blockNum)
+	}
+	if resBlock.Block.Height == 0 {
+		return nil, errors.New("genesis is not traceable")
+	}
+
+	if idx := b.traceIndexer(resBlock.Block.Height, resBlock.Block.Height); idx != nil {
+		return idx.GetBlockTraces(resBlock.Block.Height)
+	}
+
+	blockRes, err := b.TendermintBlockResultByNumber(&resBlock.Block.Height)
+	if err != nil {
+		return nil, err
+	}
+	msgs := b.EthMsgsFromTendermintBlock(resBlock, blockRes)
+	results, err := b.TraceBlock(rpctypes.BlockNumber(resBlock.Block.Height), &evmtypes.TraceConfig{Tracer: callTracer}, resBlock)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(msgs) {
+		return nil, fmt.Errorf("trace results mismatch the transactions of block %d: %d != %d", resBlock.Block.Height, len(results), len(msgs))
+	}
+
+	traces := []*rpctypes.ParityTrace{}
+	for i, result := range results {
+		if result.Error != "" {
+			return nil, fmt.Errorf("failed to trace transaction %s: %s", msgs[i].Hash, result.Error)
+		}
+		txTraces, err := flattenTraceResult(result.Result, resBlock, common.HexToHash(msgs[i].Hash), uint64(i))
+		if err != nil {
+			return nil, err
+		}
+		traces = append(traces, txTraces...)
+	}
+	return traces, nil
+}
+
+// TraceTransactionFlat returns the flat call traces of the transaction.
+func (b *Backend) TraceTransactionFlat(hash common.Hash) ([]*rpctypes.ParityTrace, error) {
+	transaction, err := b.GetTxByEthHash(hash)
+	if err != nil {
+		b.logger.Debug("tx not found", "hash", hash)
+		return nil, err
+	}
+
+	if idx := b.traceIndexer(transaction.Height, transaction.Height); idx != nil {
+		blockTraces, err := idx.GetBlockTraces(transaction.Height)
+		if err != nil {
+			return nil, err
+		}
+		traces := []*rpctypes.ParityTrace{}
+		for _, trace := range blockTraces {
+			if trace.TransactionHash == hash {
+				traces = append(traces, trace)
+			}
+		}
+		return traces, nil
+	}
+
+	resBlock, err := b.TendermintBlockByNumber(rpctypes.BlockNumber(transaction.Height))
+	if err != nil {
+		return nil, err
+	}
+	if resBlock == nil {
+		return nil, fmt.Errorf("block %d not found", transaction.Height)
+	}
+	result, err := b.TraceTransaction(hash, &evmtypes.TraceConfig{Tracer: callTracer})
+	if err != nil {
+		return nil, err
+	}
+	return flattenTraceResult(result, resBlock, hash, uint64(transaction.EthTxIndex))
+}
+
+// TraceFilter returns the flat call traces in the block range matching the filter addresses. The trace index is
+// used if it covers the whole range, otherwise the blocks are replayed, limited by the trace filter range cap.
+func (b *Backend) TraceFilter(args rpctypes.TraceFilterArgs) ([]*rpctypes.ParityTrace, error) {
+	latest, err := b.BlockNumber()
+	if err != nil {
+		return nil, err
+	}
+	resolve := func(blockNum *rpctypes.BlockNumber, defaultHeight int64) int64 {
+		switch {
+		case blockNum == nil:
+			return defaultHeight
+		case *blockNum == rpctypes.EthLatestBlockNumber, *blockNum == rpctypes.EthPendingBlockNumber:
+			return int64(latest)
+		case *blockNum == rpctypes.EthEarliestBlockNumber:
+			// genesis is not traceable
+			return 1
+		default:
+			return blockNum.Int64()
+		}
+	}
+	fromBlock := resolve(args.FromBlock, 1)
+	toBlock := resolve(args.ToBlock, int64(latest))
+	if fromBlock > toBlock {
+		return nil, fmt.Errorf("invalid block range: from block %d is greater than to block %d", fromBlock, toBlock)
+	}
+	if toBlock > int64(latest) {
+		return nil, fmt.Errorf("to block %d is greater than the latest block %d", toBlock, latest)
+	}
+
+	var traces []*rpctypes.ParityTrace
+	if idx := b.traceIndexer(fromBlock, toBlock); idx != nil {
+		if traces, err = idx.FilterTraces(fromBlock, toBlock, &args); err != nil {
+			return nil, err
+		}
+	} else {
+		rangeCap := int64(b.cfg.JSONRPC.TraceFilterRangeCap)
+		if toBlock-fromBlock+1 > rangeCap {
+			return nil, fmt.Errorf("block range %d exceeds the trace filter range cap %d, enable the trace indexer or narrow the range", toBlock-fromBlock+1, rangeCap)
+		}
+		// the blocks after the ones covering After+Count are not replayed
+		for height := fromBlock; height <= toBlock && !args.Enough(len(traces)); height++ {
+			blockTraces, err := b.TraceBlockFlat(rpctypes.BlockNumber(height))
+			if err != nil {
+				return nil, err
+			}
+			for _, trace := range blockTraces {
+				if args.Match(trace) {
+					traces = append(traces, trace)
+				}
+			}
+		}
+	}
+
+	if args.After != nil {
+		if *args.After >= uint64(len(traces)) {
+			return []*rpctypes.ParityTrace{}, nil
+		}
+		traces = traces[*args.After:]
+	}
+	if args.Count != nil && *args.Count < uint64(len(traces)) {
+		traces = traces[:*args.Count]
+	}
+	if traces == nil {
+		traces = []*rpctypes.ParityTrace{}
+	}
+	return traces, nil
+}
+
+// traceIndexer returns the trace index if it covers the block range, otherwise returns nil.
+func (b *Backend) traceIndexer(fromBlock, toBlock int64) rpctypes.TraceIndexer {
+	idx, ok := b.indexer.(rpctypes.TraceIndexer)
+	if !ok {
+		return nil
+	}
+	first, err := idx.FirstIndexedTraceBlock()
+	if err != nil || first == -1 || fromBlock < first {
+		return nil
+	}
+	last, err := idx.LastIndexedTraceBlock()
+	if err != nil || toBlock > last {
+		return nil
+	}
+	return idx
+}
+
+// flattenTraceResult converts the callTracer result of a transaction to flat call traces.
+func flattenTraceResult(result interface{}, resBlock *tmrpctypes.ResultBlock, txHash common.Hash, txIndex uint64) ([]*rpctypes.ParityTrace, error) {
+	bz, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	var frame callFrame
+	if err := json.Unmarshal(bz, &frame); err != nil {
+		return nil, errors.Wrap(err, "failed to decode callTracer result")
+	}
+	base := rpctypes.ParityTrace{
+		BlockHash:           common.BytesToHash(resBlock.Block.Hash()),
+		BlockNumber:         uint64(resBlock.Block.Height),
+		TransactionHash:     txHash,
+		TransactionPosition: txIndex,
+	}
+	return flattenCallFrame(&frame, []int{}, base), nil
+}
+
+// flattenCallFrame converts a call frame and its sub calls to flat call traces in depth-first order.
+func flattenCallFrame(frame *callFrame, traceAddress []int, base rpctypes.ParityTrace) []*rpctypes.ParityTrace {
+	trace := base
+	trace.TraceAddress = traceAddress
+	trace.Subtraces = len(frame.Calls)
+	if frame.Error != "" {
+		trace.Error = frame.Error
+		if frame.Error == vm.ErrExecutionReverted.Error() {
+			trace.Error = "Reverted"
+		}
+	}
+
+	value := frame.Value
+	if value == nil {
+		value = new(hexutil.Big)
+	}
+	gas := frame.Gas
+	from := frame.From
+
+	switch frame.Type {
+	case vm.CREATE.String(), vm.CREATE2.String():
+		init := frame.Input
+		trace.Type = rpctypes.ParityTraceTypeCreate
+		trace.Action = rpctypes.ParityTraceAction{
+			CreationMethod: strings.ToLower(frame.Type),
+			From:           &from,
+			Gas:            &gas,
+			Init:           &init,
+			Value:          value,
+		}
+		if frame.Error == "" {
+			code := frame.Output
+			trace.Result = &rpctypes.ParityTraceResult{GasUsed: frame.GasUsed, Code: &code}
+			if frame.To != "" {
+				address := common.HexToAddress(frame.To)
+				trace.Result.Address = &address
+			}
+		}
+	case vm.SELFDESTRUCT.String():
+		refundAddress := common.HexToAddress(frame.To)
+		trace.Type = rpctypes.ParityTraceTypeSuicide
+		trace.Action = rpctypes.ParityTraceAction{
+			Address:       &from,
+			RefundAddress: &refundAddress,
+			Balance:       value,
+		}
+	default:
+		input := frame.Input
+		to := common.HexToAddress(frame.To)
+		trace.Type = rpctypes.ParityTraceTypeCall
+		trace.Action = rpctypes.ParityTraceAction{
+			CallType: strings.ToLower(frame.Type),
+			From:     &from,
+			To:       &to,
+			Gas:      &gas,
+			Input:    &input,
+			Value:    value,
+		}
+		if frame.Error == "" {
+			output := frame.Output
+			trace.Result = &rpctypes.ParityTraceResult{GasUsed: frame.GasUsed, Output: &output}
+		}
+	}
+
+	traces := []*rpctypes.ParityTrace{&trace}
+	for i := range frame.Calls {
+		subTraceAddress := make([]int, len(traceAddress)+1)
+		copy(subTraceAddress, traceAddress)
+		subTraceAddress[len(traceAddress)] = i
+		traces = append(traces, flattenCallFrame(&frame.Calls[i], subTraceAddress, base)...)
+	}
+	return traces
+}
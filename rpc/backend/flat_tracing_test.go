@@ -0,0 +1,209 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package backend
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/evmos/ethermint/indexer"
+	"github.com/evmos/ethermint/rpc/backend/mocks"
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+	"google.golang.org/grpc/metadata"
+)
+
+func (suite *BackendTestSuite) TestFlattenCallFrame() {
+	sender := common.BigToAddress(big.NewInt(1))
+	contract := common.BigToAddress(big.NewInt(2))
+	created := common.BigToAddress(big.NewInt(3))
+	beneficiary := common.BigToAddress(big.NewInt(4))
+
+	frame := &callFrame{
+		Type:    "CALL",
+		From:    sender,
+		To:      contract.Hex(),
+		Value:   (*hexutil.Big)(big.NewInt(10)),
+		Gas:     100000,
+		GasUsed: 50000,
+		Input:   hexutil.Bytes{0x01},
+		Output:  hexutil.Bytes{0x02},
+		Calls: []callFrame{
+			{
+				Type:    "CREATE2",
+				From:    contract,
+				To:      created.Hex(),
+				Gas:     40000,
+				GasUsed: 30000,
+				Input:   hexutil.Bytes{0x60},
+				Output:  hexutil.Bytes{0x61},
+				Calls: []callFrame{
+					{Type: "SELFDESTRUCT", From: created, To: beneficiary.Hex(), Value: (*hexutil.Big)(big.NewInt(5))},
+				},
+			},
+			{
+				Type:  "STATICCALL",
+				From:  contract,
+				To:    sender.Hex(),
+				Gas:   1000,
+				Error: "execution reverted",
+			},
+		},
+	}
+	base := rpctypes.ParityTrace{BlockNumber: 1, TransactionPosition: 2}
+
+	traces := flattenCallFrame(frame, []int{}, base)
+	suite.Require().Len(traces, 4)
+
+	suite.Require().Equal(rpctypes.ParityTraceTypeCall, traces[0].Type)
+	suite.Require().Equal("call", traces[0].Action.CallType)
+	suite.Require().Equal([]int{}, traces[0].TraceAddress)
+	suite.Require().Equal(2, traces[0].Subtraces)
+	suite.Require().Equal(hexutil.Uint64(50000), traces[0].Result.GasUsed)
+	suite.Require().Equal(uint64(2), traces[0].TransactionPosition)
+
+	suite.Require().Equal(rpctypes.ParityTraceTypeCreate, traces[1].Type)
+	suite.Require().Equal("create2", traces[1].Action.CreationMethod)
+	suite.Require().Equal([]int{0}, traces[1].TraceAddress)
+	suite.Require().Equal(created, *traces[1].Result.Address)
+	suite.Require().Equal(created, *traces[1].ToAddress())
+
+	suite.Require().Equal(rpctypes.ParityTraceTypeSuicide, traces[2].Type)
+	suite.Require().Equal([]int{0, 0}, traces[2].TraceAddress)
+	suite.Require().Equal(created, *traces[2].FromAddress())
+	suite.Require().Equal(beneficiary, *traces[2].ToAddress())
+	suite.Require().Equal(big.NewInt(5), traces[2].Action.Balance.ToInt())
+
+	suite.Require().Equal("staticcall", traces[3].Action.CallType)
+	suite.Require().Equal([]int{1}, traces[3].TraceAddress)
+	suite.Require().Equal("Reverted", traces[3].Error)
+	suite.Require().Nil(traces[3].Result)
+}
+
+func (suite *BackendTestSuite) TestTraceFilter() {
+	from := common.BigToAddress(big.NewInt(1))
+	to := common.BigToAddress(big.NewInt(2))
+	other := common.BigToAddress(big.NewInt(3))
+	newTrace := func(height uint64, from, to common.Address) *rpctypes.ParityTrace {
+		return &rpctypes.ParityTrace{
+			Type:         rpctypes.ParityTraceTypeCall,
+			BlockNumber:  height,
+			TraceAddress: []int{},
+			Action:       rpctypes.ParityTraceAction{CallType: "call", From: &from, To: &to},
+			Result:       &rpctypes.ParityTraceResult{},
+		}
+	}
+	one := uint64(1)
+	blockNum := func(n int64) *rpctypes.BlockNumber {
+		bn := rpctypes.BlockNumber(n)
+		return &bn
+	}
+
+	testCases := []struct {
+		name         string
+		registerMock func()
+		args         rpctypes.TraceFilterArgs
+		expHeights   []uint64
+		expPass      bool
+	}{
+		{
+			"fail - range exceeds the cap without trace index",
+			func() {
+				var header metadata.MD
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterParamsWithLatestHeight(queryClient, &header, 2)
+				suite.backend.cfg.JSONRPC.TraceFilterRangeCap = 1
+			},
+			rpctypes.TraceFilterArgs{},
+			nil,
+			false,
+		},
+		{
+			"fail - from block greater than to block",
+			func() {
+				var header metadata.MD
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterParamsWithLatestHeight(queryClient, &header, 2)
+			},
+			rpctypes.TraceFilterArgs{FromBlock: blockNum(2), ToBlock: blockNum(1)},
+			nil,
+			false,
+		},
+		{
+			"pass - filter from address on the trace index",
+			func() {
+				var header metadata.MD
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterParamsWithLatestHeight(queryClient, &header, 2)
+			},
+			rpctypes.TraceFilterArgs{FromAddress: []common.Address{from}},
+			[]uint64{1, 2},
+			true,
+		},
+		{
+			"pass - filter to address with after and count on the trace index",
+			func() {
+				var header metadata.MD
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterParamsWithLatestHeight(queryClient, &header, 2)
+			},
+			rpctypes.TraceFilterArgs{ToAddress: []common.Address{to, other}, After: &one, Count: &one},
+			[]uint64{2},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset test and queries
+			tc.registerMock()
+
+			if tc.expPass {
+				idxer := suite.backend.indexer.(*indexer.KVIndexer)
+				suite.Require().NoError(idxer.IndexBlockTraces(1, []*rpctypes.ParityTrace{newTrace(1, from, to), newTrace(1, other, from)}))
+				suite.Require().NoError(idxer.IndexBlockTraces(2, []*rpctypes.ParityTrace{newTrace(2, from, other)}))
+			}
+
+			traces, err := suite.backend.TraceFilter(tc.args)
+			if tc.expPass {
+				suite.Require().NoError(err)
+				heights := []uint64{}
+				for _, trace := range traces {
+					heights = append(heights, trace.BlockNumber)
+				}
+				suite.Require().Equal(tc.expHeights, heights)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+
+	// the trace index covers only block 1, so the range is replayed, and the replay stops once the count is covered
+	// instead of tracing block 2
+	suite.SetupTest()
+	var header metadata.MD
+	queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+	RegisterParamsWithLatestHeight(queryClient, &header, 2)
+	client := suite.backend.clientCtx.Client.(*mocks.Client)
+	RegisterBlock(client, 1, nil)
+	suite.backend.cfg.JSONRPC.TraceFilterRangeCap = 2
+	idxer := suite.backend.indexer.(*indexer.KVIndexer)
+	suite.Require().NoError(idxer.IndexBlockTraces(1, []*rpctypes.ParityTrace{newTrace(1, from, to)}))
+	traces, err := suite.backend.TraceFilter(rpctypes.TraceFilterArgs{Count: &one})
+	suite.Require().NoError(err)
+	suite.Require().Len(traces, 1)
+	client.AssertNumberOfCalls(suite.T(), "Block", 1)
+}
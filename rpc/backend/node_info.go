@@ -310,6 +310,15 @@ func (b *Backend) RPCEVMTimeout() time.Duration {
 	return b.cfg.JSONRPC.EVMTimeout
 }
 
+// RPCSendTxSyncTimeout is the max wait of `eth_sendRawTransactionSync` for the tx inclusion, the default one
+// is used when it's not configured.
+func (b *Backend) RPCSendTxSyncTimeout() time.Duration {
+	if b.cfg.JSONRPC.SendTxSyncTimeout == 0 {
+		return config.DefaultSendTxSyncTimeout
+	}
+	return b.cfg.JSONRPC.SendTxSyncTimeout
+}
+
 // RPCGasCap is the global gas cap for eth-call variants.
 func (b *Backend) RPCTxFeeCap() float64 {
 	return b.cfg.JSONRPC.TxFeeCap
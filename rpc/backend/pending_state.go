@@ -0,0 +1,241 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package backend
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie"
+
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+	evmtypes "github.com/evmos/ethermint/x/evm/types"
+)
+
+// pendingState is the state of the next block as seen by the node: the ethereum transactions of its mempool
+// applied on top of the latest block. It's executed once per latest block and set of mempool transactions, the
+// accounts written by the pending transactions are recorded and the other ones keep their latest state.
+type pendingState struct {
+	height   int64
+	digest   common.Hash
+	txs      []*evmtypes.MsgEthereumTx
+	executed bool
+	applied  []common.Hash
+	gasUsed  uint64
+	accounts map[common.Address]evmtypes.PendingAccount
+}
+
+// pendingStateCache holds the pending state of the latest block.
+type pendingStateCache struct {
+	mtx   sync.Mutex
+	state *pendingState
+}
+
+// pendingEthMsgs returns the ethereum transactions of the mempool in the order of the pool.
+func (b *Backend) pendingEthMsgs() ([]*evmtypes.MsgEthereumTx, error) {
+	txs, err := b.PendingTransactions()
+	if err != nil {
+		return nil, err
+	}
+
+	var msgs []*evmtypes.MsgEthereumTx
+	for _, tx := range txs {
+		for _, msg := range (*tx).GetMsgs() {
+			ethMsg, ok := msg.(*evmtypes.MsgEthereumTx)
+			if !ok {
+				// not ethereum tx
+				break
+			}
+			msgs = append(msgs, ethMsg)
+		}
+	}
+	return msgs, nil
+}
+
+// loadPendingState returns the pending state of the latest block, it's rebuilt when a new block is committed or
+// the mempool changes. The caller must hold the lock of the cache.
+func (b *Backend) loadPendingState() (*pendingState, error) {
+	bn, err := b.BlockNumber()
+	if err != nil {
+		return nil, err
+	}
+	// the latest state is used when the mempool can't be read
+	txs, err := b.pendingEthMsgs()
+	if err != nil {
+		b.logger.Error("failed to fetch pending transactions", "error", err.Error())
+	}
+
+	hashes := make([]byte, 0, len(txs)*common.HashLength)
+	for _, tx := range txs {
+		hashes = append(hashes, common.HexToHash(tx.Hash).Bytes()...)
+	}
+	digest := crypto.Keccak256Hash(hashes)
+
+	if state := b.pendingCache.state; state != nil && state.height == int64(bn) && state.digest == digest {
+		return state, nil
+	}
+
+	state := &pendingState{
+		height:   int64(bn),
+		digest:   digest,
+		txs:      txs,
+		accounts: make(map[common.Address]evmtypes.PendingAccount),
+	}
+	b.pendingCache.state = state
+	return state, nil
+}
+
+// executePendingState applies the pending transactions on top of the latest block and records the given accounts
+// along with the ones written by the pending transactions.
+func (b *Backend) executePendingState(state *pendingState, addresses ...common.Address) error {
+	req := &evmtypes.QueryPendingStateRequest{
+		Txs:     state.txs,
+		ChainId: b.chainID.Int64(),
+	}
+	for _, address := range addresses {
+		req.Addresses = append(req.Addresses, address.Hex())
+	}
+
+	res, err := b.queryClient.PendingState(rpctypes.ContextWithHeight(state.height), req)
+	if err != nil {
+		return err
+	}
+
+	state.applied = make([]common.Hash, len(res.TxHashes))
+	for i, hash := range res.TxHashes {
+		state.applied[i] = common.HexToHash(hash)
+	}
+	state.gasUsed = res.GasUsed
+	state.executed = true
+	for _, acc := range res.Accounts {
+		state.accounts[common.HexToAddress(acc.Address)] = acc
+	}
+	for _, acc := range res.ChangedAccounts {
+		state.accounts[common.HexToAddress(acc.Address)] = acc
+	}
+	return nil
+}
+
+// PendingAccount returns the balance and nonce of the account in the pending state. It returns nil when the mempool
+// has no ethereum transactions or the pending transactions don't write the account, its pending state is then the
+// latest one.
+func (b *Backend) PendingAccount(address common.Address) (*evmtypes.PendingAccount, error) {
+	b.pendingCache.mtx.Lock()
+	defer b.pendingCache.mtx.Unlock()
+
+	state, err := b.loadPendingState()
+	if err != nil {
+		return nil, err
+	}
+	if len(state.txs) == 0 {
+		return nil, nil
+	}
+
+	if !state.executed {
+		if err := b.executePendingState(state, address); err != nil {
+			return nil, err
+		}
+	}
+	acc, ok := state.accounts[address]
+	if !ok {
+		return nil, nil
+	}
+	return &acc, nil
+}
+
+// pendingTxs returns the mempool transactions applied by the pending state and the gas they used.
+func (b *Backend) pendingTxs() ([]*evmtypes.MsgEthereumTx, uint64, error) {
+	b.pendingCache.mtx.Lock()
+	defer b.pendingCache.mtx.Unlock()
+
+	state, err := b.loadPendingState()
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(state.txs) == 0 {
+		return nil, 0, nil
+	}
+	if !state.executed {
+		if err := b.executePendingState(state); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	byHash := make(map[common.Hash]*evmtypes.MsgEthereumTx, len(state.txs))
+	for _, tx := range state.txs {
+		byHash[common.HexToHash(tx.Hash)] = tx
+	}
+	txs := make([]*evmtypes.MsgEthereumTx, 0, len(state.applied))
+	for _, hash := range state.applied {
+		if tx, ok := byHash[hash]; ok {
+			txs = append(txs, tx)
+		}
+	}
+	return txs, state.gasUsed, nil
+}
+
+// pendingBlock returns the block the node would propose next: the latest block followed by the mempool
+// transactions applied by the pending state. It's not sealed, so its hash, nonce and miner are nil.
+func (b *Backend) pendingBlock(latest map[string]interface{}, fullTx bool) (map[string]interface{}, error) {
+	txs, gasUsed, err := b.pendingTxs()
+	if err != nil {
+		return nil, err
+	}
+
+	var baseFee *big.Int
+	if fee, ok := latest["baseFeePerGas"].(*hexutil.Big); ok {
+		baseFee = fee.ToInt()
+	}
+	number := uint64(latest["number"].(hexutil.Uint64)) + 1
+
+	transactions := make([]interface{}, 0, len(txs))
+	ethTxs := make(ethtypes.Transactions, 0, len(txs))
+	for i, tx := range txs {
+		ethTxs = append(ethTxs, tx.AsTransaction())
+		if !fullTx {
+			transactions = append(transactions, common.HexToHash(tx.Hash))
+			continue
+		}
+		rpcTx, err := rpctypes.NewTransactionFromMsg(tx, common.Hash{}, number, uint64(i), baseFee, b.chainID)
+		if err != nil {
+			return nil, err
+		}
+		rpcTx.BlockHash = nil
+		transactions = append(transactions, rpcTx)
+	}
+
+	block := make(map[string]interface{}, len(latest))
+	for k, v := range latest {
+		block[k] = v
+	}
+	block["number"] = hexutil.Uint64(number)
+	block["parentHash"] = common.BytesToHash(latest["hash"].(hexutil.Bytes))
+	block["hash"] = nil
+	block["nonce"] = nil
+	block["miner"] = nil
+	block["gasUsed"] = (*hexutil.Big)(new(big.Int).SetUint64(gasUsed))
+	block["logsBloom"] = ethtypes.Bloom{}
+	block["transactions"] = transactions
+	block["transactionsRoot"] = ethtypes.EmptyRootHash
+	if len(ethTxs) > 0 {
+		block["transactionsRoot"] = ethtypes.DeriveSha(ethTxs, trie.NewStackTrie(nil))
+	}
+	return block, nil
+}
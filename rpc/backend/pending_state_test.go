@@ -0,0 +1,226 @@
+package backend
+
+import (
+	"fmt"
+	"math/big"
+
+	tmtypes "github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/evmos/ethermint/rpc/backend/mocks"
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+	"github.com/evmos/ethermint/tests"
+	evmtypes "github.com/evmos/ethermint/x/evm/types"
+)
+
+func (suite *BackendTestSuite) TestPendingAccount() {
+	addr := tests.GenerateAddress()
+	pendingAcc := evmtypes.PendingAccount{Address: addr.Hex(), Balance: "100", Nonce: 2}
+
+	testCases := []struct {
+		name         string
+		registerMock func()
+		expAccount   *evmtypes.PendingAccount
+		expPass      bool
+	}{
+		{
+			"pass - mempool error falls back to the latest state",
+			func() {
+				var header metadata.MD
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterParams(queryClient, &header, 1)
+				RegisterUnconfirmedTxsError(client, pendingTxsLimit())
+			},
+			nil,
+			true,
+		},
+		{
+			"pass - no pending transactions",
+			func() {
+				var header metadata.MD
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterParams(queryClient, &header, 1)
+				RegisterUnconfirmedTxs(client, pendingTxsLimit(), nil)
+			},
+			nil,
+			true,
+		},
+		{
+			"fail - pending state error",
+			func() {
+				var header metadata.MD
+				msgEthereumTx, _ := suite.buildEthereumTx()
+				txBz := suite.signAndEncodeEthTx(msgEthereumTx)
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterParams(queryClient, &header, 1)
+				RegisterUnconfirmedTxs(client, pendingTxsLimit(), []tmtypes.Tx{txBz})
+				RegisterPendingStateError(queryClient, 1)
+			},
+			nil,
+			false,
+		},
+		{
+			"pass - account of the pending state",
+			func() {
+				var header metadata.MD
+				msgEthereumTx, _ := suite.buildEthereumTx()
+				txBz := suite.signAndEncodeEthTx(msgEthereumTx)
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterParams(queryClient, &header, 1)
+				RegisterUnconfirmedTxs(client, pendingTxsLimit(), []tmtypes.Tx{txBz})
+				RegisterPendingState(queryClient, 1, &evmtypes.QueryPendingStateResponse{
+					Accounts: []evmtypes.PendingAccount{pendingAcc},
+					TxHashes: []string{msgEthereumTx.Hash},
+					GasUsed:  21000,
+				})
+			},
+			&pendingAcc,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.name), func() {
+			suite.SetupTest() // reset
+			tc.registerMock()
+
+			// the second lookup is served by the cache, the pending state is only queried once
+			for i := 0; i < 2; i++ {
+				acc, err := suite.backend.PendingAccount(addr)
+				if tc.expPass {
+					suite.Require().NoError(err)
+					suite.Require().Equal(tc.expAccount, acc)
+				} else {
+					suite.Require().Error(err)
+				}
+			}
+		})
+	}
+}
+
+func (suite *BackendTestSuite) TestPendingAccountSingleExecution() {
+	var header metadata.MD
+	msgEthereumTx, _ := suite.buildEthereumTx()
+	txBz := suite.signAndEncodeEthTx(msgEthereumTx)
+	addr := tests.GenerateAddress()
+	recipient := tests.GenerateAddress()
+	untouched := tests.GenerateAddress()
+	recipientAcc := evmtypes.PendingAccount{Address: recipient.Hex(), Balance: "100"}
+
+	client := suite.backend.clientCtx.Client.(*mocks.Client)
+	queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+	RegisterParams(queryClient, &header, 1)
+	RegisterUnconfirmedTxs(client, pendingTxsLimit(), []tmtypes.Tx{txBz})
+	RegisterPendingState(queryClient, 1, &evmtypes.QueryPendingStateResponse{
+		Accounts:        []evmtypes.PendingAccount{{Address: addr.Hex(), Balance: "1", Nonce: 1}},
+		TxHashes:        []string{msgEthereumTx.Hash},
+		GasUsed:         21000,
+		ChangedAccounts: []evmtypes.PendingAccount{{Address: addr.Hex(), Balance: "1", Nonce: 1}, recipientAcc},
+	})
+
+	acc, err := suite.backend.PendingAccount(addr)
+	suite.Require().NoError(err)
+	suite.Require().Equal(uint64(1), acc.Nonce)
+
+	// the other accounts are served by the same execution
+	acc, err = suite.backend.PendingAccount(recipient)
+	suite.Require().NoError(err)
+	suite.Require().Equal(&recipientAcc, acc)
+
+	acc, err = suite.backend.PendingAccount(untouched)
+	suite.Require().NoError(err)
+	suite.Require().Nil(acc)
+}
+
+func (suite *BackendTestSuite) TestPendingStateRefresh() {
+	var header metadata.MD
+	msgEthereumTx, _ := suite.buildEthereumTx()
+	txBz := suite.signAndEncodeEthTx(msgEthereumTx)
+	addr := tests.GenerateAddress()
+
+	client := suite.backend.clientCtx.Client.(*mocks.Client)
+	queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+	RegisterParams(queryClient, &header, 1)
+	RegisterUnconfirmedTxs(client, pendingTxsLimit(), []tmtypes.Tx{txBz})
+	RegisterPendingState(queryClient, 1, &evmtypes.QueryPendingStateResponse{
+		Accounts: []evmtypes.PendingAccount{{Address: addr.Hex(), Balance: "1", Nonce: 1}},
+	})
+
+	acc, err := suite.backend.PendingAccount(addr)
+	suite.Require().NoError(err)
+	suite.Require().Equal(uint64(1), acc.Nonce)
+
+	// a new block drops the cached pending state
+	client = mocks.NewClient(suite.T())
+	queryClient = mocks.NewEVMQueryClient(suite.T())
+	suite.backend.clientCtx.Client = client
+	suite.backend.queryClient.QueryClient = queryClient
+	RegisterParamsWithLatestHeight(queryClient, &header, 2)
+	RegisterUnconfirmedTxs(client, pendingTxsLimit(), []tmtypes.Tx{txBz})
+	RegisterPendingState(queryClient, 2, &evmtypes.QueryPendingStateResponse{
+		Accounts: []evmtypes.PendingAccount{{Address: addr.Hex(), Balance: "1", Nonce: 2}},
+	})
+
+	acc, err = suite.backend.PendingAccount(addr)
+	suite.Require().NoError(err)
+	suite.Require().Equal(uint64(2), acc.Nonce)
+}
+
+func (suite *BackendTestSuite) TestGetBalancePending() {
+	var header metadata.MD
+	msgEthereumTx, _ := suite.buildEthereumTx()
+	txBz := suite.signAndEncodeEthTx(msgEthereumTx)
+	addr := tests.GenerateAddress()
+	blockNr := rpctypes.EthPendingBlockNumber
+
+	client := suite.backend.clientCtx.Client.(*mocks.Client)
+	queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+	RegisterParams(queryClient, &header, 1)
+	RegisterBlock(client, 1, nil)
+	RegisterUnconfirmedTxs(client, pendingTxsLimit(), []tmtypes.Tx{txBz})
+	RegisterPendingState(queryClient, 1, &evmtypes.QueryPendingStateResponse{
+		Accounts: []evmtypes.PendingAccount{{Address: addr.Hex(), Balance: "100"}},
+	})
+
+	balance, err := suite.backend.GetBalance(addr, rpctypes.BlockNumberOrHash{BlockNumber: &blockNr})
+	suite.Require().NoError(err)
+	suite.Require().Equal((*hexutil.Big)(big.NewInt(100)), balance)
+}
+
+func (suite *BackendTestSuite) TestGetBlockByNumberPending() {
+	var header metadata.MD
+	msgEthereumTx, _ := suite.buildEthereumTx()
+	txBz := suite.signAndEncodeEthTx(msgEthereumTx)
+	txHash := common.HexToHash(msgEthereumTx.Hash)
+	validator := sdk.AccAddress(tests.GenerateAddress().Bytes())
+
+	client := suite.backend.clientCtx.Client.(*mocks.Client)
+	queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+	RegisterParams(queryClient, &header, 1)
+	resBlock, _ := RegisterBlock(client, 1, nil)
+	RegisterBlockResults(client, 1)
+	RegisterConsensusParams(client, 1)
+	RegisterBaseFee(queryClient, sdk.NewInt(1))
+	RegisterValidatorAccount(queryClient, validator)
+	RegisterUnconfirmedTxs(client, pendingTxsLimit(), []tmtypes.Tx{txBz})
+	RegisterPendingState(queryClient, 1, &evmtypes.QueryPendingStateResponse{
+		TxHashes: []string{txHash.Hex()},
+		GasUsed:  21000,
+	})
+
+	block, err := suite.backend.GetBlockByNumber(rpctypes.EthPendingBlockNumber, false)
+	suite.Require().NoError(err)
+	suite.Require().Equal(hexutil.Uint64(2), block["number"])
+	suite.Require().Equal(common.BytesToHash(resBlock.Block.Hash()), block["parentHash"])
+	suite.Require().Nil(block["hash"])
+	suite.Require().Nil(block["miner"])
+	suite.Require().Equal((*hexutil.Big)(big.NewInt(21000)), block["gasUsed"])
+	suite.Require().Equal([]interface{}{txHash}, block["transactions"])
+}
@@ -0,0 +1,357 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package backend
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/ethereum/go-ethereum/common"
+	evmtypes "github.com/evmos/ethermint/x/evm/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// traceCachePrefixEntry is the prefix of the cached trace results, keyed by the entry key
+	traceCachePrefixEntry = byte(1)
+	// traceCachePrefixAccess is the prefix of the access order of the entries, keyed by the access sequence
+	traceCachePrefixAccess = byte(2)
+	// traceCacheKeyMeta stores the total size of the cached results and the last access sequence
+	traceCacheKeyMeta = byte(3)
+	// traceCachePrefixEntrySeq is the prefix of the last access sequence of the entries, keyed by the entry key
+	traceCachePrefixEntrySeq = byte(4)
+
+	// traceCacheEntryKeyLen is the length of an entry key: tx hash, tracer hash and tracer config hash
+	traceCacheEntryKeyLen = 3 * common.HashLength
+
+	// traceCacheMaxPendingAccesses is the number of accessed entries kept in memory before their
+	// access order is persisted
+	traceCacheMaxPendingAccesses = 1024
+)
+
+// TraceCache is a persistent cache of the transaction trace results, keyed by the tx hash,
+// the tracer and the hash of the tracer config. The transactions are only traced once they are
+// committed, and CometBFT blocks are final once committed, so the entries never become stale.
+// The total size of the cached results is bounded, the least recently used entries are evicted first.
+// The reads only record the access order in memory, it's persisted on writes, on close, or once
+// enough entries are accessed, so an unclean shutdown may lose the recency of the latest reads.
+type TraceCache struct {
+	mtx sync.Mutex
+	db  dbm.DB
+
+	maxSize      int64
+	maxEntrySize int64
+
+	// total size of the cached results
+	size int64
+	// last access sequence, it orders the entries from the least recently used
+	seq uint64
+	// accessed is the access sequence of the entries read since the last flush, keyed by the entry key
+	accessed map[string]uint64
+}
+
+// NewTraceCache creates a trace cache on the db, the results larger than maxEntrySize are not cached.
+func NewTraceCache(db dbm.DB, maxSize, maxEntrySize int64) (*TraceCache, error) {
+	if maxSize <= 0 || maxEntrySize <= 0 || maxEntrySize > maxSize {
+		return nil, fmt.Errorf("invalid trace cache size limits, max size %d, max entry size %d", maxSize, maxEntrySize)
+	}
+	cache := &TraceCache{
+		db:           db,
+		maxSize:      maxSize,
+		maxEntrySize: maxEntrySize,
+		accessed:     make(map[string]uint64),
+	}
+
+	bz, err := db.Get([]byte{traceCacheKeyMeta})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read trace cache metadata")
+	}
+	if len(bz) == 16 {
+		cache.size = int64(binary.BigEndian.Uint64(bz[:8]))
+		cache.seq = binary.BigEndian.Uint64(bz[8:])
+	}
+
+	// the limits may have been lowered since the last run
+	batch := db.NewBatch()
+	defer batch.Close()
+	if err := cache.evict(batch, 0, nil); err != nil {
+		return nil, err
+	}
+	if err := cache.writeMeta(batch); err != nil {
+		return nil, err
+	}
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+	return cache, nil
+}
+
+// Get returns the cached trace result of the transaction, it returns nil if the result is not cached.
+func (c *TraceCache) Get(txHash common.Hash, config *evmtypes.TraceConfig) (json.RawMessage, error) {
+	key, err := TraceCacheEntryKey(txHash, config)
+	if err != nil {
+		return nil, err
+	}
+
+	// the db is safe for concurrent use, only the access order is guarded by the mutex
+	result, err := c.db.Get(traceCacheEntryDBKey(key))
+	if err != nil || result == nil {
+		return nil, err
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	// move the entry to the most recently used
+	c.seq++
+	c.accessed[string(key)] = c.seq
+	if len(c.accessed) >= traceCacheMaxPendingAccesses {
+		if err := c.flush(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Put caches the trace result of the transaction, evicting the least recently used entries
+// if the cache is full. The results larger than the max entry size are ignored.
+func (c *TraceCache) Put(txHash common.Hash, config *evmtypes.TraceConfig, result json.RawMessage) error {
+	if int64(len(result)) > c.maxEntrySize {
+		return nil
+	}
+	key, err := TraceCacheEntryKey(txHash, config)
+	if err != nil {
+		return err
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	// the eviction follows the persisted access order
+	if err := c.flush(); err != nil {
+		return err
+	}
+
+	batch := c.db.NewBatch()
+	defer batch.Close()
+
+	seq, size, err := c.getEntry(key)
+	if err != nil {
+		return err
+	}
+	if seq != 0 {
+		if err := c.deleteEntry(batch, key, seq, size); err != nil {
+			return err
+		}
+	}
+	if err := c.evict(batch, int64(len(result)), key); err != nil {
+		return err
+	}
+	if err := batch.Set(traceCacheEntryDBKey(key), result); err != nil {
+		return err
+	}
+	c.seq++
+	if err := c.setAccess(batch, key, 0, c.seq); err != nil {
+		return err
+	}
+	c.size += int64(len(result))
+	if err := c.writeMeta(batch); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// Size returns the total size of the cached results.
+func (c *TraceCache) Size() int64 {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.size
+}
+
+// Close persists the access order and closes the underlying db.
+func (c *TraceCache) Close() error {
+	c.mtx.Lock()
+	err := c.flush()
+	c.mtx.Unlock()
+	if err != nil {
+		return err
+	}
+	return c.db.Close()
+}
+
+// flush persists the access order of the entries read since the last flush, the entries evicted
+// in the meantime are skipped.
+func (c *TraceCache) flush() error {
+	if len(c.accessed) == 0 {
+		return nil
+	}
+
+	batch := c.db.NewBatch()
+	defer batch.Close()
+	for key, seq := range c.accessed {
+		prevSeq, _, err := c.getEntry([]byte(key))
+		if err != nil {
+			return err
+		}
+		if prevSeq == 0 {
+			continue
+		}
+		if err := c.setAccess(batch, []byte(key), prevSeq, seq); err != nil {
+			return err
+		}
+	}
+	if err := c.writeMeta(batch); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	c.accessed = make(map[string]uint64)
+	return nil
+}
+
+// getEntry returns the persisted access sequence and the result size of the entry, the sequence is zero if not found.
+func (c *TraceCache) getEntry(key []byte) (uint64, int64, error) {
+	bz, err := c.db.Get(traceCacheEntrySeqDBKey(key))
+	if err != nil || bz == nil {
+		return 0, 0, err
+	}
+	if len(bz) != 8 {
+		return 0, 0, fmt.Errorf("invalid trace cache entry %x", key)
+	}
+	result, err := c.db.Get(traceCacheEntryDBKey(key))
+	if err != nil {
+		return 0, 0, err
+	}
+	return binary.BigEndian.Uint64(bz), int64(len(result)), nil
+}
+
+// setAccess records the access sequence of the entry, removing its previous access sequence if not zero.
+func (c *TraceCache) setAccess(batch dbm.Batch, key []byte, prevSeq, seq uint64) error {
+	if prevSeq != 0 {
+		if err := batch.Delete(traceCacheAccessDBKey(prevSeq)); err != nil {
+			return err
+		}
+	}
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, seq)
+	if err := batch.Set(traceCacheEntrySeqDBKey(key), bz); err != nil {
+		return err
+	}
+	return batch.Set(traceCacheAccessDBKey(seq), key)
+}
+
+func (c *TraceCache) deleteEntry(batch dbm.Batch, key []byte, seq uint64, size int64) error {
+	if err := batch.Delete(traceCacheEntryDBKey(key)); err != nil {
+		return err
+	}
+	if err := batch.Delete(traceCacheEntrySeqDBKey(key)); err != nil {
+		return err
+	}
+	if err := batch.Delete(traceCacheAccessDBKey(seq)); err != nil {
+		return err
+	}
+	c.size -= size
+	return nil
+}
+
+// evict removes the least recently used entries until there is room for an entry of the given size,
+// the entry being replaced is skipped since it's already deleted in the batch.
+func (c *TraceCache) evict(batch dbm.Batch, size int64, replaced []byte) error {
+	if c.size+size <= c.maxSize {
+		return nil
+	}
+
+	it, err := dbm.IteratePrefix(c.db, []byte{traceCachePrefixAccess})
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for ; it.Valid() && c.size+size > c.maxSize; it.Next() {
+		key := it.Value()
+		if bytes.Equal(key, replaced) {
+			continue
+		}
+		seq, entrySize, err := c.getEntry(key)
+		if err != nil {
+			return err
+		}
+		if seq == 0 {
+			// dangling access record
+			if err := batch.Delete(it.Key()); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := c.deleteEntry(batch, key, seq, entrySize); err != nil {
+			return err
+		}
+	}
+	if c.size < 0 {
+		c.size = 0
+	}
+	return it.Error()
+}
+
+func (c *TraceCache) writeMeta(batch dbm.Batch) error {
+	bz := make([]byte, 16)
+	binary.BigEndian.PutUint64(bz[:8], uint64(c.size))
+	binary.BigEndian.PutUint64(bz[8:], c.seq)
+	return batch.Set([]byte{traceCacheKeyMeta}, bz)
+}
+
+// TraceCacheEntryKey returns the cache key of the trace of a transaction with the tracer config. The
+// timeout is left out of the config hash since it doesn't change the result of a completed trace.
+func TraceCacheEntryKey(txHash common.Hash, config *evmtypes.TraceConfig) ([]byte, error) {
+	cfg := evmtypes.TraceConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	tracerHash := sha256.Sum256([]byte(cfg.Tracer))
+	cfg.Tracer = ""
+	cfg.Timeout = ""
+	bz, err := json.Marshal(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	configHash := sha256.Sum256(bz)
+
+	key := make([]byte, 0, traceCacheEntryKeyLen)
+	key = append(key, txHash.Bytes()...)
+	key = append(key, tracerHash[:]...)
+	return append(key, configHash[:]...), nil
+}
+
+func traceCacheEntryDBKey(key []byte) []byte {
+	return append([]byte{traceCachePrefixEntry}, key...)
+}
+
+func traceCacheEntrySeqDBKey(key []byte) []byte {
+	return append([]byte{traceCachePrefixEntrySeq}, key...)
+}
+
+func traceCacheAccessDBKey(seq uint64) []byte {
+	key := make([]byte, 9)
+	key[0] = traceCachePrefixAccess
+	binary.BigEndian.PutUint64(key[1:], seq)
+	return key
+}
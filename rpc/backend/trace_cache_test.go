@@ -0,0 +1,141 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package backend
+
+import (
+	"encoding/json"
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/ethereum/go-ethereum/common"
+	evmtypes "github.com/evmos/ethermint/x/evm/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceCache(t *testing.T) {
+	callTracer := &evmtypes.TraceConfig{Tracer: "callTracer"}
+	hash1 := common.BytesToHash([]byte{1})
+	hash2 := common.BytesToHash([]byte{2})
+	hash3 := common.BytesToHash([]byte{3})
+	result := json.RawMessage(`{"type":"CALL"}`)
+
+	_, err := NewTraceCache(dbm.NewMemDB(), 10, 20)
+	require.Error(t, err)
+	_, err = NewTraceCache(dbm.NewMemDB(), 0, 0)
+	require.Error(t, err)
+
+	db := dbm.NewMemDB()
+	cache, err := NewTraceCache(db, 3*int64(len(result)), int64(len(result)))
+	require.NoError(t, err)
+
+	// miss
+	cached, err := cache.Get(hash1, callTracer)
+	require.NoError(t, err)
+	require.Nil(t, cached)
+
+	// hit, the timeout is not part of the key
+	require.NoError(t, cache.Put(hash1, callTracer, result))
+	cached, err = cache.Get(hash1, &evmtypes.TraceConfig{Tracer: "callTracer", Timeout: "10s"})
+	require.NoError(t, err)
+	require.Equal(t, result, cached)
+	require.Equal(t, int64(len(result)), cache.Size())
+
+	// other tracer or tracer config
+	cached, err = cache.Get(hash1, nil)
+	require.NoError(t, err)
+	require.Nil(t, cached)
+	cached, err = cache.Get(hash1, &evmtypes.TraceConfig{Tracer: "callTracer", TracerJsonConfig: `{"onlyTopCall":true}`})
+	require.NoError(t, err)
+	require.Nil(t, cached)
+
+	// replacing an entry doesn't grow the cache
+	require.NoError(t, cache.Put(hash1, callTracer, result))
+	require.Equal(t, int64(len(result)), cache.Size())
+
+	// results larger than the max entry size are skipped
+	require.NoError(t, cache.Put(hash2, callTracer, append(result, ' ')))
+	cached, err = cache.Get(hash2, callTracer)
+	require.NoError(t, err)
+	require.Nil(t, cached)
+
+	// the least recently used entry is evicted
+	require.NoError(t, cache.Put(hash2, callTracer, result))
+	require.NoError(t, cache.Put(hash3, callTracer, result))
+	cached, err = cache.Get(hash1, callTracer)
+	require.NoError(t, err)
+	require.Equal(t, result, cached)
+	require.NoError(t, cache.Put(hash3, nil, result))
+	require.Equal(t, 3*int64(len(result)), cache.Size())
+
+	cached, err = cache.Get(hash2, callTracer)
+	require.NoError(t, err)
+	require.Nil(t, cached)
+	for _, hash := range []common.Hash{hash1, hash3} {
+		cached, err = cache.Get(hash, callTracer)
+		require.NoError(t, err)
+		require.Equal(t, result, cached)
+	}
+
+	// the reads only update the access order in memory until it's flushed
+	key, err := TraceCacheEntryKey(hash1, callTracer)
+	require.NoError(t, err)
+	seq, err := db.Get(traceCacheEntrySeqDBKey(key))
+	require.NoError(t, err)
+	cached, err = cache.Get(hash1, callTracer)
+	require.NoError(t, err)
+	require.Equal(t, result, cached)
+	persisted, err := db.Get(traceCacheEntrySeqDBKey(key))
+	require.NoError(t, err)
+	require.Equal(t, seq, persisted)
+	for _, hash := range []common.Hash{hash1, hash3} {
+		_, err = cache.Get(hash, callTracer)
+		require.NoError(t, err)
+	}
+
+	// the entries and the access order persist on close, lower limits evict on reopen
+	require.NoError(t, cache.Close())
+	cache, err = NewTraceCache(db, 2*int64(len(result)), int64(len(result)))
+	require.NoError(t, err)
+	require.Equal(t, 2*int64(len(result)), cache.Size())
+	cached, err = cache.Get(hash3, nil)
+	require.NoError(t, err)
+	require.Nil(t, cached)
+	for _, hash := range []common.Hash{hash1, hash3} {
+		cached, err = cache.Get(hash, callTracer)
+		require.NoError(t, err)
+		require.Equal(t, result, cached)
+	}
+}
+
+func (suite *BackendTestSuite) TestTraceTransactionCached() {
+	suite.SetupTest()
+	hash := common.BytesToHash([]byte{1})
+	config := &evmtypes.TraceConfig{Tracer: "callTracer"}
+
+	cache, err := NewTraceCache(dbm.NewMemDB(), 1<<20, 1<<10)
+	suite.Require().NoError(err)
+	suite.Require().NoError(cache.Put(hash, config, json.RawMessage(`{"type":"CALL"}`)))
+	suite.backend.traceCache = cache
+
+	// no block or trace queries are mocked, the result is served from the cache
+	res, err := suite.backend.TraceTransaction(hash, config)
+	suite.Require().NoError(err)
+	suite.Require().Equal(map[string]interface{}{"type": "CALL"}, res)
+
+	// a miss replays the transaction
+	_, err = suite.backend.TraceTransaction(hash, nil)
+	suite.Require().Error(err)
+}
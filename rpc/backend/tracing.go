@@ -16,21 +16,44 @@
 package backend
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"time"
 
 	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
 	rpctypes "github.com/evmos/ethermint/rpc/types"
+	ethermint "github.com/evmos/ethermint/types"
 	evmtypes "github.com/evmos/ethermint/x/evm/types"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 // TraceTransaction returns the structured logs created during the execution of EVM
-// and returns them as a JSON object.
+// and returns them as a JSON object. A replay diverging from the committed receipt is logged,
+// the block traces report it in the divergence field of the transaction results.
 func (b *Backend) TraceTransaction(hash common.Hash, config *evmtypes.TraceConfig) (interface{}, error) {
+	if b.traceCache != nil {
+		cached, err := b.traceCache.Get(hash, config)
+		if err != nil {
+			b.logger.Error("failed to read the trace cache", "hash", hash, "error", err.Error())
+		} else if cached != nil {
+			var decodedResult interface{}
+			if err := json.Unmarshal(cached, &decodedResult); err == nil {
+				return decodedResult, nil
+			}
+		}
+	}
+
 	// Get transaction by hash
 	transaction, err := b.GetTxByEthHash(hash)
 	if err != nil {
@@ -56,6 +79,8 @@ func (b *Backend) TraceTransaction(hash common.Hash, config *evmtypes.TraceConfi
 	}
 
 	var predecessors []*evmtypes.MsgEthereumTx
+	// non-EVM messages are not replayed, they are counted to report a diverged replay
+	var cosmosMsgs uint64
 	for _, txBz := range blk.Block.Txs[:transaction.TxIndex] {
 		tx, err := b.clientCtx.TxConfig.TxDecoder()(txBz)
 		if err != nil {
@@ -65,6 +90,7 @@ func (b *Backend) TraceTransaction(hash common.Hash, config *evmtypes.TraceConfi
 		for _, msg := range tx.GetMsgs() {
 			ethMsg, ok := msg.(*evmtypes.MsgEthereumTx)
 			if !ok {
+				cosmosMsgs++
 				continue
 			}
 
@@ -126,6 +152,22 @@ func (b *Backend) TraceTransaction(hash common.Hash, config *evmtypes.TraceConfi
 		return nil, err
 	}
 
+	if traceResult.Replay != nil {
+		divergence := evmtypes.NewTraceDivergence(transaction.GasUsed, transaction.Failed, *traceResult.Replay, cosmosMsgs)
+		if divergence != nil {
+			// the tracer output is returned as is, the divergence is only logged
+			b.logger.Info("replayed transaction diverges from the receipt", "hash", hash, "divergence", divergence)
+		}
+	}
+
+	if b.traceCache != nil {
+		if bz, err := json.Marshal(decodedResult); err == nil {
+			if err := b.traceCache.Put(hash, config, bz); err != nil {
+				b.logger.Error("failed to write the trace cache", "hash", hash, "error", err.Error())
+			}
+		}
+	}
+
 	return decodedResult, nil
 }
 
@@ -136,10 +178,7 @@ func (b *Backend) TraceBlock(height rpctypes.BlockNumber,
 	config *evmtypes.TraceConfig,
 	block *tmrpctypes.ResultBlock,
 ) ([]*evmtypes.TxTraceResult, error) {
-	txs := block.Block.Txs
-	txsLength := len(txs)
-
-	if txsLength == 0 {
+	if len(block.Block.Txs) == 0 {
 		// If there are no transactions return empty array
 		return []*evmtypes.TxTraceResult{}, nil
 	}
@@ -148,9 +187,81 @@ func (b *Backend) TraceBlock(height rpctypes.BlockNumber,
 		b.logger.Debug("block result not found", "height", block.Block.Height, "error", err.Error())
 		return nil, nil
 	}
+
+	results := []*evmtypes.TxTraceResult{}
+	err = b.traceBlockTxs(context.Background(), height, config, block, blockRes,
+		func(_ int, _ *evmtypes.MsgEthereumTx, result *evmtypes.TxTraceResult) error {
+			results = append(results, result)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// StreamTraceBlock configures a new tracer according to the provided configuration, and
+// executes all the transactions of the block, fn is called with the result of each transaction
+// as soon as it is received. The tracing stops when ctx is done or fn returns an error.
+// It returns the number of traced transactions.
+func (b *Backend) StreamTraceBlock(
+	ctx context.Context,
+	blockNum rpctypes.BlockNumber,
+	config *evmtypes.TraceConfig,
+	fn func(result *rpctypes.TraceBlockStreamResult) error,
+) (int, error) {
+	resBlock, err := b.TendermintBlockByNumber(blockNum)
+	if err != nil {
+		return 0, err
+	}
+	if resBlock == nil || resBlock.Block == nil {
+		return 0, fmt.Errorf("block %d not found", blockNum)
+	}
+	if resBlock.Block.Height == 0 {
+		return 0, errors.New("genesis is not traceable")
+	}
+	if len(resBlock.Block.Txs) == 0 {
+		return 0, nil
+	}
+	blockRes, err := b.TendermintBlockResultByNumber(&resBlock.Block.Height)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err = b.traceBlockTxs(ctx, rpctypes.BlockNumber(resBlock.Block.Height), config, resBlock, blockRes,
+		func(index int, msg *evmtypes.MsgEthereumTx, result *evmtypes.TxTraceResult) error {
+			count++
+			return fn(&rpctypes.TraceBlockStreamResult{
+				TxTraceResult: *result,
+				TxIndex:       uint64(index),
+				TxHash:        msg.AsTransaction().Hash(),
+			})
+		})
+	return count, err
+}
+
+// traceBlockTxs traces the ethereum transactions of the block, fn is called with the result of each
+// transaction in order. The results are streamed if the node is connected over gRPC, otherwise, or if
+// the node doesn't serve the streaming query, fn is called once the whole block is traced.
+func (b *Backend) traceBlockTxs(
+	ctx context.Context,
+	height rpctypes.BlockNumber,
+	config *evmtypes.TraceConfig,
+	block *tmrpctypes.ResultBlock,
+	blockRes *tmrpctypes.ResultBlockResults,
+	fn func(index int, msg *evmtypes.MsgEthereumTx, result *evmtypes.TxTraceResult) error,
+) error {
+	txs := block.Block.Txs
 	txDecoder := b.clientCtx.TxConfig.TxDecoder()
 
-	var txsMessages []*evmtypes.MsgEthereumTx
+	var (
+		txsMessages []*evmtypes.MsgEthereumTx
+		// the committed receipts and the count of the non-EVM messages executed before, one per message
+		receipts       []*rpctypes.ParsedTx
+		cosmosMsgs     []uint64
+		cosmosMsgCount uint64
+	)
 	for i, tx := range txs {
 		if !rpctypes.TxSuccessOrExceedsBlockGasLimit(blockRes.TxsResults[i]) {
 			b.logger.Debug("invalid tx result code", "cosmos-hash", hexutil.Encode(tx.Hash()))
@@ -162,13 +273,26 @@ func (b *Backend) TraceBlock(height rpctypes.BlockNumber,
 			continue
 		}
 
+		parsedTxs, err := rpctypes.ParseTxResult(blockRes.TxsResults[i], decodedTx)
+		if err != nil {
+			b.logger.Debug("failed to parse tx result", "cosmos-hash", hexutil.Encode(tx.Hash()), "error", err.Error())
+		}
+
 		for _, msg := range decodedTx.GetMsgs() {
 			ethMessage, ok := msg.(*evmtypes.MsgEthereumTx)
 			if !ok {
 				// Just considers Ethereum transactions
+				cosmosMsgCount++
 				continue
 			}
 			txsMessages = append(txsMessages, ethMessage)
+
+			var receipt *rpctypes.ParsedTx
+			if parsedTxs != nil {
+				receipt = parsedTxs.GetTxByHash(ethMessage.AsTransaction().Hash())
+			}
+			receipts = append(receipts, receipt)
+			cosmosMsgs = append(cosmosMsgs, cosmosMsgCount)
 		}
 	}
 
@@ -178,7 +302,6 @@ func (b *Backend) TraceBlock(height rpctypes.BlockNumber,
 		// 0 is a special value for `ContextWithHeight`.
 		contextHeight = 1
 	}
-	ctxWithHeight := rpctypes.ContextWithHeight(int64(contextHeight))
 
 	traceBlockRequest := &evmtypes.QueryTraceBlockRequest{
 		Txs:             txsMessages,
@@ -190,15 +313,321 @@ func (b *Backend) TraceBlock(height rpctypes.BlockNumber,
 		ChainId:         b.chainID.Int64(),
 	}
 
-	res, err := b.queryClient.TraceBlock(ctxWithHeight, traceBlockRequest)
+	// report the transactions whose replay diverges from the committed receipt
+	handleResult := func(index int, result *evmtypes.TxTraceResult, replay *evmtypes.TraceReplayResult) error {
+		if index < 0 || index >= len(txsMessages) {
+			return fmt.Errorf("trace result index %d out of range of %d transactions", index, len(txsMessages))
+		}
+		if result == nil {
+			result = &evmtypes.TxTraceResult{}
+		}
+		if replay != nil && result.Error == "" && receipts[index] != nil {
+			result.Divergence = evmtypes.NewTraceDivergence(receipts[index].GasUsed, receipts[index].Failed, *replay, cosmosMsgs[index])
+			if result.Divergence != nil {
+				b.logger.Info("replayed transaction diverges from the receipt", "hash", receipts[index].Hash, "divergence", result.Divergence)
+			}
+		}
+		return fn(index, txsMessages[index], result)
+	}
+
+	if b.clientCtx.GRPCClient != nil {
+		streamed, err := b.streamTraceBlock(ctx, int64(contextHeight), traceBlockRequest, func(res *evmtypes.QueryTraceBlockStreamResponse) error {
+			var result evmtypes.TxTraceResult
+			if err := json.Unmarshal(res.Data, &result); err != nil {
+				return err
+			}
+			return handleResult(int(res.Index), &result, &res.Replay)
+		})
+		if streamed || err != nil {
+			return err
+		}
+		b.logger.Debug("trace block stream is not served by the node, falling back to the unary query")
+	}
+
+	res, err := b.queryClient.TraceBlock(rpctypes.ContextWithHeight(int64(contextHeight)), traceBlockRequest)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	decodedResults := make([]*evmtypes.TxTraceResult, txsLength)
+	decodedResults := make([]*evmtypes.TxTraceResult, len(txsMessages))
 	if err := json.Unmarshal(res.Data, &decodedResults); err != nil {
+		return err
+	}
+	for i, result := range decodedResults {
+		var replay *evmtypes.TraceReplayResult
+		if len(res.Replays) == len(decodedResults) {
+			replay = &res.Replays[i]
+		}
+		if err := handleResult(i, result, replay); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamTraceBlock runs the TraceBlockStream query, fn is called with each received result, a slow fn
+// holds back the node through the flow control of the stream. It returns false if the node doesn't
+// serve the streaming query.
+func (b *Backend) streamTraceBlock(
+	ctx context.Context,
+	height int64,
+	req *evmtypes.QueryTraceBlockRequest,
+	fn func(res *evmtypes.QueryTraceBlockStreamResponse) error,
+) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, fmt.Sprintf("%d", height))
+
+	stream, err := b.queryClient.TraceBlockStream(ctx, req)
+	if err != nil {
+		return false, unimplementedOrErr(err)
+	}
+	for received := 0; ; received++ {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return true, nil
+		}
+		if err != nil {
+			if received == 0 {
+				return false, unimplementedOrErr(err)
+			}
+			return true, err
+		}
+		if err := fn(res); err != nil {
+			return true, err
+		}
+	}
+}
+
+// unimplementedOrErr returns nil if the error reports an unimplemented gRPC method, otherwise returns the error.
+func unimplementedOrErr(err error) error {
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+	return err
+}
+
+// TraceRawBlock returns the structured logs created during the execution of the transactions
+// of an RLP encoded ethereum block, executed on top of the state of its parent block.
+func (b *Backend) TraceRawBlock(blockRLP hexutil.Bytes, config *evmtypes.TraceConfig) ([]*evmtypes.TxTraceResult, error) {
+	block := new(ethtypes.Block)
+	if err := rlp.DecodeBytes(blockRLP, block); err != nil {
+		return nil, errors.Wrap(err, "could not decode block")
+	}
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis is not traceable")
+	}
+	latest, err := b.BlockNumber()
+	if err != nil {
 		return nil, err
 	}
+	if block.NumberU64() > uint64(latest)+1 {
+		return nil, fmt.Errorf("parent block %d of block %d is not available, latest block is %d", block.NumberU64()-1, block.NumberU64(), latest)
+	}
+	if len(block.Transactions()) == 0 {
+		return []*evmtypes.TxTraceResult{}, nil
+	}
+
+	signer := ethtypes.LatestSignerForChainID(b.chainID)
+	txsMessages := make([]*evmtypes.MsgEthereumTx, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		from, err := ethtypes.Sender(signer, tx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid sender of transaction %s", tx.Hash())
+		}
+		msg := &evmtypes.MsgEthereumTx{}
+		if err := msg.FromEthereumTx(tx); err != nil {
+			return nil, errors.Wrapf(err, "invalid transaction %s", tx.Hash())
+		}
+		msg.From = from.Hex()
+		txsMessages = append(txsMessages, msg)
+	}
+
+	traceBlockRequest := &evmtypes.QueryTraceBlockRequest{
+		Txs:         txsMessages,
+		TraceConfig: config,
+		BlockNumber: block.Number().Int64(),
+		BlockTime:   time.Unix(int64(block.Time()), 0).UTC(),
+		BlockHash:   common.Bytes2Hex(block.Hash().Bytes()),
+		ChainId:     b.chainID.Int64(),
+	}
 
+	return b.traceBlockRequest(traceBlockRequest)
+}
+
+// TraceBadBlock re-traces all the ethereum transactions of a bad block recorded by the node,
+// a block containing ethereum transactions rejected in DeliverTx. The bad blocks are recorded
+// by the EVM transaction indexer when it indexes the block results. The proposals rejected by
+// consensus aren't recorded, the application accepts all the proposals.
+// The rejected transactions are traced in their block position on top of the state of
+// the parent block, so the traces of the later transactions may differ from the
+// committed execution.
+func (b *Backend) TraceBadBlock(hash common.Hash, config *evmtypes.TraceConfig) ([]*rpctypes.BadBlockTxTraceResult, error) {
+	idxer, ok := b.indexer.(ethermint.BadBlockIndexer)
+	if !ok {
+		return nil, errors.New("the bad blocks are recorded by the EVM transaction indexer, which is disabled")
+	}
+	height, err := idxer.GetBadBlockHeight(hash)
+	if err != nil {
+		return nil, err
+	}
+	if height < 0 {
+		return nil, fmt.Errorf("bad block %s not found", hash)
+	}
+
+	resBlock, err := b.TendermintBlockByNumber(rpctypes.BlockNumber(height))
+	if err != nil {
+		return nil, err
+	}
+	if resBlock == nil || resBlock.Block == nil {
+		return nil, fmt.Errorf("block %d not found", height)
+	}
+	blockRes, err := b.TendermintBlockResultByNumber(&height)
+	if err != nil {
+		return nil, err
+	}
+
+	txDecoder := b.clientCtx.TxConfig.TxDecoder()
+	var (
+		txsMessages []*evmtypes.MsgEthereumTx
+		results     []*rpctypes.BadBlockTxTraceResult
+		rejected    bool
+	)
+	for i, tx := range resBlock.Block.Txs {
+		if i >= len(blockRes.TxsResults) {
+			return nil, fmt.Errorf("tx result of transaction %d of block %d not found", i, resBlock.Block.Height)
+		}
+		result := rpctypes.BadBlockTxTraceResult{Rejected: !rpctypes.TxSuccessOrExceedsBlockGasLimit(blockRes.TxsResults[i])}
+		if result.Rejected {
+			result.Log = blockRes.TxsResults[i].Log
+		}
+		rejected = rejected || result.Rejected
+
+		decodedTx, err := txDecoder(tx)
+		if err != nil {
+			b.logger.Error("failed to decode transaction", "hash", tx.Hash(), "error", err.Error())
+			continue
+		}
+		for _, msg := range decodedTx.GetMsgs() {
+			ethMessage, ok := msg.(*evmtypes.MsgEthereumTx)
+			if !ok {
+				// Just considers Ethereum transactions
+				continue
+			}
+			txResult := result
+			txResult.TxHash = ethMessage.AsTransaction().Hash()
+			txsMessages = append(txsMessages, ethMessage)
+			results = append(results, &txResult)
+		}
+	}
+	if !rejected {
+		return nil, fmt.Errorf("block %s is not a bad block, all of its transactions were delivered", hash)
+	}
+	if len(txsMessages) == 0 {
+		return []*rpctypes.BadBlockTxTraceResult{}, nil
+	}
+
+	traceBlockRequest := &evmtypes.QueryTraceBlockRequest{
+		Txs:             txsMessages,
+		TraceConfig:     config,
+		BlockNumber:     resBlock.Block.Height,
+		BlockTime:       resBlock.Block.Time,
+		BlockHash:       common.Bytes2Hex(resBlock.BlockID.Hash),
+		ProposerAddress: sdk.ConsAddress(resBlock.Block.ProposerAddress),
+		ChainId:         b.chainID.Int64(),
+	}
+	traceResults, err := b.traceBlockRequest(traceBlockRequest)
+	if err != nil {
+		return nil, err
+	}
+	if len(traceResults) != len(results) {
+		return nil, fmt.Errorf("trace results mismatch the transactions of block %d: %d != %d", resBlock.Block.Height, len(traceResults), len(results))
+	}
+	for i, traceResult := range traceResults {
+		if traceResult != nil {
+			results[i].TxTraceResult = *traceResult
+		}
+	}
+	return results, nil
+}
+
+// traceBlockRequest traces the block request on top of the state of the parent block.
+func (b *Backend) traceBlockRequest(req *evmtypes.QueryTraceBlockRequest) ([]*evmtypes.TxTraceResult, error) {
+	// minus one to get the context at the beginning of the block
+	contextHeight := req.BlockNumber - 1
+	if contextHeight < 1 {
+		// 0 is a special value for `ContextWithHeight`.
+		contextHeight = 1
+	}
+
+	res, err := b.queryClient.TraceBlock(rpctypes.ContextWithHeight(contextHeight), req)
+	if err != nil {
+		return nil, err
+	}
+
+	decodedResults := make([]*evmtypes.TxTraceResult, 0, len(req.Txs))
+	if err := json.Unmarshal(res.Data, &decodedResults); err != nil {
+		return nil, err
+	}
 	return decodedResults, nil
 }
+
+// TraceCall lets you trace a given eth_call. It collects the structured logs created
+// during the execution of EVM if the given transaction was added on top of the provided
+// block and returns them as a JSON object.
+func (b *Backend) TraceCall(
+	args evmtypes.TransactionArgs,
+	blockNrOrHash rpctypes.BlockNumberOrHash,
+	config *rpctypes.TraceCallConfig,
+) (interface{}, error) {
+	blockNum, err := b.BlockNumberFromTendermint(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := b.TendermintBlockByNumber(blockNum)
+	if err != nil {
+		// the error message imitates geth behavior
+		return nil, errors.New("header not found")
+	}
+
+	bz, err := json.Marshal(&args)
+	if err != nil {
+		return nil, err
+	}
+
+	traceCallRequest := evmtypes.QueryTraceCallRequest{
+		Args:            bz,
+		GasCap:          b.RPCGasCap(),
+		BlockNumber:     header.Block.Height,
+		BlockTime:       header.Block.Time,
+		BlockHash:       common.Bytes2Hex(header.BlockID.Hash),
+		ProposerAddress: sdk.ConsAddress(header.Block.ProposerAddress),
+		ChainId:         b.chainID.Int64(),
+	}
+
+	if config != nil {
+		traceCallRequest.TraceConfig = &config.TraceConfig
+		if config.StateOverrides != nil {
+			overrides, err := json.Marshal(config.StateOverrides)
+			if err != nil {
+				return nil, err
+			}
+			traceCallRequest.StateOverrides = overrides
+		}
+	}
+
+	traceResult, err := b.queryClient.TraceCall(rpctypes.ContextWithHeight(blockNum.Int64()), &traceCallRequest)
+	if err != nil {
+		return nil, b.prunedStateError(blockNum.Int64(), err)
+	}
+
+	// Response format is unknown due to custom tracer config param
+	// More information can be found here https://geth.ethereum.org/docs/dapp/tracing-filtered
+	var decodedResult interface{}
+	if err := json.Unmarshal(traceResult.Data, &decodedResult); err != nil {
+		return nil, err
+	}
+
+	return decodedResult, nil
+}
@@ -1,7 +1,11 @@
 package backend
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"math/big"
+	"time"
 
 	dbm "github.com/cometbft/cometbft-db"
 	abci "github.com/cometbft/cometbft/abci/types"
@@ -10,12 +14,22 @@ import (
 	"github.com/cometbft/cometbft/types"
 	tmtypes "github.com/cometbft/cometbft/types"
 	"github.com/cosmos/cosmos-sdk/crypto"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/evmos/ethermint/crypto/ethsecp256k1"
 	"github.com/evmos/ethermint/indexer"
 	"github.com/evmos/ethermint/rpc/backend/mocks"
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+	"github.com/evmos/ethermint/tests"
 	evmtypes "github.com/evmos/ethermint/x/evm/types"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func (suite *BackendTestSuite) TestTraceTransaction() {
@@ -47,6 +61,23 @@ func (suite *BackendTestSuite) TestTraceTransaction() {
 	tx2, _ := msgEthereumTx.BuildTx(suite.backend.clientCtx.TxConfig.NewTxBuilder(), "aphoton")
 	txBz2, _ := txEncoder(tx2)
 
+	receiptResponse := []*abci.ResponseDeliverTx{
+		{
+			Code:    0,
+			GasUsed: 21000,
+			Events: []abci.Event{
+				{Type: evmtypes.EventTypeEthereumTx, Attributes: []abci.EventAttribute{
+					{Key: "ethereumTxHash", Value: txHash.Hex()},
+					{Key: "txIndex", Value: "0"},
+					{Key: "amount", Value: "1000"},
+					{Key: "txGasUsed", Value: "21000"},
+					{Key: "txHash", Value: ""},
+					{Key: "recipient", Value: "0x775b87ef5D82ca211811C1a02CE0fE0CA3a455d7"},
+				}},
+			},
+		},
+	}
+
 	testCases := []struct {
 		name          string
 		registerMock  func()
@@ -170,6 +201,34 @@ func (suite *BackendTestSuite) TestTraceTransaction() {
 			map[string]interface{}{"test": "hello"},
 			true,
 		},
+
+		{
+			"pass - replay matches the receipt",
+			func() {
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterBlock(client, 1, txBz)
+				RegisterTraceTransactionWithReplay(queryClient, msgEthereumTx, &evmtypes.TraceReplayResult{GasUsed: 21000})
+			},
+			&types.Block{Header: types.Header{Height: 1}, Data: types.Data{Txs: []types.Tx{txBz}}},
+			receiptResponse,
+			map[string]interface{}{"test": "hello"},
+			true,
+		},
+		{
+			"pass - replay diverges from the receipt",
+			func() {
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterBlock(client, 1, txBz)
+				RegisterTraceTransactionWithReplay(queryClient, msgEthereumTx, &evmtypes.TraceReplayResult{GasUsed: 30000, Failed: true})
+			},
+			&types.Block{Header: types.Header{Height: 1}, Data: types.Data{Txs: []types.Tx{txBz}}},
+			receiptResponse,
+			// the tracer output isn't altered by the divergence
+			map[string]interface{}{"test": "hello"},
+			true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -250,3 +309,423 @@ func (suite *BackendTestSuite) TestTraceBlock() {
 		})
 	}
 }
+
+func (suite *BackendTestSuite) TestTraceCall() {
+	toAddr := tests.GenerateAddress()
+	callArgs := evmtypes.TransactionArgs{
+		To:      &toAddr,
+		ChainID: (*hexutil.Big)(suite.backend.chainID),
+	}
+	argsBz, err := json.Marshal(callArgs)
+	suite.Require().NoError(err)
+
+	balance := (*hexutil.Big)(big.NewInt(100))
+	overrides := rpctypes.StateOverride{
+		toAddr: rpctypes.OverrideAccount{Balance: &balance},
+	}
+	overridesBz, err := json.Marshal(overrides)
+	suite.Require().NoError(err)
+
+	blockNum := rpctypes.BlockNumber(1)
+	blockNrOrHash := rpctypes.BlockNumberOrHash{BlockNumber: &blockNum}
+
+	traceCallRequest := func(resBlock *tmrpctypes.ResultBlock, traceConfig *evmtypes.TraceConfig, stateOverrides []byte) *evmtypes.QueryTraceCallRequest {
+		return &evmtypes.QueryTraceCallRequest{
+			Args:            argsBz,
+			GasCap:          suite.backend.RPCGasCap(),
+			TraceConfig:     traceConfig,
+			StateOverrides:  stateOverrides,
+			BlockNumber:     resBlock.Block.Height,
+			BlockTime:       resBlock.Block.Time,
+			BlockHash:       common.Bytes2Hex(resBlock.BlockID.Hash),
+			ProposerAddress: sdk.ConsAddress(resBlock.Block.ProposerAddress),
+			ChainId:         suite.backend.chainID.Int64(),
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		registerMock func()
+		config       *rpctypes.TraceCallConfig
+		expResult    interface{}
+		expPass      bool
+	}{
+		{
+			"fail - block not found",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterBlockError(client, 1)
+			},
+			nil,
+			nil,
+			false,
+		},
+		{
+			"fail - trace call error",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				resBlock, _ := RegisterBlock(client, 1, nil)
+				RegisterTraceCallError(queryClient, traceCallRequest(resBlock, nil, nil))
+			},
+			nil,
+			nil,
+			false,
+		},
+		{
+			"pass - without config",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				resBlock, _ := RegisterBlock(client, 1, nil)
+				RegisterTraceCall(queryClient, traceCallRequest(resBlock, nil, nil))
+			},
+			nil,
+			map[string]interface{}{"test": "hello"},
+			true,
+		},
+		{
+			"pass - with tracer and state overrides",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				resBlock, _ := RegisterBlock(client, 1, nil)
+				RegisterTraceCall(queryClient, traceCallRequest(resBlock, &evmtypes.TraceConfig{Tracer: "callTracer"}, overridesBz))
+			},
+			&rpctypes.TraceCallConfig{
+				TraceConfig:    evmtypes.TraceConfig{Tracer: "callTracer"},
+				StateOverrides: &overrides,
+			},
+			map[string]interface{}{"test": "hello"},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("case %s", tc.name), func() {
+			suite.SetupTest() // reset test and queries
+			tc.registerMock()
+
+			result, err := suite.backend.TraceCall(callArgs, blockNrOrHash, tc.config)
+
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(tc.expResult, result)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
+func (suite *BackendTestSuite) TestTraceRawBlock() {
+	msgEthTx, _ := suite.buildEthereumTx()
+	priv, _ := ethsecp256k1.GenerateKey()
+	from := common.BytesToAddress(priv.PubKey().Address().Bytes())
+	msgEthTx.From = from.Hex()
+	err := msgEthTx.Sign(ethtypes.LatestSignerForChainID(suite.backend.chainID), tests.NewSigner(priv))
+	suite.Require().NoError(err)
+	ethTx := msgEthTx.AsTransaction()
+
+	encodeBlock := func(height int64, txs []*ethtypes.Transaction) hexutil.Bytes {
+		header := &ethtypes.Header{Number: big.NewInt(height), Time: 1000}
+		bz, err := rlp.EncodeToBytes(ethtypes.NewBlockWithHeader(header).WithBody(txs, nil))
+		suite.Require().NoError(err)
+		return bz
+	}
+	blockBz := encodeBlock(2, []*ethtypes.Transaction{ethTx})
+	block := new(ethtypes.Block)
+	suite.Require().NoError(rlp.DecodeBytes(blockBz, block))
+
+	expMsg := &evmtypes.MsgEthereumTx{}
+	suite.Require().NoError(expMsg.FromEthereumTx(block.Transactions()[0]))
+	expMsg.From = from.Hex()
+
+	testCases := []struct {
+		name            string
+		registerMock    func()
+		blockRLP        hexutil.Bytes
+		expTraceResults []*evmtypes.TxTraceResult
+		expPass         bool
+	}{
+		{
+			"fail - invalid block rlp",
+			func() {},
+			hexutil.Bytes{0x01, 0x02},
+			nil,
+			false,
+		},
+		{
+			"fail - parent block not available",
+			func() {
+				var header metadata.MD
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterParamsWithLatestHeight(queryClient, &header, 1)
+			},
+			encodeBlock(3, []*ethtypes.Transaction{ethTx}),
+			nil,
+			false,
+		},
+		{
+			"pass - block without transactions",
+			func() {
+				var header metadata.MD
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterParamsWithLatestHeight(queryClient, &header, 1)
+			},
+			encodeBlock(2, nil),
+			[]*evmtypes.TxTraceResult{},
+			true,
+		},
+		{
+			"pass - traces the block on top of its parent",
+			func() {
+				var header metadata.MD
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterParamsWithLatestHeight(queryClient, &header, 1)
+				RegisterTraceBlockRequest(queryClient, 1, &evmtypes.QueryTraceBlockRequest{
+					Txs:         []*evmtypes.MsgEthereumTx{expMsg},
+					TraceConfig: &evmtypes.TraceConfig{},
+					BlockNumber: 2,
+					BlockTime:   time.Unix(1000, 0).UTC(),
+					BlockHash:   common.Bytes2Hex(block.Hash().Bytes()),
+					ChainId:     9000,
+				}, []byte(`[{"result":{"test":"hello"}}]`))
+			},
+			blockBz,
+			[]*evmtypes.TxTraceResult{{Result: map[string]interface{}{"test": "hello"}}},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("case %s", tc.name), func() {
+			suite.SetupTest() // reset test and queries
+			tc.registerMock()
+
+			traceResults, err := suite.backend.TraceRawBlock(tc.blockRLP, &evmtypes.TraceConfig{})
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(tc.expTraceResults, traceResults)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
+func (suite *BackendTestSuite) TestTraceBadBlock() {
+	msgEthTx, _ := suite.buildEthereumTx()
+	// the indexer only records the blocks of rejected ethereum transactions
+	tx, err := msgEthTx.BuildTx(suite.backend.clientCtx.TxConfig.NewTxBuilder(), "aphoton")
+	suite.Require().NoError(err)
+	bz, err := suite.backend.clientCtx.TxConfig.TxEncoder()(tx)
+	suite.Require().NoError(err)
+	request := &evmtypes.QueryTraceBlockRequest{
+		Txs:         []*evmtypes.MsgEthereumTx{msgEthTx},
+		TraceConfig: &evmtypes.TraceConfig{},
+		BlockNumber: 1,
+		ChainId:     9000,
+	}
+	data := []byte(`[{"error":"out of gas"}]`)
+
+	testCases := []struct {
+		name            string
+		registerMock    func() common.Hash
+		expTraceResults []*rpctypes.BadBlockTxTraceResult
+		expPass         bool
+	}{
+		{
+			"fail - bad block not recorded",
+			func() common.Hash {
+				return common.Hash{}
+			},
+			nil,
+			false,
+		},
+		{
+			"fail - indexer disabled",
+			func() common.Hash {
+				suite.backend.indexer = nil
+				return common.Hash{}
+			},
+			nil,
+			false,
+		},
+		{
+			"fail - delivered block not recorded",
+			func() common.Hash {
+				block := tmtypes.MakeBlock(1, []tmtypes.Tx{bz}, nil, nil)
+				suite.Require().NoError(suite.backend.indexer.IndexBlock(block, []*abci.ResponseDeliverTx{{Code: 0}}))
+				return common.BytesToHash(block.Hash())
+			},
+			nil,
+			false,
+		},
+		{
+			"pass - transaction rejected in DeliverTx",
+			func() common.Hash {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				resBlock, _ := RegisterBlock(client, 1, bz)
+				blockRes := RegisterBlockResultsRejected(client, 1, "out of gas")
+				suite.Require().NoError(suite.backend.indexer.IndexBlock(resBlock.Block, blockRes.TxsResults))
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterTraceBlockRequest(queryClient, 1, request, data)
+				return common.BytesToHash(resBlock.Block.Hash())
+			},
+			[]*rpctypes.BadBlockTxTraceResult{
+				{
+					TxTraceResult: evmtypes.TxTraceResult{Error: "out of gas"},
+					TxHash:        msgEthTx.AsTransaction().Hash(),
+					Rejected:      true,
+					Log:           "out of gas",
+				},
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("case %s", tc.name), func() {
+			suite.SetupTest() // reset test and queries
+			hash := tc.registerMock()
+
+			traceResults, err := suite.backend.TraceBadBlock(hash, &evmtypes.TraceConfig{})
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(tc.expTraceResults, traceResults)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
+func (suite *BackendTestSuite) TestTraceBlockStreamed() {
+	msgEthTx, bz := suite.buildEthereumTx()
+	filledBlock := tmtypes.MakeBlock(1, []tmtypes.Tx{bz}, nil, nil)
+	filledBlock.ChainID = ChainID
+	resBlockFilled := tmrpctypes.ResultBlock{Block: filledBlock, BlockID: filledBlock.LastBlockID}
+	request := &evmtypes.QueryTraceBlockRequest{
+		Txs:         []*evmtypes.MsgEthereumTx{msgEthTx},
+		TraceConfig: &evmtypes.TraceConfig{},
+		BlockNumber: 1,
+		ChainId:     9000,
+	}
+	streamed := []*evmtypes.QueryTraceBlockStreamResponse{{Index: 0, Data: []byte(`{"result":{"test":"hello"}}`)}}
+
+	testCases := []struct {
+		name            string
+		registerMock    func()
+		expTraceResults []*evmtypes.TxTraceResult
+		expPass         bool
+	}{
+		{
+			"pass - results are streamed",
+			func() {
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterTraceBlockStream(queryClient, request, streamed, nil)
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterBlockResults(client, 1)
+			},
+			[]*evmtypes.TxTraceResult{{Result: map[string]interface{}{"test": "hello"}}},
+			true,
+		},
+		{
+			"pass - falls back to the unary query if the stream is not served",
+			func() {
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterTraceBlockStream(queryClient, request, nil, status.Error(codes.Unimplemented, "unknown method"))
+				RegisterTraceBlockRequest(queryClient, 1, request, []byte(`[{"result":{"test":"hello"}}]`))
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterBlockResults(client, 1)
+			},
+			[]*evmtypes.TxTraceResult{{Result: map[string]interface{}{"test": "hello"}}},
+			true,
+		},
+		{
+			"fail - stream broken after the first result",
+			func() {
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterTraceBlockStream(queryClient, request, streamed, status.Error(codes.Unavailable, "connection reset"))
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterBlockResults(client, 1)
+			},
+			nil,
+			false,
+		},
+		{
+			"fail - streamed result out of range",
+			func() {
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				RegisterTraceBlockStream(queryClient, request, []*evmtypes.QueryTraceBlockStreamResponse{{Index: 1, Data: []byte(`{}`)}}, nil)
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterBlockResults(client, 1)
+			},
+			nil,
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("case %s", tc.name), func() {
+			suite.SetupTest() // reset test and queries
+			// the results are streamed when connected to the node over gRPC
+			suite.backend.clientCtx.GRPCClient = &grpc.ClientConn{}
+			tc.registerMock()
+
+			traceResults, err := suite.backend.TraceBlock(1, &evmtypes.TraceConfig{}, &resBlockFilled)
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(tc.expTraceResults, traceResults)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
+func (suite *BackendTestSuite) TestStreamTraceBlock() {
+	msgEthTx, bz := suite.buildEthereumTx()
+	request := &evmtypes.QueryTraceBlockRequest{
+		Txs:         []*evmtypes.MsgEthereumTx{msgEthTx},
+		TraceConfig: &evmtypes.TraceConfig{},
+		BlockNumber: 1,
+		ChainId:     9000,
+	}
+
+	suite.SetupTest()
+	suite.backend.clientCtx.GRPCClient = &grpc.ClientConn{}
+	client := suite.backend.clientCtx.Client.(*mocks.Client)
+	RegisterBlock(client, 1, bz)
+	RegisterBlockResults(client, 1)
+	queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+	RegisterTraceBlockStream(queryClient, request, []*evmtypes.QueryTraceBlockStreamResponse{
+		{Index: 0, Data: []byte(`{"error":"out of gas"}`)},
+	}, nil)
+
+	var results []*rpctypes.TraceBlockStreamResult
+	count, err := suite.backend.StreamTraceBlock(context.Background(), 1, &evmtypes.TraceConfig{}, func(result *rpctypes.TraceBlockStreamResult) error {
+		results = append(results, result)
+		return nil
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, count)
+	suite.Require().Equal([]*rpctypes.TraceBlockStreamResult{
+		{
+			TxTraceResult: evmtypes.TxTraceResult{Error: "out of gas"},
+			TxIndex:       0,
+			TxHash:        msgEthTx.AsTransaction().Hash(),
+		},
+	}, results)
+
+	// the tracing stops when the callback fails
+	queryClient.ExpectedCalls = nil
+	RegisterTraceBlockStream(queryClient, request, []*evmtypes.QueryTraceBlockStreamResponse{
+		{Index: 0, Data: []byte(`{"error":"out of gas"}`)},
+	}, nil)
+	_, err = suite.backend.StreamTraceBlock(context.Background(), 1, &evmtypes.TraceConfig{}, func(*rpctypes.TraceBlockStreamResult) error {
+		return errors.New("peer gone")
+	})
+	suite.Require().ErrorContains(err, "peer gone")
+}
@@ -21,7 +21,9 @@ import (
 	"math/big"
 
 	errorsmod "cosmossdk.io/errors"
+	abci "github.com/cometbft/cometbft/abci/types"
 	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -133,6 +135,149 @@ func (b *Backend) getTransactionByHashPending(txHash common.Hash) (*rpctypes.RPC
 	return nil, nil
 }
 
+// GetRawTransaction returns the canonical encoding of the transaction identified by hash, the typed transactions
+// are encoded as their EIP-2718 envelope. It falls back to the mempool for the pending transactions.
+func (b *Backend) GetRawTransaction(txHash common.Hash) (hexutil.Bytes, error) {
+	res, err := b.GetTxByEthHash(txHash)
+	if err != nil {
+		return b.getRawTransactionPending(txHash)
+	}
+
+	block, err := b.TendermintBlockByNumber(rpctypes.BlockNumber(res.Height))
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := b.clientCtx.TxConfig.TxDecoder()(block.Block.Txs[res.TxIndex])
+	if err != nil {
+		return nil, err
+	}
+
+	// the `res.MsgIndex` is inferred from tx index, should be within the bound.
+	msg, ok := tx.GetMsgs()[res.MsgIndex].(*evmtypes.MsgEthereumTx)
+	if !ok {
+		return nil, errors.New("invalid ethereum tx")
+	}
+
+	return msg.AsTransaction().MarshalBinary()
+}
+
+// getRawTransactionPending find the encoding of a pending tx from mempool
+func (b *Backend) getRawTransactionPending(txHash common.Hash) (hexutil.Bytes, error) {
+	hexTx := txHash.Hex()
+	txs, err := b.PendingTransactions()
+	if err != nil {
+		b.logger.Debug("tx not found", "hash", hexTx, "error", err.Error())
+		return nil, nil
+	}
+
+	for _, tx := range txs {
+		msg, err := evmtypes.UnwrapEthereumMsg(tx, txHash)
+		if err != nil {
+			// not ethereum tx
+			continue
+		}
+
+		if msg.Hash == hexTx {
+			return msg.AsTransaction().MarshalBinary()
+		}
+	}
+
+	b.logger.Debug("tx not found", "hash", hexTx)
+	return nil, nil
+}
+
+// EthReceiptsFromTendermintBlock returns the receipts of the ethereum transactions of a block, in the order of
+// EthMsgsFromTendermintBlock. The consensus fields are the ones returned by GetTransactionReceipt.
+func (b *Backend) EthReceiptsFromTendermintBlock(
+	resBlock *tmrpctypes.ResultBlock,
+	blockRes *tmrpctypes.ResultBlockResults,
+) (ethtypes.Receipts, error) {
+	msgs := b.EthMsgsFromTendermintBlock(resBlock, blockRes)
+	blockHash := common.BytesToHash(resBlock.Block.Header.Hash())
+	receipts := make(ethtypes.Receipts, 0, len(msgs))
+	gasCounter := newBlockGasCounter(blockRes)
+
+	for i, ethMsg := range msgs {
+		res, err := b.GetTxByEthHash(common.HexToHash(ethMsg.Hash))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tx %s: %w", ethMsg.Hash, err)
+		}
+		// the index of the tx among the valid eth transactions is known from the iteration
+		res.EthTxIndex = int32(i)
+
+		txData, err := evmtypes.UnpackTxData(ethMsg.Data)
+		if err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, b.ethReceipt(ethMsg, txData, res, blockHash, blockRes, gasCounter))
+	}
+
+	return receipts, nil
+}
+
+// blockGasCounter sums the gas used by the cosmos transactions of a block, it's advanced incrementally
+// so the cumulative gas of the receipts of a block is computed in a single pass over its results.
+type blockGasCounter struct {
+	txsResults []*abci.ResponseDeliverTx
+	// next is the index of the next transaction to add to the total
+	next  uint32
+	total uint64
+}
+
+func newBlockGasCounter(blockRes *tmrpctypes.ResultBlockResults) *blockGasCounter {
+	return &blockGasCounter{txsResults: blockRes.TxsResults}
+}
+
+// gasUsedBefore returns the gas used by the transactions of the block before txIndex.
+func (c *blockGasCounter) gasUsedBefore(txIndex uint32) uint64 {
+	if txIndex < c.next {
+		c.next, c.total = 0, 0
+	}
+	for ; c.next < txIndex; c.next++ {
+		c.total += uint64(c.txsResults[c.next].GasUsed)
+	}
+	return c.total
+}
+
+// ethReceipt builds the receipt of an ethereum transaction from its indexed result and the results of its block,
+// it's the single source of the receipts fields returned by the rpc apis.
+func (b *Backend) ethReceipt(
+	ethMsg *evmtypes.MsgEthereumTx,
+	txData evmtypes.TxData,
+	res *ethermint.TxResult,
+	blockHash common.Hash,
+	blockRes *tmrpctypes.ResultBlockResults,
+	gasCounter *blockGasCounter,
+) *ethtypes.Receipt {
+	status := ethtypes.ReceiptStatusSuccessful
+	if res.Failed {
+		status = ethtypes.ReceiptStatusFailed
+	}
+
+	// parse tx logs from events
+	logs, err := TxLogsFromEvents(blockRes.TxsResults[res.TxIndex].Events, int(res.MsgIndex))
+	if err != nil {
+		b.logger.Debug("failed to parse logs", "hash", ethMsg.Hash, "error", err.Error())
+	}
+	if logs == nil {
+		logs = []*ethtypes.Log{}
+	}
+
+	return &ethtypes.Receipt{
+		Type:              ethMsg.AsTransaction().Type(),
+		Status:            status,
+		CumulativeGasUsed: gasCounter.gasUsedBefore(res.TxIndex) + res.CumulativeGasUsed,
+		Bloom:             ethtypes.BytesToBloom(ethtypes.LogsBloom(logs)),
+		Logs:              logs,
+		TxHash:            common.HexToHash(ethMsg.Hash),
+		GasUsed:           b.GetGasUsed(res, txData.GetGasPrice(), txData.GetGas()),
+		BlockHash:         blockHash,
+		BlockNumber:       big.NewInt(res.Height),
+		TransactionIndex:  uint(res.EthTxIndex),
+	}
+}
+
 // GetGasUsed returns gasUsed from transaction
 func (b *Backend) GetGasUsed(res *ethermint.TxResult, price *big.Int, gas uint64) uint64 {
 	// patch gasUsed if tx is reverted and happened before height on which fixed was introduced
@@ -154,6 +299,44 @@ func (b *Backend) GetTransactionReceipt(hash common.Hash) (map[string]interface{
 		b.logger.Debug("tx not found", "hash", hexTx, "error", err.Error())
 		return nil, nil
 	}
+	return b.txReceiptFromResult(hash, res)
+}
+
+// GetTransactionReceiptFromEvent returns the receipt of the ethereum transaction from the event of the cosmos
+// transaction including it. Unlike GetTransactionReceipt it doesn't go through the tx indexers, so the receipt
+// is available as soon as the event is published. It returns nil when the transaction isn't part of the event.
+func (b *Backend) GetTransactionReceiptFromEvent(data tmtypes.EventDataTx, hash common.Hash) (map[string]interface{}, error) {
+	txResult := &tmrpctypes.ResultTx{
+		Height:   data.Height,
+		Index:    data.Index,
+		TxResult: data.Result,
+		Tx:       data.Tx,
+	}
+	if !rpctypes.TxSuccessOrExceedsBlockGasLimit(&txResult.TxResult) {
+		return nil, nil
+	}
+
+	var tx sdk.Tx
+	if txResult.TxResult.Code != 0 {
+		// it's only needed when the tx exceeds block gas limit
+		var err error
+		if tx, err = b.clientCtx.TxConfig.TxDecoder()(txResult.Tx); err != nil {
+			return nil, fmt.Errorf("failed to decode tx: %w", err)
+		}
+	}
+
+	res, err := rpctypes.ParseTxIndexerResult(txResult, tx, func(txs *rpctypes.ParsedTxs) *rpctypes.ParsedTx {
+		return txs.GetTxByHash(hash)
+	})
+	if err != nil {
+		return nil, nil
+	}
+	return b.txReceiptFromResult(hash, res)
+}
+
+// txReceiptFromResult returns the receipt of the ethereum transaction from its tx indexer result.
+func (b *Backend) txReceiptFromResult(hash common.Hash, res *ethermint.TxResult) (map[string]interface{}, error) {
+	hexTx := hash.Hex()
 	resBlock, err := b.TendermintBlockByNumber(rpctypes.BlockNumber(res.Height))
 	if err != nil {
 		b.logger.Debug("block not found", "height", res.Height, "error", err.Error())
@@ -166,45 +349,17 @@ func (b *Backend) GetTransactionReceipt(hash common.Hash) (map[string]interface{
 	}
 	ethMsg := tx.GetMsgs()[res.MsgIndex].(*evmtypes.MsgEthereumTx)
 
-	txData, err := evmtypes.UnpackTxData(ethMsg.Data)
-	if err != nil {
-		b.logger.Error("failed to unpack tx data", "error", err.Error())
-		return nil, err
-	}
-
-	cumulativeGasUsed := uint64(0)
 	blockRes, err := b.TendermintBlockResultByNumber(&res.Height)
 	if err != nil {
 		b.logger.Debug("failed to retrieve block results", "height", res.Height, "error", err.Error())
 		return nil, nil
 	}
-	for _, txResult := range blockRes.TxsResults[0:res.TxIndex] {
-		cumulativeGasUsed += uint64(txResult.GasUsed)
-	}
-	cumulativeGasUsed += res.CumulativeGasUsed
 
-	var status hexutil.Uint
-	if res.Failed {
-		status = hexutil.Uint(ethtypes.ReceiptStatusFailed)
-	} else {
-		status = hexutil.Uint(ethtypes.ReceiptStatusSuccessful)
-	}
 	chainID, err := b.ChainID()
 	if err != nil {
 		return nil, err
 	}
 
-	from, err := ethMsg.GetSender(chainID.ToInt())
-	if err != nil {
-		return nil, err
-	}
-
-	// parse tx logs from events
-	logs, err := TxLogsFromEvents(blockRes.TxsResults[res.TxIndex].Events, int(res.MsgIndex))
-	if err != nil {
-		b.logger.Debug("failed to parse logs", "hash", hexTx, "error", err.Error())
-	}
-
 	if res.EthTxIndex == -1 {
 		// Fallback to find tx index by iterating all valid eth transactions
 		msgs := b.EthMsgsFromTendermintBlock(resBlock, blockRes)
@@ -220,33 +375,129 @@ func (b *Backend) GetTransactionReceipt(hash common.Hash) (map[string]interface{
 		return nil, errors.New("can't find index of ethereum tx")
 	}
 
+	var baseFee *big.Int
+	if ethMsg.AsTransaction().Type() == ethtypes.DynamicFeeTxType {
+		baseFee = b.receiptBaseFee(blockRes)
+	}
+
+	return b.formatTxReceipt(ethMsg, res, common.BytesToHash(resBlock.Block.Header.Hash()), blockRes, newBlockGasCounter(blockRes), chainID.ToInt(), baseFee)
+}
+
+// GetBlockReceipts returns the receipts of all the ethereum transactions of the block, the block and its
+// results are loaded once for all of them.
+func (b *Backend) GetBlockReceipts(blockNrOrHash rpctypes.BlockNumberOrHash) ([]map[string]interface{}, error) {
+	blockNum, err := b.BlockNumberFromTendermint(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	resBlock, err := b.TendermintBlockByNumber(blockNum)
+	if err != nil {
+		b.logger.Debug("block not found", "height", blockNum, "error", err.Error())
+		return nil, nil
+	}
+	if resBlock == nil || resBlock.Block == nil {
+		b.logger.Debug("block not found", "height", blockNum)
+		return nil, nil
+	}
+
+	blockRes, err := b.TendermintBlockResultByNumber(&resBlock.Block.Height)
+	if err != nil {
+		b.logger.Debug("failed to retrieve block results", "height", resBlock.Block.Height, "error", err.Error())
+		return nil, nil
+	}
+
+	chainID, err := b.ChainID()
+	if err != nil {
+		return nil, err
+	}
+
+	blockHash := common.BytesToHash(resBlock.Block.Header.Hash())
+	msgs := b.EthMsgsFromTendermintBlock(resBlock, blockRes)
+	receipts := make([]map[string]interface{}, 0, len(msgs))
+	gasCounter := newBlockGasCounter(blockRes)
+
+	var baseFee *big.Int
+	for i, ethMsg := range msgs {
+		res, err := b.GetTxByEthHash(common.HexToHash(ethMsg.Hash))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tx %s: %w", ethMsg.Hash, err)
+		}
+		// the index of the tx among the valid eth transactions is known from the iteration
+		res.EthTxIndex = int32(i)
+
+		if baseFee == nil && ethMsg.AsTransaction().Type() == ethtypes.DynamicFeeTxType {
+			baseFee = b.receiptBaseFee(blockRes)
+		}
+
+		receipt, err := b.formatTxReceipt(ethMsg, res, blockHash, blockRes, gasCounter, chainID.ToInt(), baseFee)
+		if err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	return receipts, nil
+}
+
+// receiptBaseFee returns the base fee of the block used for the effective gas price of the receipts,
+// nil if it can't be fetched.
+func (b *Backend) receiptBaseFee(blockRes *tmrpctypes.ResultBlockResults) *big.Int {
+	baseFee, err := b.BaseFee(blockRes)
+	if err != nil {
+		// tolerate the error for pruned node.
+		b.logger.Error("fetch basefee failed, node is pruned?", "height", blockRes.Height, "error", err)
+		return nil
+	}
+	return baseFee
+}
+
+// formatTxReceipt returns the RPC receipt of an ethereum transaction from its indexed result and the results
+// of its block. The effective gas price of the dynamic fee transactions is omitted when the base fee is nil.
+func (b *Backend) formatTxReceipt(
+	ethMsg *evmtypes.MsgEthereumTx,
+	res *ethermint.TxResult,
+	blockHash common.Hash,
+	blockRes *tmrpctypes.ResultBlockResults,
+	gasCounter *blockGasCounter,
+	chainID *big.Int,
+	baseFee *big.Int,
+) (map[string]interface{}, error) {
+	txData, err := evmtypes.UnpackTxData(ethMsg.Data)
+	if err != nil {
+		b.logger.Error("failed to unpack tx data", "error", err.Error())
+		return nil, err
+	}
+
+	from, err := ethMsg.GetSender(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	ethReceipt := b.ethReceipt(ethMsg, txData, res, blockHash, blockRes, gasCounter)
 	receipt := map[string]interface{}{
 		// Consensus fields: These fields are defined by the Yellow Paper
-		"status":            status,
-		"cumulativeGasUsed": hexutil.Uint64(cumulativeGasUsed),
-		"logsBloom":         ethtypes.BytesToBloom(ethtypes.LogsBloom(logs)),
-		"logs":              logs,
+		"status":            hexutil.Uint(ethReceipt.Status),
+		"cumulativeGasUsed": hexutil.Uint64(ethReceipt.CumulativeGasUsed),
+		"logsBloom":         ethReceipt.Bloom,
+		"logs":              ethReceipt.Logs,
 
 		// Implementation fields: These fields are added by geth when processing a transaction.
 		// They are stored in the chain database.
-		"transactionHash": hash,
+		"transactionHash": ethReceipt.TxHash,
 		"contractAddress": nil,
-		"gasUsed":         hexutil.Uint64(b.GetGasUsed(res, txData.GetGasPrice(), txData.GetGas())),
+		"gasUsed":         hexutil.Uint64(ethReceipt.GasUsed),
 
 		// Inclusion information: These fields provide information about the inclusion of the
 		// transaction corresponding to this receipt.
-		"blockHash":        common.BytesToHash(resBlock.Block.Header.Hash()).Hex(),
+		"blockHash":        blockHash.Hex(),
 		"blockNumber":      hexutil.Uint64(res.Height),
-		"transactionIndex": hexutil.Uint64(res.EthTxIndex),
+		"transactionIndex": hexutil.Uint64(ethReceipt.TransactionIndex),
 
 		// sender and receiver (contract or EOA) addreses
 		"from": from,
 		"to":   txData.GetTo(),
-		"type": hexutil.Uint(ethMsg.AsTransaction().Type()),
-	}
-
-	if logs == nil {
-		receipt["logs"] = [][]*ethtypes.Log{}
+		"type": hexutil.Uint(ethReceipt.Type),
 	}
 
 	// If the ContractAddress is 20 0x0 bytes, assume it is not a contract creation
@@ -254,14 +505,8 @@ func (b *Backend) GetTransactionReceipt(hash common.Hash) (map[string]interface{
 		receipt["contractAddress"] = crypto.CreateAddress(from, txData.GetNonce())
 	}
 
-	if dynamicTx, ok := txData.(*evmtypes.DynamicFeeTx); ok {
-		baseFee, err := b.BaseFee(blockRes)
-		if err != nil {
-			// tolerate the error for pruned node.
-			b.logger.Error("fetch basefee failed, node is pruned?", "height", res.Height, "error", err)
-		} else {
-			receipt["effectiveGasPrice"] = hexutil.Big(*dynamicTx.EffectiveGasPrice(baseFee))
-		}
+	if dynamicTx, ok := txData.(*evmtypes.DynamicFeeTx); ok && baseFee != nil {
+		receipt["effectiveGasPrice"] = hexutil.Big(*dynamicTx.EffectiveGasPrice(baseFee))
 	}
 
 	return receipt, nil
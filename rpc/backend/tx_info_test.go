@@ -1,6 +1,7 @@
 package backend
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
 
@@ -12,9 +13,11 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/evmos/ethermint/indexer"
 	"github.com/evmos/ethermint/rpc/backend/mocks"
 	rpctypes "github.com/evmos/ethermint/rpc/types"
+	"github.com/evmos/ethermint/tests"
 	ethermint "github.com/evmos/ethermint/types"
 	evmtypes "github.com/evmos/ethermint/x/evm/types"
 	"google.golang.org/grpc/metadata"
@@ -137,7 +140,7 @@ func (suite *BackendTestSuite) TestGetTransactionsByHashPending() {
 			"fail - Pending transactions returns error",
 			func() {
 				client := suite.backend.clientCtx.Client.(*mocks.Client)
-				RegisterUnconfirmedTxsError(client, nil)
+				RegisterUnconfirmedTxsError(client, pendingTxsLimit())
 			},
 			msgEthereumTx,
 			nil,
@@ -147,7 +150,7 @@ func (suite *BackendTestSuite) TestGetTransactionsByHashPending() {
 			"fail - Tx not found return nil",
 			func() {
 				client := suite.backend.clientCtx.Client.(*mocks.Client)
-				RegisterUnconfirmedTxs(client, nil, nil)
+				RegisterUnconfirmedTxs(client, pendingTxsLimit(), nil)
 			},
 			msgEthereumTx,
 			nil,
@@ -157,7 +160,7 @@ func (suite *BackendTestSuite) TestGetTransactionsByHashPending() {
 			"pass - Tx found and returned",
 			func() {
 				client := suite.backend.clientCtx.Client.(*mocks.Client)
-				RegisterUnconfirmedTxs(client, nil, types.Txs{bz})
+				RegisterUnconfirmedTxs(client, pendingTxsLimit(), types.Txs{bz})
 			},
 			msgEthereumTx,
 			rpcTransaction,
@@ -599,6 +602,246 @@ func (suite *BackendTestSuite) TestGetTransactionReceipt() {
 	}
 }
 
+func (suite *BackendTestSuite) TestGetRawTransaction() {
+	msgEthereumTx, _ := suite.buildEthereumTx()
+	txBz := suite.signAndEncodeEthTx(msgEthereumTx)
+	txHash := common.HexToHash(msgEthereumTx.Hash)
+	block := &types.Block{Header: types.Header{Height: 1, ChainID: "test"}, Data: types.Data{Txs: []types.Tx{txBz}}}
+	responseDeliver := []*abci.ResponseDeliverTx{
+		{
+			Code: 0,
+			Events: []abci.Event{
+				{Type: evmtypes.EventTypeEthereumTx, Attributes: []abci.EventAttribute{
+					{Key: "ethereumTxHash", Value: txHash.Hex()},
+					{Key: "txIndex", Value: "0"},
+					{Key: "amount", Value: "1000"},
+					{Key: "txGasUsed", Value: "21000"},
+					{Key: "txHash", Value: ""},
+					{Key: "recipient", Value: ""},
+				}},
+			},
+		},
+	}
+	expRaw, err := msgEthereumTx.AsTransaction().MarshalBinary()
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name         string
+		registerMock func()
+		hash         common.Hash
+		expRaw       hexutil.Bytes
+		expPass      bool
+	}{
+		{
+			"fail - Block error",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterBlockError(client, 1)
+			},
+			txHash,
+			nil,
+			false,
+		},
+		{
+			"pass - Transaction not found",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterUnconfirmedTxsEmpty(client, pendingTxsLimit())
+			},
+			common.Hash{},
+			nil,
+			true,
+		},
+		{
+			"pass - Transaction found and encoded",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterBlock(client, 1, txBz)
+			},
+			txHash,
+			expRaw,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.registerMock()
+
+			db := dbm.NewMemDB()
+			suite.backend.indexer = indexer.NewKVIndexer(db, tmlog.NewNopLogger(), suite.backend.clientCtx)
+			err := suite.backend.indexer.IndexBlock(block, responseDeliver)
+			suite.Require().NoError(err)
+
+			raw, err := suite.backend.GetRawTransaction(tc.hash)
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Equal(tc.expRaw, raw)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
+func (suite *BackendTestSuite) TestEthReceiptsFromTendermintBlock() {
+	suite.SetupTest() // reset
+
+	msgEthereumTx, _ := suite.buildEthereumTx()
+	txBz := suite.signAndEncodeEthTx(msgEthereumTx)
+	txHash := common.HexToHash(msgEthereumTx.Hash)
+
+	log := &evmtypes.Log{
+		Address: tests.GenerateAddress().Hex(),
+		Topics:  []string{common.BigToHash(big.NewInt(1)).Hex()},
+		Data:    []byte{1},
+		TxHash:  txHash.Hex(),
+	}
+	logBz, err := json.Marshal(log)
+	suite.Require().NoError(err)
+
+	block := &types.Block{Header: types.Header{Height: 1, ChainID: "test"}, Data: types.Data{Txs: []types.Tx{txBz}}}
+	blockRes := &tmrpctypes.ResultBlockResults{
+		Height: 1,
+		TxsResults: []*abci.ResponseDeliverTx{
+			{
+				Code:    0,
+				GasUsed: 21000,
+				Events: []abci.Event{
+					{Type: evmtypes.EventTypeEthereumTx, Attributes: []abci.EventAttribute{
+						{Key: "ethereumTxHash", Value: txHash.Hex()},
+						{Key: "txIndex", Value: "0"},
+						{Key: "amount", Value: "1000"},
+						{Key: "txGasUsed", Value: "21000"},
+						{Key: "txHash", Value: ""},
+						{Key: "recipient", Value: ""},
+					}},
+					{Type: evmtypes.EventTypeTxLog, Attributes: []abci.EventAttribute{
+						{Key: evmtypes.AttributeKeyTxLog, Value: string(logBz)},
+					}},
+				},
+			},
+		},
+	}
+
+	db := dbm.NewMemDB()
+	suite.backend.indexer = indexer.NewKVIndexer(db, tmlog.NewNopLogger(), suite.backend.clientCtx)
+	suite.Require().NoError(suite.backend.indexer.IndexBlock(block, blockRes.TxsResults))
+
+	receipts, err := suite.backend.EthReceiptsFromTendermintBlock(&tmrpctypes.ResultBlock{Block: block}, blockRes)
+	suite.Require().NoError(err)
+	suite.Require().Len(receipts, 1)
+
+	receipt := receipts[0]
+	suite.Require().Equal(ethtypes.ReceiptStatusSuccessful, receipt.Status)
+	suite.Require().Equal(uint64(21000), receipt.CumulativeGasUsed)
+	suite.Require().Equal(uint64(21000), receipt.GasUsed)
+	suite.Require().Equal(txHash, receipt.TxHash)
+	suite.Require().Len(receipt.Logs, 1)
+	suite.Require().True(receipt.Bloom.Test(common.HexToAddress(log.Address).Bytes()))
+
+	// the binary encoding round trips the consensus fields
+	raw, err := receipt.MarshalBinary()
+	suite.Require().NoError(err)
+	var decoded ethtypes.Receipt
+	suite.Require().NoError(decoded.UnmarshalBinary(raw))
+	suite.Require().Equal(receipt.CumulativeGasUsed, decoded.CumulativeGasUsed)
+	suite.Require().Equal(receipt.Bloom, decoded.Bloom)
+	suite.Require().Len(decoded.Logs, 1)
+}
+
+func (suite *BackendTestSuite) TestGetBlockReceipts() {
+	msgEthereumTx, _ := suite.buildEthereumTx()
+	txBz := suite.signAndEncodeEthTx(msgEthereumTx)
+	txHash := common.HexToHash(msgEthereumTx.Hash)
+	blockNr := rpctypes.NewBlockNumber(big.NewInt(1))
+
+	block := &types.Block{Header: types.Header{Height: 1, ChainID: "test"}, Data: types.Data{Txs: []types.Tx{txBz}}}
+	responseDeliver := []*abci.ResponseDeliverTx{
+		{
+			Code: 0,
+			Events: []abci.Event{
+				{Type: evmtypes.EventTypeEthereumTx, Attributes: []abci.EventAttribute{
+					{Key: "ethereumTxHash", Value: txHash.Hex()},
+					{Key: "txIndex", Value: "0"},
+					{Key: "amount", Value: "1000"},
+					{Key: "txGasUsed", Value: "21000"},
+					{Key: "txHash", Value: ""},
+					{Key: "recipient", Value: ""},
+				}},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		registerMock func()
+		expReceipts  int
+		expPass      bool
+	}{
+		{
+			"pass - block not found",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterBlockError(client, 1)
+			},
+			0,
+			true,
+		},
+		{
+			"pass - block results not found",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterBlock(client, 1, txBz)
+				RegisterBlockResultsError(client, 1)
+			},
+			0,
+			true,
+		},
+		{
+			"pass - receipts of the block",
+			func() {
+				var header metadata.MD
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterParams(queryClient, &header, 1)
+				RegisterParamsWithoutHeader(queryClient, 1)
+				RegisterBlock(client, 1, txBz)
+				RegisterBlockResults(client, 1)
+			},
+			1,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.registerMock()
+
+			db := dbm.NewMemDB()
+			suite.backend.indexer = indexer.NewKVIndexer(db, tmlog.NewNopLogger(), suite.backend.clientCtx)
+			err := suite.backend.indexer.IndexBlock(block, responseDeliver)
+			suite.Require().NoError(err)
+
+			receipts, err := suite.backend.GetBlockReceipts(rpctypes.BlockNumberOrHash{BlockNumber: &blockNr})
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Len(receipts, tc.expReceipts)
+				for _, receipt := range receipts {
+					suite.Require().Equal(txHash, receipt["transactionHash"])
+					suite.Require().Equal(hexutil.Uint64(0), receipt["transactionIndex"])
+					suite.Require().Equal(hexutil.Uint64(1), receipt["blockNumber"])
+					suite.Require().Equal(hexutil.Uint(ethtypes.ReceiptStatusSuccessful), receipt["status"])
+				}
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
 func (suite *BackendTestSuite) TestGetGasUsed() {
 	origin := suite.backend.cfg.JSONRPC.FixRevertGasRefundHeight
 	testCases := []struct {
@@ -654,3 +897,96 @@ func (suite *BackendTestSuite) TestGetGasUsed() {
 		})
 	}
 }
+
+func (suite *BackendTestSuite) TestGetTransactionReceiptFromEvent() {
+	msgEthereumTx, _ := suite.buildEthereumTx()
+	txBz := suite.signAndEncodeEthTx(msgEthereumTx)
+	txHash := common.HexToHash(msgEthereumTx.Hash)
+
+	newEvent := func(code uint32) types.EventDataTx {
+		return types.EventDataTx{TxResult: abci.TxResult{
+			Height: 1,
+			Index:  0,
+			Tx:     txBz,
+			Result: abci.ResponseDeliverTx{
+				Code: code,
+				Events: []abci.Event{
+					{Type: evmtypes.EventTypeEthereumTx, Attributes: []abci.EventAttribute{
+						{Key: "ethereumTxHash", Value: txHash.Hex()},
+						{Key: "txIndex", Value: "0"},
+						{Key: "amount", Value: "1000"},
+						{Key: "txGasUsed", Value: "21000"},
+						{Key: "txHash", Value: ""},
+						{Key: "recipient", Value: ""},
+					}},
+				},
+			},
+		}}
+	}
+
+	testCases := []struct {
+		name         string
+		registerMock func()
+		event        types.EventDataTx
+		hash         common.Hash
+		expReceipt   bool
+	}{
+		{
+			"pass - other transaction",
+			func() {},
+			newEvent(0),
+			common.HexToHash("0x1"),
+			false,
+		},
+		{
+			"pass - failed transaction",
+			func() {},
+			newEvent(1),
+			txHash,
+			false,
+		},
+		{
+			"pass - receipt of the transaction",
+			func() {
+				var header metadata.MD
+				queryClient := suite.backend.queryClient.QueryClient.(*mocks.EVMQueryClient)
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterParams(queryClient, &header, 1)
+				RegisterParamsWithoutHeader(queryClient, 1)
+				RegisterBlock(client, 1, txBz)
+				RegisterBlockResults(client, 1)
+			},
+			newEvent(0),
+			txHash,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+			tc.registerMock()
+
+			receipt, err := suite.backend.GetTransactionReceiptFromEvent(tc.event, tc.hash)
+			suite.Require().NoError(err)
+			if tc.expReceipt {
+				suite.Require().Equal(txHash, receipt["transactionHash"])
+			} else {
+				suite.Require().Nil(receipt)
+			}
+		})
+	}
+}
+
+func (suite *BackendTestSuite) TestBlockGasCounter() {
+	counter := newBlockGasCounter(&tmrpctypes.ResultBlockResults{
+		TxsResults: []*abci.ResponseDeliverTx{{GasUsed: 1}, {GasUsed: 10}, {GasUsed: 100}},
+	})
+	suite.Require().Equal(uint64(0), counter.gasUsedBefore(0))
+	suite.Require().Equal(uint64(11), counter.gasUsedBefore(2))
+	// several messages of the same tx
+	suite.Require().Equal(uint64(11), counter.gasUsedBefore(2))
+	suite.Require().Equal(uint64(111), counter.gasUsedBefore(3))
+	// going backward restarts from the first tx
+	suite.Require().Equal(uint64(1), counter.gasUsedBefore(1))
+}
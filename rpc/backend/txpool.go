@@ -0,0 +1,127 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package backend
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+	evmtypes "github.com/evmos/ethermint/x/evm/types"
+)
+
+// PendingTransactionsLimit is the maximum number of mempool transactions returned by PendingTransactions.
+// It's the page size cap of the CometBFT `unconfirmed_txs` rpc, which returns 30 transactions by default.
+const PendingTransactionsLimit = 100
+
+// TxPoolContent returns the ethereum transactions of the mempool grouped by sender and nonce. The transactions
+// executable in sequence from the on-chain nonce of their sender are pending, the others are queued.
+// Only the first PendingTransactionsLimit transactions of the mempool are listed.
+func (b *Backend) TxPoolContent() (
+	pending map[common.Address]map[uint64]*rpctypes.RPCTransaction,
+	queued map[common.Address]map[uint64]*rpctypes.RPCTransaction,
+	err error,
+) {
+	txs, err := b.PendingTransactions()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rpcTxs []*rpctypes.RPCTransaction
+	nonces := make(map[common.Address]uint64)
+	for _, tx := range txs {
+		for _, msg := range (*tx).GetMsgs() {
+			ethMsg, ok := msg.(*evmtypes.MsgEthereumTx)
+			if !ok {
+				// not ethereum tx
+				break
+			}
+
+			// use zero block values since it's not included in a block yet
+			rpcTx, err := rpctypes.NewTransactionFromMsg(ethMsg, common.Hash{}, uint64(0), uint64(0), nil, b.chainID)
+			if err != nil {
+				b.logger.Debug("invalid pending tx", "hash", ethMsg.Hash, "error", err.Error())
+				continue
+			}
+			rpcTxs = append(rpcTxs, rpcTx)
+
+			if _, ok := nonces[rpcTx.From]; ok {
+				continue
+			}
+			// the latest height is queried with the zero height
+			nonce, err := b.getAccountNonce(rpcTx.From, false, 0, b.logger)
+			if err != nil {
+				return nil, nil, err
+			}
+			nonces[rpcTx.From] = nonce
+		}
+	}
+
+	pending, queued = splitTxPoolContent(rpcTxs, nonces)
+	return pending, queued, nil
+}
+
+// splitTxPoolContent groups the transactions by sender and nonce, a transaction is pending when all the nonces
+// from the on-chain nonce of its sender up to its own are in the pool. The transactions reusing the nonce of
+// a previous one are ignored, as well as the ones below the on-chain nonce, which are already included in a
+// block or can never be, and are only left in the mempool until it's rechecked.
+func splitTxPoolContent(
+	txs []*rpctypes.RPCTransaction,
+	nonces map[common.Address]uint64,
+) (
+	pending map[common.Address]map[uint64]*rpctypes.RPCTransaction,
+	queued map[common.Address]map[uint64]*rpctypes.RPCTransaction,
+) {
+	bySender := make(map[common.Address]map[uint64]*rpctypes.RPCTransaction)
+	for _, tx := range txs {
+		senderTxs, ok := bySender[tx.From]
+		if !ok {
+			senderTxs = make(map[uint64]*rpctypes.RPCTransaction)
+			bySender[tx.From] = senderTxs
+		}
+		if _, ok := senderTxs[uint64(tx.Nonce)]; !ok {
+			senderTxs[uint64(tx.Nonce)] = tx
+		}
+	}
+
+	pending = make(map[common.Address]map[uint64]*rpctypes.RPCTransaction)
+	queued = make(map[common.Address]map[uint64]*rpctypes.RPCTransaction)
+	for sender, senderTxs := range bySender {
+		sorted := make([]uint64, 0, len(senderTxs))
+		for nonce := range senderTxs {
+			sorted = append(sorted, nonce)
+		}
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		next := nonces[sender]
+		for _, nonce := range sorted {
+			if nonce < nonces[sender] {
+				continue
+			}
+			target := queued
+			if nonce == next {
+				target = pending
+				next++
+			}
+			if target[sender] == nil {
+				target[sender] = make(map[uint64]*rpctypes.RPCTransaction)
+			}
+			target[sender][nonce] = senderTxs[nonce]
+		}
+	}
+
+	return pending, queued
+}
@@ -0,0 +1,87 @@
+package backend
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/evmos/ethermint/rpc/backend/mocks"
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+	"github.com/evmos/ethermint/tests"
+)
+
+func (suite *BackendTestSuite) TestTxPoolContent() {
+	testCases := []struct {
+		name         string
+		registerMock func()
+		expPass      bool
+	}{
+		{
+			"fail - mempool error",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterUnconfirmedTxsError(client, pendingTxsLimit())
+			},
+			false,
+		},
+		{
+			"pass - empty mempool",
+			func() {
+				client := suite.backend.clientCtx.Client.(*mocks.Client)
+				RegisterUnconfirmedTxs(client, pendingTxsLimit(), nil)
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.name), func() {
+			suite.SetupTest() // reset
+			tc.registerMock()
+
+			pending, queued, err := suite.backend.TxPoolContent()
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Empty(pending)
+				suite.Require().Empty(queued)
+			} else {
+				suite.Require().Error(err)
+			}
+		})
+	}
+}
+
+func (suite *BackendTestSuite) TestSplitTxPoolContent() {
+	sender, other := tests.GenerateAddress(), tests.GenerateAddress()
+	newTx := func(from common.Address, nonce uint64) *rpctypes.RPCTransaction {
+		return &rpctypes.RPCTransaction{From: from, Nonce: hexutil.Uint64(nonce)}
+	}
+
+	txs := []*rpctypes.RPCTransaction{
+		newTx(sender, 6),
+		newTx(sender, 4),
+		newTx(sender, 5),
+		newTx(sender, 8), // nonce gap
+		newTx(sender, 3), // stale nonce
+		newTx(other, 1),  // on-chain nonce not reached
+	}
+	duplicate := newTx(sender, 4)
+	txs = append(txs, duplicate)
+
+	pending, queued := splitTxPoolContent(txs, map[common.Address]uint64{sender: 4, other: 0})
+
+	suite.Require().Len(pending, 1)
+	suite.Require().Len(pending[sender], 3)
+	for _, nonce := range []uint64{4, 5, 6} {
+		suite.Require().Equal(hexutil.Uint64(nonce), pending[sender][nonce].Nonce)
+	}
+	suite.Require().NotSame(duplicate, pending[sender][4])
+
+	// the stale nonce is dropped instead of being queued forever
+	suite.Require().Len(queued, 2)
+	suite.Require().Len(queued[sender], 1)
+	suite.Require().Contains(queued[sender], uint64(8))
+	suite.Require().NotContains(queued[sender], uint64(3))
+	suite.Require().Len(queued[other], 1)
+}
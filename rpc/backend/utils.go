@@ -309,3 +309,19 @@ func GetHexProofs(proof *crypto.ProofOps) []string {
 	}
 	return proofs
 }
+
+// prunedStateError converts the error of a query at the given height into a PrunedStateError
+// carrying the earliest available height if the state of the height has been pruned.
+func (b *Backend) prunedStateError(height int64, err error) error {
+	if height <= 0 || !evmtypes.IsPrunedStateError(err) {
+		return err
+	}
+
+	earliest, errEarliest := b.EarliestStateBlockNumber()
+	if errEarliest != nil {
+		b.logger.Debug("failed to fetch earliest state height", "error", errEarliest.Error())
+		return err
+	}
+
+	return evmtypes.NewPrunedStateError(height, int64(earliest))
+}
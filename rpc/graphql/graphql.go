@@ -0,0 +1,1313 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+
+// Package graphql serves the go-ethereum GraphQL schema on top of the EVM backend of the JSON-RPC server.
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/cometbft/cometbft/libs/log"
+	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/evmos/ethermint/rpc/backend"
+	"github.com/evmos/ethermint/rpc/namespaces/ethereum/eth/filters"
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+	evmtypes "github.com/evmos/ethermint/x/evm/types"
+)
+
+var errBlockNotFound = errors.New("block not found")
+
+// Backend is the backend of the GraphQL service, the log queries run on the filters of the eth namespace.
+type Backend interface {
+	backend.EVMBackend
+	filters.Backend
+}
+
+// Long is a 64 bit integer of the schema.
+type Long int64
+
+// ImplementsGraphQLType returns true if Long implements the provided GraphQL type.
+func (b Long) ImplementsGraphQLType(name string) bool { return name == "Long" }
+
+// UnmarshalGraphQL unmarshals the provided GraphQL query data.
+func (b *Long) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case string:
+		value, err := strconv.ParseInt(input, 10, 64)
+		*b = Long(value)
+		return err
+	case int32:
+		*b = Long(input)
+	case int64:
+		*b = Long(input)
+	case float64:
+		*b = Long(input)
+	default:
+		return fmt.Errorf("unexpected type %T for Long", input)
+	}
+	return nil
+}
+
+func blockNumberOrHash(number rpctypes.BlockNumber) rpctypes.BlockNumberOrHash {
+	return rpctypes.BlockNumberOrHash{BlockNumber: &number}
+}
+
+// Account represents an Ethereum account at a particular block.
+type Account struct {
+	r             *Resolver
+	address       common.Address
+	blockNrOrHash rpctypes.BlockNumberOrHash
+}
+
+func (a *Account) Address() common.Address {
+	return a.address
+}
+
+func (a *Account) Balance() (hexutil.Big, error) {
+	balance, err := a.r.backend.GetBalance(a.address, a.blockNrOrHash)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return *balance, nil
+}
+
+func (a *Account) TransactionCount() (hexutil.Uint64, error) {
+	blockNum, err := a.r.backend.BlockNumberFromTendermint(a.blockNrOrHash)
+	if err != nil {
+		return 0, err
+	}
+	nonce, err := a.r.backend.GetTransactionCount(a.address, blockNum)
+	if err != nil {
+		return 0, err
+	}
+	return *nonce, nil
+}
+
+func (a *Account) Code() (hexutil.Bytes, error) {
+	return a.r.backend.GetCode(a.address, a.blockNrOrHash)
+}
+
+func (a *Account) Storage(args struct{ Slot common.Hash }) (common.Hash, error) {
+	value, err := a.r.backend.GetStorageAt(a.address, args.Slot.Hex(), a.blockNrOrHash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(value), nil
+}
+
+// Log represents an individual log message. All arguments are mandatory.
+type Log struct {
+	r           *Resolver
+	transaction *Transaction
+	log         *ethtypes.Log
+}
+
+func (l *Log) Transaction() *Transaction {
+	return l.transaction
+}
+
+func (l *Log) Account(args BlockNumberArgs) *Account {
+	return &Account{
+		r:             l.r,
+		address:       l.log.Address,
+		blockNrOrHash: args.NumberOrLatest(),
+	}
+}
+
+func (l *Log) Index() int32 {
+	return int32(l.log.Index)
+}
+
+func (l *Log) Topics() []common.Hash {
+	return l.log.Topics
+}
+
+func (l *Log) Data() hexutil.Bytes {
+	return l.log.Data
+}
+
+// AccessTuple represents EIP-2930
+type AccessTuple struct {
+	address     common.Address
+	storageKeys []common.Hash
+}
+
+func (at *AccessTuple) Address() common.Address {
+	return at.address
+}
+
+func (at *AccessTuple) StorageKeys() []common.Hash {
+	return at.storageKeys
+}
+
+// Transaction represents an Ethereum transaction.
+// r and hash are mandatory; all others will be fetched when required.
+type Transaction struct {
+	r     *Resolver
+	hash  common.Hash
+	msg   *evmtypes.MsgEthereumTx
+	block *Block
+	index uint64
+}
+
+// resolve returns the message of the transaction, from its block or the mempool. It returns nil if the
+// transaction is unknown.
+func (t *Transaction) resolve() (*evmtypes.MsgEthereumTx, error) {
+	if t.msg != nil {
+		return t.msg, nil
+	}
+
+	if res, err := t.r.backend.GetTxByEthHash(t.hash); err == nil {
+		block := &Block{r: t.r, numberOrHash: blockNumberOrHash(rpctypes.BlockNumber(res.Height))}
+		msgs, err := block.resolveMsgs()
+		if err != nil {
+			return nil, err
+		}
+		for i, msg := range msgs {
+			if common.HexToHash(msg.Hash) == t.hash {
+				t.msg, t.block, t.index = msg, block, uint64(i)
+				return t.msg, nil
+			}
+		}
+	}
+
+	msgs, err := t.r.pendingMsgs()
+	if err != nil {
+		return nil, err
+	}
+	for _, msg := range msgs {
+		if common.HexToHash(msg.Hash) == t.hash {
+			t.msg = msg
+			break
+		}
+	}
+	return t.msg, nil
+}
+
+// tx returns the transaction, it's nil if the transaction is unknown.
+func (t *Transaction) tx() (*ethtypes.Transaction, error) {
+	msg, err := t.resolve()
+	if err != nil || msg == nil {
+		return nil, err
+	}
+	return msg.AsTransaction(), nil
+}
+
+func (t *Transaction) Hash() common.Hash {
+	return t.hash
+}
+
+func (t *Transaction) InputData() (hexutil.Bytes, error) {
+	tx, err := t.tx()
+	if err != nil || tx == nil {
+		return hexutil.Bytes{}, err
+	}
+	return tx.Data(), nil
+}
+
+func (t *Transaction) Gas() (hexutil.Uint64, error) {
+	tx, err := t.tx()
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(tx.Gas()), nil
+}
+
+// baseFee returns the base fee of the block of the transaction, it's nil for the pending transactions.
+func (t *Transaction) baseFee() (*big.Int, error) {
+	if t.block == nil {
+		return nil, nil
+	}
+	header, err := t.block.resolveHeader()
+	if err != nil || header == nil {
+		return nil, err
+	}
+	return header.BaseFee, nil
+}
+
+func (t *Transaction) GasPrice() (hexutil.Big, error) {
+	tx, err := t.tx()
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	if tx.Type() == ethtypes.DynamicFeeTxType {
+		baseFee, err := t.baseFee()
+		if err != nil {
+			return hexutil.Big{}, err
+		}
+		if baseFee != nil {
+			return hexutil.Big(*effectiveGasPrice(tx, baseFee)), nil
+		}
+	}
+	return hexutil.Big(*tx.GasPrice()), nil
+}
+
+func (t *Transaction) EffectiveGasPrice() (*hexutil.Big, error) {
+	tx, err := t.tx()
+	if err != nil || tx == nil || t.block == nil {
+		return nil, err
+	}
+	baseFee, err := t.baseFee()
+	if err != nil {
+		return nil, err
+	}
+	if baseFee == nil {
+		return (*hexutil.Big)(tx.GasPrice()), nil
+	}
+	return (*hexutil.Big)(effectiveGasPrice(tx, baseFee)), nil
+}
+
+// effectiveGasPrice returns min(tip + baseFee, gasFeeCap).
+func effectiveGasPrice(tx *ethtypes.Transaction, baseFee *big.Int) *big.Int {
+	price := new(big.Int).Add(tx.GasTipCap(), baseFee)
+	if price.Cmp(tx.GasFeeCap()) > 0 {
+		return tx.GasFeeCap()
+	}
+	return price
+}
+
+func (t *Transaction) MaxFeePerGas() (*hexutil.Big, error) {
+	tx, err := t.tx()
+	if err != nil || tx == nil || tx.Type() != ethtypes.DynamicFeeTxType {
+		return nil, err
+	}
+	return (*hexutil.Big)(tx.GasFeeCap()), nil
+}
+
+func (t *Transaction) MaxPriorityFeePerGas() (*hexutil.Big, error) {
+	tx, err := t.tx()
+	if err != nil || tx == nil || tx.Type() != ethtypes.DynamicFeeTxType {
+		return nil, err
+	}
+	return (*hexutil.Big)(tx.GasTipCap()), nil
+}
+
+func (t *Transaction) EffectiveTip() (*hexutil.Big, error) {
+	tx, err := t.tx()
+	if err != nil || tx == nil || t.block == nil {
+		return nil, err
+	}
+	baseFee, err := t.baseFee()
+	if err != nil {
+		return nil, err
+	}
+	if baseFee == nil {
+		return (*hexutil.Big)(tx.GasPrice()), nil
+	}
+	tip, err := tx.EffectiveGasTip(baseFee)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(tip), nil
+}
+
+func (t *Transaction) Value() (hexutil.Big, error) {
+	tx, err := t.tx()
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	if tx.Value() == nil {
+		return hexutil.Big{}, fmt.Errorf("invalid transaction value %x", t.hash)
+	}
+	return hexutil.Big(*tx.Value()), nil
+}
+
+func (t *Transaction) Nonce() (hexutil.Uint64, error) {
+	tx, err := t.tx()
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(tx.Nonce()), nil
+}
+
+func (t *Transaction) To(args BlockNumberArgs) (*Account, error) {
+	tx, err := t.tx()
+	if err != nil || tx == nil || tx.To() == nil {
+		return nil, err
+	}
+	return &Account{
+		r:             t.r,
+		address:       *tx.To(),
+		blockNrOrHash: args.NumberOrLatest(),
+	}, nil
+}
+
+func (t *Transaction) From(args BlockNumberArgs) (*Account, error) {
+	msg, err := t.resolve()
+	if err != nil || msg == nil {
+		return nil, err
+	}
+	from, err := msg.GetSender(t.r.backend.ChainConfig().ChainID)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		r:             t.r,
+		address:       from,
+		blockNrOrHash: args.NumberOrLatest(),
+	}, nil
+}
+
+func (t *Transaction) Block() (*Block, error) {
+	if _, err := t.resolve(); err != nil {
+		return nil, err
+	}
+	return t.block, nil
+}
+
+func (t *Transaction) Index() (*int32, error) {
+	if _, err := t.resolve(); err != nil || t.block == nil {
+		return nil, err
+	}
+	index := int32(t.index)
+	return &index, nil
+}
+
+// getReceipt returns the receipt associated with this transaction, if any.
+func (t *Transaction) getReceipt() (*ethtypes.Receipt, error) {
+	if _, err := t.resolve(); err != nil || t.block == nil {
+		return nil, err
+	}
+	receipts, err := t.block.resolveReceipts()
+	if err != nil {
+		return nil, err
+	}
+	if t.index >= uint64(len(receipts)) {
+		return nil, nil
+	}
+	return receipts[t.index], nil
+}
+
+func (t *Transaction) Status() (*Long, error) {
+	receipt, err := t.getReceipt()
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	ret := Long(receipt.Status)
+	return &ret, nil
+}
+
+func (t *Transaction) GasUsed() (*Long, error) {
+	receipt, err := t.getReceipt()
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	ret := Long(receipt.GasUsed)
+	return &ret, nil
+}
+
+func (t *Transaction) CumulativeGasUsed() (*Long, error) {
+	receipt, err := t.getReceipt()
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	ret := Long(receipt.CumulativeGasUsed)
+	return &ret, nil
+}
+
+func (t *Transaction) CreatedContract(args BlockNumberArgs) (*Account, error) {
+	tx, err := t.tx()
+	if err != nil || tx == nil || tx.To() != nil {
+		return nil, err
+	}
+	receipt, err := t.getReceipt()
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	from, err := t.msg.GetSender(t.r.backend.ChainConfig().ChainID)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		r:             t.r,
+		address:       crypto.CreateAddress(from, tx.Nonce()),
+		blockNrOrHash: args.NumberOrLatest(),
+	}, nil
+}
+
+func (t *Transaction) Logs() (*[]*Log, error) {
+	receipt, err := t.getReceipt()
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	ret := make([]*Log, 0, len(receipt.Logs))
+	for _, log := range receipt.Logs {
+		ret = append(ret, &Log{
+			r:           t.r,
+			transaction: t,
+			log:         log,
+		})
+	}
+	return &ret, nil
+}
+
+func (t *Transaction) Type() (*int32, error) {
+	tx, err := t.tx()
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	txType := int32(tx.Type())
+	return &txType, nil
+}
+
+func (t *Transaction) AccessList() (*[]*AccessTuple, error) {
+	tx, err := t.tx()
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	accessList := tx.AccessList()
+	ret := make([]*AccessTuple, 0, len(accessList))
+	for _, al := range accessList {
+		ret = append(ret, &AccessTuple{
+			address:     al.Address,
+			storageKeys: al.StorageKeys,
+		})
+	}
+	return &ret, nil
+}
+
+func (t *Transaction) R() (hexutil.Big, error) {
+	tx, err := t.tx()
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	_, r, _ := tx.RawSignatureValues()
+	return hexutil.Big(*r), nil
+}
+
+func (t *Transaction) S() (hexutil.Big, error) {
+	tx, err := t.tx()
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	_, _, s := tx.RawSignatureValues()
+	return hexutil.Big(*s), nil
+}
+
+func (t *Transaction) V() (hexutil.Big, error) {
+	tx, err := t.tx()
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	v, _, _ := tx.RawSignatureValues()
+	return hexutil.Big(*v), nil
+}
+
+func (t *Transaction) Raw() (hexutil.Bytes, error) {
+	tx, err := t.tx()
+	if err != nil || tx == nil {
+		return hexutil.Bytes{}, err
+	}
+	return tx.MarshalBinary()
+}
+
+func (t *Transaction) RawReceipt() (hexutil.Bytes, error) {
+	receipt, err := t.getReceipt()
+	if err != nil || receipt == nil {
+		return hexutil.Bytes{}, err
+	}
+	return receipt.MarshalBinary()
+}
+
+// Block represents an Ethereum block.
+// r and numberOrHash are mandatory. The Tendermint block and its results are fetched once when required,
+// the other fields are derived from them.
+type Block struct {
+	r            *Resolver
+	numberOrHash rpctypes.BlockNumberOrHash
+	resBlock     *tmrpctypes.ResultBlock
+	blockRes     *tmrpctypes.ResultBlockResults
+	block        *ethtypes.Block
+	msgs         []*evmtypes.MsgEthereumTx
+	receipts     ethtypes.Receipts
+}
+
+// resolve returns the Ethereum block, fetching it if necessary. It returns nil if the block doesn't exist.
+func (b *Block) resolve() (*ethtypes.Block, error) {
+	if b.block != nil {
+		return b.block, nil
+	}
+
+	var err error
+	if b.numberOrHash.BlockHash != nil {
+		b.resBlock, err = b.r.backend.TendermintBlockByHash(*b.numberOrHash.BlockHash)
+	} else {
+		b.resBlock, err = b.r.backend.TendermintBlockByNumber(*b.numberOrHash.BlockNumber)
+	}
+	if err != nil || b.resBlock == nil || b.resBlock.Block == nil {
+		return nil, err
+	}
+
+	b.blockRes, err = b.r.backend.TendermintBlockResultByNumber(&b.resBlock.Block.Height)
+	if err != nil {
+		return nil, fmt.Errorf("block result not found for height %d: %w", b.resBlock.Block.Height, err)
+	}
+
+	b.block, err = b.r.backend.EthBlockFromTendermintBlock(b.resBlock, b.blockRes)
+	if err != nil {
+		return nil, err
+	}
+	// later accesses use the resolved height
+	b.numberOrHash = blockNumberOrHash(rpctypes.BlockNumber(b.resBlock.Block.Height))
+	return b.block, nil
+}
+
+// resolveHeader returns the header of the block, it's nil if the block doesn't exist.
+func (b *Block) resolveHeader() (*ethtypes.Header, error) {
+	block, err := b.resolve()
+	if err != nil || block == nil {
+		return nil, err
+	}
+	return block.Header(), nil
+}
+
+// mustResolveHeader returns the header of the block, or an error if the block doesn't exist.
+func (b *Block) mustResolveHeader() (*ethtypes.Header, error) {
+	header, err := b.resolveHeader()
+	if err == nil && header == nil {
+		err = errBlockNotFound
+	}
+	return header, err
+}
+
+// resolveMsgs returns the Ethereum transaction messages of the block.
+func (b *Block) resolveMsgs() ([]*evmtypes.MsgEthereumTx, error) {
+	if b.msgs == nil {
+		if _, err := b.mustResolveHeader(); err != nil {
+			return nil, err
+		}
+		b.msgs = b.r.backend.EthMsgsFromTendermintBlock(b.resBlock, b.blockRes)
+	}
+	return b.msgs, nil
+}
+
+// resolveReceipts returns the list of receipts for this block, fetching them if necessary.
+func (b *Block) resolveReceipts() (ethtypes.Receipts, error) {
+	if b.receipts == nil {
+		if _, err := b.mustResolveHeader(); err != nil {
+			return nil, err
+		}
+		receipts, err := b.r.backend.EthReceiptsFromTendermintBlock(b.resBlock, b.blockRes)
+		if err != nil {
+			return nil, err
+		}
+		b.receipts = receipts
+	}
+	return b.receipts, nil
+}
+
+func (b *Block) Number() (Long, error) {
+	header, err := b.mustResolveHeader()
+	if err != nil {
+		return 0, err
+	}
+	return Long(header.Number.Int64()), nil
+}
+
+func (b *Block) Hash() (common.Hash, error) {
+	if _, err := b.mustResolveHeader(); err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(b.resBlock.Block.Hash()), nil
+}
+
+func (b *Block) GasLimit() (Long, error) {
+	header, err := b.mustResolveHeader()
+	if err != nil {
+		return 0, err
+	}
+	return Long(header.GasLimit), nil
+}
+
+func (b *Block) GasUsed() (Long, error) {
+	receipts, err := b.resolveReceipts()
+	if err != nil {
+		return 0, err
+	}
+	// the block gas used counts the Ethereum transactions only
+	gasUsed := uint64(0)
+	for _, receipt := range receipts {
+		gasUsed += receipt.GasUsed
+	}
+	return Long(gasUsed), nil
+}
+
+func (b *Block) BaseFeePerGas() (*hexutil.Big, error) {
+	header, err := b.mustResolveHeader()
+	if err != nil || header.BaseFee == nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(header.BaseFee), nil
+}
+
+func (b *Block) NextBaseFeePerGas() (*hexutil.Big, error) {
+	header, err := b.mustResolveHeader()
+	if err != nil || header.BaseFee == nil {
+		return nil, err
+	}
+	// the fee history computes the base fee following the block with the fee market params
+	history, err := b.r.backend.FeeHistory(1, ethrpc.BlockNumber(header.Number.Int64()), nil)
+	if err != nil || len(history.BaseFee) < 2 {
+		return nil, err
+	}
+	return history.BaseFee[1], nil
+}
+
+func (b *Block) Parent() (*Block, error) {
+	header, err := b.mustResolveHeader()
+	if err != nil || header.Number.Int64() < 1 {
+		return nil, err
+	}
+	return &Block{
+		r:            b.r,
+		numberOrHash: blockNumberOrHash(rpctypes.BlockNumber(header.Number.Int64() - 1)),
+	}, nil
+}
+
+func (b *Block) Difficulty() (hexutil.Big, error) {
+	header, err := b.mustResolveHeader()
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*header.Difficulty), nil
+}
+
+func (b *Block) Timestamp() (hexutil.Uint64, error) {
+	header, err := b.mustResolveHeader()
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(header.Time), nil
+}
+
+func (b *Block) Nonce() (hexutil.Bytes, error) {
+	header, err := b.mustResolveHeader()
+	if err != nil {
+		return hexutil.Bytes{}, err
+	}
+	return header.Nonce[:], nil
+}
+
+func (b *Block) MixHash() (common.Hash, error) {
+	header, err := b.mustResolveHeader()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.MixDigest, nil
+}
+
+func (b *Block) TransactionsRoot() (common.Hash, error) {
+	header, err := b.mustResolveHeader()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.TxHash, nil
+}
+
+func (b *Block) StateRoot() (common.Hash, error) {
+	header, err := b.mustResolveHeader()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.Root, nil
+}
+
+func (b *Block) ReceiptsRoot() (common.Hash, error) {
+	header, err := b.mustResolveHeader()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.ReceiptHash, nil
+}
+
+func (b *Block) OmmerHash() (common.Hash, error) {
+	header, err := b.mustResolveHeader()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return header.UncleHash, nil
+}
+
+// OmmerCount returns 0, the blocks have no ommers.
+func (b *Block) OmmerCount() (*int32, error) {
+	if _, err := b.mustResolveHeader(); err != nil {
+		return nil, err
+	}
+	count := int32(0)
+	return &count, nil
+}
+
+// Ommers returns an empty list, the blocks have no ommers.
+func (b *Block) Ommers() (*[]*Block, error) {
+	if _, err := b.mustResolveHeader(); err != nil {
+		return nil, err
+	}
+	return &[]*Block{}, nil
+}
+
+// OmmerAt returns nil, the blocks have no ommers.
+func (b *Block) OmmerAt(args struct{ Index int32 }) (*Block, error) {
+	_, err := b.mustResolveHeader()
+	return nil, err
+}
+
+func (b *Block) ExtraData() (hexutil.Bytes, error) {
+	header, err := b.mustResolveHeader()
+	if err != nil {
+		return hexutil.Bytes{}, err
+	}
+	return header.Extra, nil
+}
+
+func (b *Block) LogsBloom() (hexutil.Bytes, error) {
+	header, err := b.mustResolveHeader()
+	if err != nil {
+		return hexutil.Bytes{}, err
+	}
+	return header.Bloom.Bytes(), nil
+}
+
+// TotalDifficulty returns 0, the blocks have no difficulty.
+func (b *Block) TotalDifficulty() (hexutil.Big, error) {
+	if _, err := b.mustResolveHeader(); err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big{}, nil
+}
+
+func (b *Block) RawHeader() (hexutil.Bytes, error) {
+	header, err := b.mustResolveHeader()
+	if err != nil {
+		return hexutil.Bytes{}, err
+	}
+	return rlp.EncodeToBytes(header)
+}
+
+func (b *Block) Raw() (hexutil.Bytes, error) {
+	if _, err := b.mustResolveHeader(); err != nil {
+		return hexutil.Bytes{}, err
+	}
+	return rlp.EncodeToBytes(b.block)
+}
+
+// BlockNumberArgs encapsulates arguments to accessors that specify a block number.
+type BlockNumberArgs struct {
+	Block *hexutil.Uint64
+}
+
+// NumberOrLatest returns the provided block number argument, or the "latest" block number if none
+// was provided.
+func (a BlockNumberArgs) NumberOrLatest() rpctypes.BlockNumberOrHash {
+	if a.Block != nil {
+		return blockNumberOrHash(rpctypes.BlockNumber(*a.Block))
+	}
+	return blockNumberOrHash(rpctypes.EthLatestBlockNumber)
+}
+
+func (b *Block) Miner(args BlockNumberArgs) (*Account, error) {
+	header, err := b.mustResolveHeader()
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		r:             b.r,
+		address:       header.Coinbase,
+		blockNrOrHash: args.NumberOrLatest(),
+	}, nil
+}
+
+func (b *Block) TransactionCount() (*int32, error) {
+	msgs, err := b.resolveMsgs()
+	if err != nil {
+		return nil, err
+	}
+	count := int32(len(msgs))
+	return &count, nil
+}
+
+func (b *Block) Transactions() (*[]*Transaction, error) {
+	msgs, err := b.resolveMsgs()
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*Transaction, 0, len(msgs))
+	for i, msg := range msgs {
+		ret = append(ret, &Transaction{
+			r:     b.r,
+			hash:  common.HexToHash(msg.Hash),
+			msg:   msg,
+			block: b,
+			index: uint64(i),
+		})
+	}
+	return &ret, nil
+}
+
+func (b *Block) TransactionAt(args struct{ Index int32 }) (*Transaction, error) {
+	msgs, err := b.resolveMsgs()
+	if err != nil {
+		return nil, err
+	}
+	if args.Index < 0 || int(args.Index) >= len(msgs) {
+		return nil, nil
+	}
+	msg := msgs[args.Index]
+	return &Transaction{
+		r:     b.r,
+		hash:  common.HexToHash(msg.Hash),
+		msg:   msg,
+		block: b,
+		index: uint64(args.Index),
+	}, nil
+}
+
+// BlockFilterCriteria encapsulates criteria passed to a `logs` accessor inside
+// a block.
+type BlockFilterCriteria struct {
+	Addresses *[]common.Address // restricts matches to events created by specific contracts
+
+	// The Topic list restricts matches to particular event topics, see FilterCriteria.
+	Topics *[][]common.Hash
+}
+
+func (b *Block) Logs(args struct{ Filter BlockFilterCriteria }) ([]*Log, error) {
+	msgs, err := b.resolveMsgs()
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := b.resolveReceipts()
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []common.Address
+	if args.Filter.Addresses != nil {
+		addresses = *args.Filter.Addresses
+	}
+	var topics [][]common.Hash
+	if args.Filter.Topics != nil {
+		topics = *args.Filter.Topics
+	}
+
+	ret := []*Log{}
+	for i, receipt := range receipts {
+		tx := &Transaction{
+			r:     b.r,
+			hash:  receipt.TxHash,
+			msg:   msgs[i],
+			block: b,
+			index: uint64(i),
+		}
+		for _, log := range filters.FilterLogs(receipt.Logs, nil, nil, addresses, topics) {
+			ret = append(ret, &Log{r: b.r, transaction: tx, log: log})
+		}
+	}
+	return ret, nil
+}
+
+func (b *Block) Account(args struct {
+	Address common.Address
+}) (*Account, error) {
+	if _, err := b.mustResolveHeader(); err != nil {
+		return nil, err
+	}
+	return &Account{
+		r:             b.r,
+		address:       args.Address,
+		blockNrOrHash: b.numberOrHash,
+	}, nil
+}
+
+// CallData encapsulates arguments to `call` or `estimateGas`.
+// All arguments are optional.
+type CallData struct {
+	From                 *common.Address // The Ethereum address the call is from.
+	To                   *common.Address // The Ethereum address the call is to.
+	Gas                  *hexutil.Uint64 // The amount of gas provided for the call.
+	GasPrice             *hexutil.Big    // The price of each unit of gas, in wei.
+	MaxFeePerGas         *hexutil.Big    // The max price of each unit of gas, in wei (1559).
+	MaxPriorityFeePerGas *hexutil.Big    // The max tip of each unit of gas, in wei (1559).
+	Value                *hexutil.Big    // The value sent along with the call.
+	Data                 *hexutil.Bytes  // Any data sent with the call.
+}
+
+func (c CallData) args() evmtypes.TransactionArgs {
+	return evmtypes.TransactionArgs{
+		From:                 c.From,
+		To:                   c.To,
+		Gas:                  c.Gas,
+		GasPrice:             c.GasPrice,
+		MaxFeePerGas:         c.MaxFeePerGas,
+		MaxPriorityFeePerGas: c.MaxPriorityFeePerGas,
+		Value:                c.Value,
+		Data:                 c.Data,
+	}
+}
+
+// CallResult encapsulates the result of an invocation of the `call` accessor.
+type CallResult struct {
+	data    hexutil.Bytes // The return data from the call
+	gasUsed Long          // The amount of gas used
+	status  Long          // The return status of the call - 0 for failure or 1 for success.
+}
+
+func (c *CallResult) Data() hexutil.Bytes {
+	return c.data
+}
+
+func (c *CallResult) GasUsed() Long {
+	return c.gasUsed
+}
+
+func (c *CallResult) Status() Long {
+	return c.status
+}
+
+func (r *Resolver) call(data CallData, blockNum rpctypes.BlockNumber) (*CallResult, error) {
+	if err := r.checkMethod("eth_call"); err != nil {
+		return nil, err
+	}
+	res, err := r.backend.DoCall(data.args(), blockNum)
+	if err != nil {
+		return nil, err
+	}
+	status := Long(1)
+	if res.Failed() {
+		status = 0
+	}
+	return &CallResult{
+		data:    res.Ret,
+		gasUsed: Long(res.GasUsed),
+		status:  status,
+	}, nil
+}
+
+func (r *Resolver) estimateGas(data CallData, blockNum rpctypes.BlockNumber) (Long, error) {
+	if err := r.checkMethod("eth_estimateGas"); err != nil {
+		return 0, err
+	}
+	gas, err := r.backend.EstimateGas(data.args(), &blockNum)
+	return Long(gas), err
+}
+
+func (b *Block) Call(args struct{ Data CallData }) (*CallResult, error) {
+	if _, err := b.mustResolveHeader(); err != nil {
+		return nil, err
+	}
+	return b.r.call(args.Data, *b.numberOrHash.BlockNumber)
+}
+
+func (b *Block) EstimateGas(args struct{ Data CallData }) (Long, error) {
+	if _, err := b.mustResolveHeader(); err != nil {
+		return 0, err
+	}
+	return b.r.estimateGas(args.Data, *b.numberOrHash.BlockNumber)
+}
+
+// Pending represents the pending state, the mempool transactions applied on the latest state.
+type Pending struct {
+	r *Resolver
+}
+
+func (p *Pending) TransactionCount() (int32, error) {
+	msgs, err := p.r.pendingMsgs()
+	return int32(len(msgs)), err
+}
+
+func (p *Pending) Transactions() (*[]*Transaction, error) {
+	msgs, err := p.r.pendingMsgs()
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*Transaction, 0, len(msgs))
+	for i, msg := range msgs {
+		ret = append(ret, &Transaction{
+			r:     p.r,
+			hash:  common.HexToHash(msg.Hash),
+			msg:   msg,
+			index: uint64(i),
+		})
+	}
+	return &ret, nil
+}
+
+func (p *Pending) Account(args struct {
+	Address common.Address
+}) *Account {
+	return &Account{
+		r:             p.r,
+		address:       args.Address,
+		blockNrOrHash: blockNumberOrHash(rpctypes.EthPendingBlockNumber),
+	}
+}
+
+func (p *Pending) Call(args struct{ Data CallData }) (*CallResult, error) {
+	return p.r.call(args.Data, rpctypes.EthPendingBlockNumber)
+}
+
+func (p *Pending) EstimateGas(args struct{ Data CallData }) (Long, error) {
+	return p.r.estimateGas(args.Data, rpctypes.EthPendingBlockNumber)
+}
+
+// Resolver is the top-level object in the GraphQL hierarchy.
+type Resolver struct {
+	backend       Backend
+	logger        log.Logger
+	methodAllowed func(method string) bool
+}
+
+// checkMethod returns an error if the eth_ method matching the field is disabled on the JSON-RPC server.
+func (r *Resolver) checkMethod(method string) error {
+	if r.methodAllowed != nil && !r.methodAllowed(method) {
+		return fmt.Errorf("the method %s is disabled on this node", method)
+	}
+	return nil
+}
+
+// pendingMsgs returns the Ethereum transaction messages of the mempool.
+func (r *Resolver) pendingMsgs() ([]*evmtypes.MsgEthereumTx, error) {
+	txs, err := r.backend.PendingTransactions()
+	if err != nil {
+		return nil, err
+	}
+	var msgs []*evmtypes.MsgEthereumTx
+	for _, tx := range txs {
+		for _, msg := range (*tx).GetMsgs() {
+			if ethMsg, ok := msg.(*evmtypes.MsgEthereumTx); ok {
+				msgs = append(msgs, ethMsg)
+			}
+		}
+	}
+	return msgs, nil
+}
+
+func (r *Resolver) Block(args struct {
+	Number *Long
+	Hash   *common.Hash
+}) (*Block, error) {
+	block := &Block{r: r, numberOrHash: blockNumberOrHash(rpctypes.EthLatestBlockNumber)}
+	switch {
+	case args.Number != nil:
+		if *args.Number < 0 {
+			return nil, nil
+		}
+		block.numberOrHash = blockNumberOrHash(rpctypes.BlockNumber(*args.Number))
+	case args.Hash != nil:
+		block.numberOrHash = rpctypes.BlockNumberOrHash{BlockHash: args.Hash}
+	}
+
+	// return nil if the block doesn't exist
+	header, err := block.resolveHeader()
+	if err != nil || header == nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (r *Resolver) Blocks(args struct {
+	From *Long
+	To   *Long
+}) ([]*Block, error) {
+	var from, to rpctypes.BlockNumber
+	if args.From != nil {
+		from = rpctypes.BlockNumber(*args.From)
+	}
+	if args.To != nil {
+		to = rpctypes.BlockNumber(*args.To)
+	} else {
+		latest, err := r.backend.BlockNumber()
+		if err != nil {
+			return nil, err
+		}
+		to = rpctypes.BlockNumber(latest)
+	}
+	if to < from {
+		return []*Block{}, nil
+	}
+	if limit := r.backend.RPCBlockRangeCap(); limit > 0 && int64(to-from) > int64(limit) {
+		return nil, fmt.Errorf("maximum [from, to] blocks distance: %d", limit)
+	}
+
+	ret := make([]*Block, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		block := &Block{r: r, numberOrHash: blockNumberOrHash(i)}
+		header, err := block.resolveHeader()
+		if err != nil {
+			return nil, err
+		}
+		if header == nil {
+			// blocks after must be non-existent too
+			break
+		}
+		ret = append(ret, block)
+	}
+	return ret, nil
+}
+
+func (r *Resolver) Pending() *Pending {
+	return &Pending{r}
+}
+
+func (r *Resolver) Transaction(args struct{ Hash common.Hash }) (*Transaction, error) {
+	tx := &Transaction{
+		r:    r,
+		hash: args.Hash,
+	}
+	// return nil if the transaction doesn't exist
+	msg, err := tx.resolve()
+	if err != nil || msg == nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func (r *Resolver) SendRawTransaction(args struct{ Data hexutil.Bytes }) (common.Hash, error) {
+	if err := r.checkMethod("eth_sendRawTransaction"); err != nil {
+		return common.Hash{}, err
+	}
+	return r.backend.SendRawTransaction(args.Data)
+}
+
+// FilterCriteria encapsulates the arguments to `logs` on the root resolver object.
+type FilterCriteria struct {
+	FromBlock *hexutil.Uint64   // beginning of the queried range, nil means latest block
+	ToBlock   *hexutil.Uint64   // end of the range, nil means latest block
+	Addresses *[]common.Address // restricts matches to events created by specific contracts
+
+	// The Topic list restricts matches to particular event topics. Each event has a list
+	// of topics. Topics matches a prefix of that list. An empty element slice matches any
+	// topic. Non-empty elements represent an alternative that matches any of the
+	// contained topics.
+	//
+	// Examples:
+	// {} or nil          matches any topic list
+	// {{A}}              matches topic A in first position
+	// {{}, {B}}          matches any topic in first position, B in second position
+	// {{A}, {B}}         matches topic A in first position, B in second position
+	// {{A, B}}, {C, D}}  matches topic (A OR B) in first position, (C OR D) in second position
+	Topics *[][]common.Hash
+}
+
+func (r *Resolver) Logs(ctx context.Context, args struct{ Filter FilterCriteria }) ([]*Log, error) {
+	if err := r.checkMethod("eth_getLogs"); err != nil {
+		return nil, err
+	}
+	begin := rpctypes.EthLatestBlockNumber.Int64()
+	if args.Filter.FromBlock != nil {
+		begin = int64(*args.Filter.FromBlock)
+	}
+	end := rpctypes.EthLatestBlockNumber.Int64()
+	if args.Filter.ToBlock != nil {
+		end = int64(*args.Filter.ToBlock)
+	}
+	var addresses []common.Address
+	if args.Filter.Addresses != nil {
+		addresses = *args.Filter.Addresses
+	}
+	var topics [][]common.Hash
+	if args.Filter.Topics != nil {
+		topics = *args.Filter.Topics
+	}
+
+	filter := filters.NewRangeFilter(r.logger, r.backend, begin, end, addresses, topics)
+	logs, err := filter.Logs(ctx, int(r.backend.RPCLogsCap()), int64(r.backend.RPCBlockRangeCap()))
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*Log, 0, len(logs))
+	for _, log := range logs {
+		ret = append(ret, &Log{
+			r:           r,
+			transaction: &Transaction{r: r, hash: log.TxHash},
+			log:         log,
+		})
+	}
+	return ret, nil
+}
+
+func (r *Resolver) GasPrice() (hexutil.Big, error) {
+	price, err := r.backend.GasPrice()
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return *price, nil
+}
+
+func (r *Resolver) MaxPriorityFeePerGas() (hexutil.Big, error) {
+	header, err := r.backend.HeaderByNumber(rpctypes.EthLatestBlockNumber)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	tipcap, err := r.backend.SuggestGasTipCap(header.BaseFee)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*tipcap), nil
+}
+
+func (r *Resolver) ChainID() (hexutil.Big, error) {
+	chainID, err := r.backend.ChainID()
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return *chainID, nil
+}
+
+// SyncState represents the synchronisation status returned from the `syncing` accessor.
+type SyncState struct {
+	startingBlock hexutil.Uint64
+	currentBlock  hexutil.Uint64
+}
+
+func (s *SyncState) StartingBlock() hexutil.Uint64 {
+	return s.startingBlock
+}
+
+func (s *SyncState) CurrentBlock() hexutil.Uint64 {
+	return s.currentBlock
+}
+
+// HighestBlock returns the current block, the highest block isn't known while catching up.
+func (s *SyncState) HighestBlock() hexutil.Uint64 {
+	return s.currentBlock
+}
+
+// Syncing returns nil if the node isn't catching up with the network.
+func (r *Resolver) Syncing() (*SyncState, error) {
+	res, err := r.backend.Syncing()
+	if err != nil {
+		return nil, err
+	}
+	progress, ok := res.(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	state := &SyncState{}
+	state.startingBlock, _ = progress["startingBlock"].(hexutil.Uint64)
+	state.currentBlock, _ = progress["currentBlock"].(hexutil.Uint64)
+	return state, nil
+}
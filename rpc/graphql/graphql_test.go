@@ -0,0 +1,278 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+	"github.com/evmos/ethermint/tests"
+	ethermint "github.com/evmos/ethermint/types"
+	evmtypes "github.com/evmos/ethermint/x/evm/types"
+)
+
+// testBlock is the block 1 of the mock backend, it contains a transfer emitting a log.
+type testBlock struct {
+	backend  *mockBackend
+	msg      *evmtypes.MsgEthereumTx
+	from     common.Address
+	to       common.Address
+	log      *ethtypes.Log
+	resBlock *tmrpctypes.ResultBlock
+	blockRes *tmrpctypes.ResultBlockResults
+	block    *ethtypes.Block
+}
+
+func newTestBlock(t *testing.T) *testBlock {
+	chainID := big.NewInt(9000)
+	from, priv := tests.NewAddrKey()
+	to := tests.GenerateAddress()
+	msg := evmtypes.NewTx(chainID, 3, &to, big.NewInt(10), 21000, big.NewInt(1), nil, nil, nil, nil)
+	msg.From = from.Hex()
+	require.NoError(t, msg.Sign(ethtypes.LatestSignerForChainID(chainID), tests.NewSigner(priv)))
+	txHash := common.HexToHash(msg.Hash)
+
+	log := &ethtypes.Log{
+		Address:     to,
+		Topics:      []common.Hash{common.BigToHash(big.NewInt(1))},
+		Data:        []byte{1, 2},
+		BlockNumber: 1,
+		TxHash:      txHash,
+	}
+	logJSON, err := json.Marshal(evmtypes.NewLogFromEth(log))
+	require.NoError(t, err)
+
+	resBlock := &tmrpctypes.ResultBlock{Block: tmtypes.MakeBlock(1, nil, &tmtypes.Commit{}, nil)}
+	blockRes := &tmrpctypes.ResultBlockResults{
+		Height: 1,
+		TxsResults: []*abci.ResponseDeliverTx{{
+			Events: []abci.Event{{
+				Type:       evmtypes.EventTypeTxLog,
+				Attributes: []abci.EventAttribute{{Key: evmtypes.AttributeKeyTxLog, Value: string(logJSON)}},
+			}},
+		}},
+	}
+	receipt := &ethtypes.Receipt{
+		Status:            ethtypes.ReceiptStatusSuccessful,
+		GasUsed:           21000,
+		CumulativeGasUsed: 21000,
+		Logs:              []*ethtypes.Log{log},
+		TxHash:            txHash,
+	}
+	header := &ethtypes.Header{Number: big.NewInt(1), GasLimit: 10000000, GasUsed: 21000, Difficulty: big.NewInt(0)}
+	block := ethtypes.NewBlock(header, []*ethtypes.Transaction{msg.AsTransaction()}, nil, []*ethtypes.Receipt{receipt}, trie.NewStackTrie(nil))
+
+	height := int64(1)
+	backend := &mockBackend{}
+	backend.On("TendermintBlockByNumber", rpctypes.BlockNumber(1)).Return(resBlock, nil)
+	backend.On("TendermintBlockByNumber", rpctypes.BlockNumber(2)).Return(nil, nil)
+	backend.On("TendermintBlockResultByNumber", &height).Return(blockRes, nil)
+	backend.On("EthBlockFromTendermintBlock", resBlock, blockRes).Return(block, nil)
+	backend.On("EthMsgsFromTendermintBlock", resBlock, blockRes).Return([]*evmtypes.MsgEthereumTx{msg})
+	backend.On("EthReceiptsFromTendermintBlock", resBlock, blockRes).Return(ethtypes.Receipts{receipt}, nil)
+	backend.On("ChainConfig").Return(&params.ChainConfig{ChainID: chainID})
+	backend.On("RPCBlockRangeCap").Return(int32(10000))
+	backend.On("RPCLogsCap").Return(int32(10000))
+
+	return &testBlock{
+		backend:  backend,
+		msg:      msg,
+		from:     from,
+		to:       to,
+		log:      log,
+		resBlock: resBlock,
+		blockRes: blockRes,
+		block:    block,
+	}
+}
+
+// query answers the GraphQL query with the handler, it returns the status code and the response.
+func query(t *testing.T, handler http.Handler, query string) (int, string) {
+	body, err := json.Marshal(map[string]string{"query": query})
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/graphql", strings.NewReader(string(body))))
+	return rec.Code, rec.Body.String()
+}
+
+func newTestHandler(t *testing.T, backend Backend, methodAllowed func(string) bool) http.Handler {
+	handler, err := NewHandler(log.NewNopLogger(), backend, methodAllowed)
+	require.NoError(t, err)
+	return handler
+}
+
+func TestSchemaResolvers(t *testing.T) {
+	// the parsing fails if a field of the schema has no resolver
+	_, err := graphql.ParseSchema(schema, &Resolver{})
+	require.NoError(t, err)
+}
+
+func TestLongUnmarshalGraphQL(t *testing.T) {
+	testCases := []struct {
+		input   interface{}
+		exp     Long
+		expPass bool
+	}{
+		{"12", 12, true},
+		{int32(12), 12, true},
+		{int64(12), 12, true},
+		{float64(12), 12, true},
+		{"0x0c", 0, false},
+		{true, 0, false},
+	}
+	for _, tc := range testCases {
+		var l Long
+		err := l.UnmarshalGraphQL(tc.input)
+		if !tc.expPass {
+			require.Error(t, err, tc.input)
+			continue
+		}
+		require.NoError(t, err, tc.input)
+		require.Equal(t, tc.exp, l)
+	}
+}
+
+func TestBlockResolvers(t *testing.T) {
+	tb := newTestBlock(t)
+	handler := newTestHandler(t, tb.backend, nil)
+
+	code, res := query(t, handler, `{ block(number: 1) { number hash gasUsed transactionCount logsBloom
+		transactions { hash index nonce value status gasUsed cumulativeGasUsed from { address } to { address } } } }`)
+	require.Equal(t, http.StatusOK, code, res)
+	require.JSONEq(t, fmt.Sprintf(`{"data":{"block":{
+		"number":1,"hash":"%s","gasUsed":21000,"transactionCount":1,"logsBloom":"%s",
+		"transactions":[{"hash":"%s","index":0,"nonce":"0x3","value":"0xa","status":1,"gasUsed":21000,
+			"cumulativeGasUsed":21000,"from":{"address":"%s"},"to":{"address":"%s"}}]}}}`,
+		common.BytesToHash(tb.resBlock.Block.Hash()).Hex(), hexutil.Encode(tb.block.Bloom().Bytes()), strings.ToLower(tb.msg.Hash),
+		strings.ToLower(tb.from.Hex()), strings.ToLower(tb.to.Hex()),
+	), res)
+
+	// the blocks after the latest one don't exist
+	code, res = query(t, handler, `{ block(number: 2) { number } }`)
+	require.Equal(t, http.StatusOK, code, res)
+	require.JSONEq(t, `{"data":{"block":null}}`, res)
+
+	code, res = query(t, handler, `{ blocks(from: 1, to: 2) { number } }`)
+	require.Equal(t, http.StatusOK, code, res)
+	require.JSONEq(t, `{"data":{"blocks":[{"number":1}]}}`, res)
+}
+
+func TestTransactionResolvers(t *testing.T) {
+	tb := newTestBlock(t)
+	txHash := common.HexToHash(tb.msg.Hash)
+	unknown := common.BigToHash(big.NewInt(1))
+	tb.backend.On("GetTxByEthHash", txHash).Return(&ethermint.TxResult{Height: 1}, nil)
+	tb.backend.On("GetTxByEthHash", unknown).Return(nil, fmt.Errorf("not found"))
+	tb.backend.On("PendingTransactions").Return([]*sdk.Tx{}, nil)
+	handler := newTestHandler(t, tb.backend, nil)
+
+	code, res := query(t, handler, fmt.Sprintf(`{ transaction(hash: "%s") { index status block { number }
+		logs { index data topics account { address } } } }`, txHash.Hex()))
+	require.Equal(t, http.StatusOK, code, res)
+	require.JSONEq(t, fmt.Sprintf(`{"data":{"transaction":{"index":0,"status":1,"block":{"number":1},
+		"logs":[{"index":0,"data":"0x0102","topics":["%s"],"account":{"address":"%s"}}]}}}`,
+		tb.log.Topics[0].Hex(), strings.ToLower(tb.to.Hex()),
+	), res)
+
+	code, res = query(t, handler, fmt.Sprintf(`{ transaction(hash: "%s") { hash } }`, unknown.Hex()))
+	require.Equal(t, http.StatusOK, code, res)
+	require.JSONEq(t, `{"data":{"transaction":null}}`, res)
+}
+
+func TestLogsResolver(t *testing.T) {
+	tb := newTestBlock(t)
+	tb.backend.On("HeaderByNumber", rpctypes.EthLatestBlockNumber).Return(tb.block.Header(), nil)
+	tb.backend.On("BlockBloom", tb.blockRes).Return(tb.block.Bloom(), nil)
+	handler := newTestHandler(t, tb.backend, nil)
+
+	code, res := query(t, handler, fmt.Sprintf(`{ logs(filter: {fromBlock: 1, toBlock: 1, addresses: ["%s"]}) {
+		index data transaction { hash } } }`, tb.to.Hex()))
+	require.Equal(t, http.StatusOK, code, res)
+	require.JSONEq(t, fmt.Sprintf(`{"data":{"logs":[{"index":0,"data":"0x0102","transaction":{"hash":"%s"}}]}}`,
+		strings.ToLower(tb.msg.Hash)), res)
+
+	// the logs of other contracts are filtered out
+	code, res = query(t, handler, fmt.Sprintf(`{ logs(filter: {fromBlock: 1, toBlock: 1, addresses: ["%s"]}) {
+		index } }`, tb.from.Hex()))
+	require.Equal(t, http.StatusOK, code, res)
+	require.JSONEq(t, `{"data":{"logs":[]}}`, res)
+}
+
+func TestAccountResolvers(t *testing.T) {
+	addr := tests.GenerateAddress()
+	slot := common.BigToHash(big.NewInt(1))
+	pending := blockNumberOrHash(rpctypes.EthPendingBlockNumber)
+	nonce := hexutil.Uint64(2)
+
+	backend := &mockBackend{}
+	backend.On("GetBalance", addr, pending).Return((*hexutil.Big)(big.NewInt(100)), nil)
+	backend.On("BlockNumberFromTendermint", pending).Return(rpctypes.EthPendingBlockNumber, nil)
+	backend.On("GetTransactionCount", addr, rpctypes.EthPendingBlockNumber).Return(&nonce, nil)
+	backend.On("GetCode", addr, pending).Return(hexutil.Bytes{0x60}, nil)
+	backend.On("GetStorageAt", addr, slot.Hex(), pending).Return(hexutil.Bytes{0x01}, nil)
+	handler := newTestHandler(t, backend, nil)
+
+	code, res := query(t, handler, fmt.Sprintf(`{ pending { account(address: "%s") {
+		address balance transactionCount code storage(slot: "%s") } } }`, addr.Hex(), slot.Hex()))
+	require.Equal(t, http.StatusOK, code, res)
+	require.JSONEq(t, fmt.Sprintf(`{"data":{"pending":{"account":{"address":"%s","balance":"0x64",
+		"transactionCount":"0x2","code":"0x60","storage":"%s"}}}}`, strings.ToLower(addr.Hex()), slot.Hex()), res)
+}
+
+func TestCallResolvers(t *testing.T) {
+	tb := newTestBlock(t)
+	to := tb.to
+	args := evmtypes.TransactionArgs{To: &to}
+	blockNum := rpctypes.BlockNumber(1)
+	tb.backend.On("DoCall", args, blockNum).Return(&evmtypes.MsgEthereumTxResponse{Ret: []byte{1}, GasUsed: 100}, nil)
+	tb.backend.On("DoCall", args, rpctypes.EthPendingBlockNumber).
+		Return(&evmtypes.MsgEthereumTxResponse{GasUsed: 200, VmError: "execution reverted"}, nil)
+	tb.backend.On("EstimateGas", args, &blockNum).Return(hexutil.Uint64(21000), nil)
+	handler := newTestHandler(t, tb.backend, nil)
+
+	code, res := query(t, handler, fmt.Sprintf(`{ block(number: 1) { call(data: {to: "%[1]s"}) { data gasUsed status }
+		estimateGas(data: {to: "%[1]s"}) } pending { call(data: {to: "%[1]s"}) { data gasUsed status } } }`, to.Hex()))
+	require.Equal(t, http.StatusOK, code, res)
+	require.JSONEq(t, `{"data":{"block":{"call":{"data":"0x01","gasUsed":100,"status":1},"estimateGas":21000},
+		"pending":{"call":{"data":"0x","gasUsed":200,"status":0}}}}`, res)
+}
+
+func TestDisabledMethods(t *testing.T) {
+	backend := &mockBackend{}
+	handler := newTestHandler(t, backend, func(method string) bool {
+		return method != "eth_call" && method != "eth_estimateGas" && method != "eth_sendRawTransaction" &&
+			method != "eth_getLogs"
+	})
+
+	for _, q := range []string{
+		`{ pending { call(data: {}) { data } } }`,
+		`{ pending { estimateGas(data: {}) } }`,
+		`{ logs(filter: {}) { index } }`,
+		`mutation { sendRawTransaction(data: "0x01") }`,
+	} {
+		code, res := query(t, handler, q)
+		require.Equal(t, http.StatusBadRequest, code, q)
+		require.Contains(t, res, "is disabled on this node", q)
+	}
+	backend.AssertNotCalled(t, "DoCall", mock.Anything, mock.Anything)
+	backend.AssertNotCalled(t, "EstimateGas", mock.Anything, mock.Anything)
+	backend.AssertNotCalled(t, "SendRawTransaction", mock.Anything)
+}
@@ -0,0 +1,154 @@
+package graphql
+
+import (
+	tmrpctypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/mock"
+
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+	ethermint "github.com/evmos/ethermint/types"
+	evmtypes "github.com/evmos/ethermint/x/evm/types"
+)
+
+// mockBackend is a mock of the methods of the backend used by the resolvers, the other ones panic.
+type mockBackend struct {
+	Backend
+	mock.Mock
+}
+
+func (m *mockBackend) BlockNumber() (hexutil.Uint64, error) {
+	args := m.Called()
+	return args.Get(0).(hexutil.Uint64), args.Error(1)
+}
+
+func (m *mockBackend) ChainConfig() *params.ChainConfig {
+	return m.Called().Get(0).(*params.ChainConfig)
+}
+
+func (m *mockBackend) RPCLogsCap() int32 {
+	return m.Called().Get(0).(int32)
+}
+
+func (m *mockBackend) RPCBlockRangeCap() int32 {
+	return m.Called().Get(0).(int32)
+}
+
+func (m *mockBackend) TendermintBlockByNumber(blockNum rpctypes.BlockNumber) (*tmrpctypes.ResultBlock, error) {
+	args := m.Called(blockNum)
+	res, _ := args.Get(0).(*tmrpctypes.ResultBlock)
+	return res, args.Error(1)
+}
+
+func (m *mockBackend) TendermintBlockByHash(blockHash common.Hash) (*tmrpctypes.ResultBlock, error) {
+	args := m.Called(blockHash)
+	res, _ := args.Get(0).(*tmrpctypes.ResultBlock)
+	return res, args.Error(1)
+}
+
+func (m *mockBackend) TendermintBlockResultByNumber(height *int64) (*tmrpctypes.ResultBlockResults, error) {
+	args := m.Called(height)
+	res, _ := args.Get(0).(*tmrpctypes.ResultBlockResults)
+	return res, args.Error(1)
+}
+
+func (m *mockBackend) EthBlockFromTendermintBlock(
+	resBlock *tmrpctypes.ResultBlock,
+	blockRes *tmrpctypes.ResultBlockResults,
+) (*ethtypes.Block, error) {
+	args := m.Called(resBlock, blockRes)
+	res, _ := args.Get(0).(*ethtypes.Block)
+	return res, args.Error(1)
+}
+
+func (m *mockBackend) EthMsgsFromTendermintBlock(
+	resBlock *tmrpctypes.ResultBlock,
+	blockRes *tmrpctypes.ResultBlockResults,
+) []*evmtypes.MsgEthereumTx {
+	res, _ := m.Called(resBlock, blockRes).Get(0).([]*evmtypes.MsgEthereumTx)
+	return res
+}
+
+func (m *mockBackend) EthReceiptsFromTendermintBlock(
+	resBlock *tmrpctypes.ResultBlock,
+	blockRes *tmrpctypes.ResultBlockResults,
+) (ethtypes.Receipts, error) {
+	args := m.Called(resBlock, blockRes)
+	res, _ := args.Get(0).(ethtypes.Receipts)
+	return res, args.Error(1)
+}
+
+func (m *mockBackend) HeaderByNumber(blockNum rpctypes.BlockNumber) (*ethtypes.Header, error) {
+	args := m.Called(blockNum)
+	res, _ := args.Get(0).(*ethtypes.Header)
+	return res, args.Error(1)
+}
+
+func (m *mockBackend) BlockBloom(blockRes *tmrpctypes.ResultBlockResults) (ethtypes.Bloom, error) {
+	args := m.Called(blockRes)
+	return args.Get(0).(ethtypes.Bloom), args.Error(1)
+}
+
+func (m *mockBackend) GetTxByEthHash(txHash common.Hash) (*ethermint.TxResult, error) {
+	args := m.Called(txHash)
+	res, _ := args.Get(0).(*ethermint.TxResult)
+	return res, args.Error(1)
+}
+
+func (m *mockBackend) PendingTransactions() ([]*sdk.Tx, error) {
+	args := m.Called()
+	res, _ := args.Get(0).([]*sdk.Tx)
+	return res, args.Error(1)
+}
+
+func (m *mockBackend) BlockNumberFromTendermint(blockNrOrHash rpctypes.BlockNumberOrHash) (rpctypes.BlockNumber, error) {
+	args := m.Called(blockNrOrHash)
+	return args.Get(0).(rpctypes.BlockNumber), args.Error(1)
+}
+
+func (m *mockBackend) GetBalance(address common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (*hexutil.Big, error) {
+	args := m.Called(address, blockNrOrHash)
+	res, _ := args.Get(0).(*hexutil.Big)
+	return res, args.Error(1)
+}
+
+func (m *mockBackend) GetTransactionCount(address common.Address, blockNum rpctypes.BlockNumber) (*hexutil.Uint64, error) {
+	args := m.Called(address, blockNum)
+	res, _ := args.Get(0).(*hexutil.Uint64)
+	return res, args.Error(1)
+}
+
+func (m *mockBackend) GetCode(address common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (hexutil.Bytes, error) {
+	args := m.Called(address, blockNrOrHash)
+	res, _ := args.Get(0).(hexutil.Bytes)
+	return res, args.Error(1)
+}
+
+func (m *mockBackend) GetStorageAt(
+	address common.Address,
+	key string,
+	blockNrOrHash rpctypes.BlockNumberOrHash,
+) (hexutil.Bytes, error) {
+	args := m.Called(address, key, blockNrOrHash)
+	res, _ := args.Get(0).(hexutil.Bytes)
+	return res, args.Error(1)
+}
+
+func (m *mockBackend) DoCall(args evmtypes.TransactionArgs, blockNr rpctypes.BlockNumber) (*evmtypes.MsgEthereumTxResponse, error) {
+	ret := m.Called(args, blockNr)
+	res, _ := ret.Get(0).(*evmtypes.MsgEthereumTxResponse)
+	return res, ret.Error(1)
+}
+
+func (m *mockBackend) EstimateGas(args evmtypes.TransactionArgs, blockNrOptional *rpctypes.BlockNumber) (hexutil.Uint64, error) {
+	ret := m.Called(args, blockNrOptional)
+	return ret.Get(0).(hexutil.Uint64), ret.Error(1)
+}
+
+func (m *mockBackend) SendRawTransaction(data hexutil.Bytes) (common.Hash, error) {
+	args := m.Called(data)
+	return args.Get(0).(common.Hash), args.Error(1)
+}
@@ -0,0 +1,65 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// handler answers the GraphQL queries.
+type handler struct {
+	schema *graphql.Schema
+}
+
+func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response := h.schema.Exec(r.Context(), params.Query, params.OperationName, params.Variables)
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if len(response.Errors) > 0 {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	_, _ = w.Write(responseJSON)
+}
+
+// NewHandler returns the http.Handler answering the GraphQL queries of the go-ethereum schema with the backend.
+// The calls, gas estimations, log queries and sent transactions are rejected when methodAllowed denies the matching
+// eth_ method.
+func NewHandler(logger log.Logger, backend Backend, methodAllowed func(method string) bool) (http.Handler, error) {
+	s, err := graphql.ParseSchema(schema, &Resolver{backend: backend, logger: logger, methodAllowed: methodAllowed})
+	if err != nil {
+		return nil, err
+	}
+	return handler{schema: s}, nil
+}
@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func signJWT(secret []byte, header, claims string) string {
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(header)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestLoadJWTSecret(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config", "jwtsecret")
+
+	secret, err := LoadJWTSecret(path)
+	require.NoError(t, err)
+	require.Len(t, secret, jwtSecretLength)
+
+	// the generated secret is reused
+	loaded, err := LoadJWTSecret(path)
+	require.NoError(t, err)
+	require.Equal(t, secret, loaded)
+
+	require.NoError(t, os.WriteFile(path, []byte("0x1234"), 0o600))
+	_, err = LoadJWTSecret(path)
+	require.Error(t, err)
+}
+
+func TestJWTHandler(t *testing.T) {
+	secret := make([]byte, jwtSecretLength)
+	now := time.Unix(1700000000, 0)
+	header := `{"alg":"HS256","typ":"JWT"}`
+
+	h := NewJWTHandler(secret, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})).(*jwtHandler)
+	h.now = func() time.Time { return now }
+
+	testCases := []struct {
+		name   string
+		auth   string
+		status int
+	}{
+		{"valid", "Bearer " + signJWT(secret, header, `{"iat":1700000010}`), http.StatusOK},
+		{"missing token", "", http.StatusUnauthorized},
+		{"wrong secret", "Bearer " + signJWT([]byte("other"), header, `{"iat":1700000000}`), http.StatusUnauthorized},
+		{"unsupported algorithm", "Bearer " + signJWT(secret, `{"alg":"none"}`, `{"iat":1700000000}`), http.StatusUnauthorized},
+		{"missing iat", "Bearer " + signJWT(secret, header, `{}`), http.StatusUnauthorized},
+		{"stale iat", "Bearer " + signJWT(secret, header, `{"iat":1699999000}`), http.StatusUnauthorized},
+		{"expired", "Bearer " + signJWT(secret, header, `{"iat":1700000000,"exp":1700000000}`), http.StatusUnauthorized},
+		{"malformed", "Bearer abc", http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", nil)
+			if tc.auth != "" {
+				req.Header.Set("Authorization", tc.auth)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			require.Equal(t, tc.status, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,438 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package rpc
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/evmos/ethermint/server/config"
+)
+
+const (
+	// internalRequestHeader carries the token of the requests forwarded by the websocket server to the HTTP
+	// server, they are limited by the websocket server with the IP of its client.
+	internalRequestHeader = "X-Ethermint-Internal"
+
+	// maxRequestContentLength is the max size of the request body accepted by the go-ethereum HTTP server.
+	maxRequestContentLength = 1024 * 1024 * 5
+
+	// bucketSweepInterval is the interval between the removals of the idle rate limit buckets.
+	bucketSweepInterval = time.Minute
+
+	errCodeInvalidRequest   = -32600
+	errCodeMethodNotAllowed = -32601
+	errCodeResponseTooLarge = -32003
+	errCodeLimitExceeded    = -32005
+)
+
+// limitError is a request rejected by the limits, it's reported as a JSON-RPC error.
+type limitError struct {
+	code    int
+	status  int
+	message string
+}
+
+func (e *limitError) Error() string {
+	return e.message
+}
+
+// tokenBucket is the rate limit bucket of a client IP, or of a client IP and method pattern.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	rate   float64
+	burst  int
+}
+
+// refill refills the bucket up to now.
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens = math.Min(float64(b.burst), b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
+
+// full returns true if the bucket would be refilled at now, it's then the same as a new bucket.
+func (b *tokenBucket) full(now time.Time) bool {
+	return b.tokens+now.Sub(b.last).Seconds()*b.rate >= float64(b.burst)
+}
+
+type bucketKey struct {
+	ip      string
+	pattern string // empty for the rate limit of all the methods
+}
+
+// RequestLimiter enforces the batch size, response size and rate limits and the allowed methods of the JSON-RPC
+// requests. It's shared by the HTTP and websocket servers, the rate limits are per client IP and apply to each
+// request of a batch.
+type RequestLimiter struct {
+	batchLimit      int
+	responseMaxSize int
+	rate            float64
+	burst           int
+	methodLimits    []config.MethodRateLimit
+	allowedMethods  []string
+	deniedMethods   []string
+	token           string
+
+	mtx       sync.Mutex
+	buckets   map[bucketKey]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewRequestLimiter returns the limiter of the JSON-RPC requests configured by cfg.
+func NewRequestLimiter(cfg config.JSONRPCConfig) (*RequestLimiter, error) {
+	methodLimits, err := config.ParseMethodRateLimits(cfg.MethodRateLimits)
+	if err != nil {
+		return nil, err
+	}
+
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+
+	return &RequestLimiter{
+		batchLimit:      cfg.BatchRequestLimit,
+		responseMaxSize: cfg.ResponseMaxSize,
+		rate:            cfg.RateLimit,
+		burst:           cfg.RateLimitBurst,
+		methodLimits:    methodLimits,
+		allowedMethods:  cfg.AllowedMethods,
+		deniedMethods:   cfg.DeniedMethods,
+		token:           hex.EncodeToString(token),
+		buckets:         make(map[bucketKey]*tokenBucket),
+		now:             time.Now,
+	}, nil
+}
+
+// rpcRequest is the part of a JSON-RPC request used by the limits.
+type rpcRequest struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+}
+
+// parseRequests returns the requests of the body the way the go-ethereum server decodes them: only the first JSON
+// value of the body is read and the elements of a batch are decoded one by one, an element that can't be decoded
+// is returned empty. A single request is returned as a batch of one. Bodies that aren't JSON return no request,
+// they are rejected by the JSON-RPC server.
+func parseRequests(body []byte) (reqs []rpcRequest, batch bool) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(&raw); err != nil {
+		return nil, isBatch(body)
+	}
+
+	if !isBatch(raw) {
+		var req rpcRequest
+		_ = json.Unmarshal(raw, &req)
+		return []rpcRequest{req}, false
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	_, _ = dec.Token() // skip '['
+	for dec.More() {
+		var req rpcRequest
+		_ = dec.Decode(&req)
+		reqs = append(reqs, req)
+	}
+	return reqs, true
+}
+
+// Check returns an error if the requests of the body exceed the batch size limit, call a method not allowed or
+// exceed the rate limits of the client IP. A batch calling a method not allowed is rejected as a whole.
+func (l *RequestLimiter) Check(ip string, body []byte) *limitError {
+	reqs, batch := parseRequests(body)
+	if batch && l.batchLimit > 0 && len(reqs) > l.batchLimit {
+		return &limitError{
+			code:    errCodeInvalidRequest,
+			status:  http.StatusOK,
+			message: "batch too large, the limit is " + strconv.Itoa(l.batchLimit) + " requests",
+		}
+	}
+	for _, req := range reqs {
+		if !l.MethodAllowed(req.Method) {
+			return &limitError{
+				code:    errCodeMethodNotAllowed,
+				status:  http.StatusOK,
+				message: "the method " + req.Method + " is disabled on this node",
+			}
+		}
+	}
+	return l.checkRate(ip, reqs)
+}
+
+// CheckMethod returns an error if the method isn't allowed or exceeds the rate limits of the client IP, it limits
+// the method calls that aren't JSON-RPC requests, e.g. the subscriptions streaming the results of a method.
+func (l *RequestLimiter) CheckMethod(ip, method string) *limitError {
+	if !l.MethodAllowed(method) {
+		return &limitError{
+			code:    errCodeMethodNotAllowed,
+			status:  http.StatusOK,
+			message: "the method " + method + " is disabled on this node",
+		}
+	}
+	return l.checkRate(ip, []rpcRequest{{Method: method}})
+}
+
+// checkRate returns an error if the requests exceed the rate limits of the client IP, each request takes a token.
+// The tokens are only taken when all the requests are within the limits, a rejected batch takes none.
+func (l *RequestLimiter) checkRate(ip string, reqs []rpcRequest) *limitError {
+	if l.rate == 0 && len(l.methodLimits) == 0 {
+		return nil
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	// the buckets in the order of their first use, so the first exceeded limit is reported
+	var keys []bucketKey
+	counts := make(map[bucketKey]int)
+	use := func(key bucketKey, rate float64, burst int) {
+		if _, ok := counts[key]; !ok {
+			l.bucket(key, rate, burst, now).refill(now)
+			keys = append(keys, key)
+		}
+		counts[key]++
+	}
+	for _, req := range reqs {
+		if l.rate > 0 {
+			use(bucketKey{ip: ip}, l.rate, l.burst)
+		}
+		for _, limit := range l.methodLimits {
+			if limit.Matches(req.Method) {
+				use(bucketKey{ip: ip, pattern: limit.Pattern}, limit.Rate, limit.Burst)
+				break
+			}
+		}
+	}
+
+	for _, key := range keys {
+		if l.buckets[key].tokens >= float64(counts[key]) {
+			continue
+		}
+		message := "rate limit exceeded"
+		if key.pattern != "" {
+			message = "rate limit of " + key.pattern + " exceeded"
+		}
+		return &limitError{
+			code:    errCodeLimitExceeded,
+			status:  http.StatusTooManyRequests,
+			message: message,
+		}
+	}
+	for _, key := range keys {
+		l.buckets[key].tokens -= float64(counts[key])
+	}
+	return nil
+}
+
+// MethodAllowed returns true if the method is in the allowed methods and not in the denied methods.
+func (l *RequestLimiter) MethodAllowed(method string) bool {
+	for _, pattern := range l.deniedMethods {
+		if config.MatchMethod(pattern, method) {
+			return false
+		}
+	}
+	if len(l.allowedMethods) == 0 {
+		return true
+	}
+	for _, pattern := range l.allowedMethods {
+		if config.MatchMethod(pattern, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// bucket returns the rate limit bucket of the key, a new bucket is full.
+func (l *RequestLimiter) bucket(key bucketKey, rate float64, burst int, now time.Time) *tokenBucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: float64(burst), last: now, rate: rate, burst: burst}
+		l.buckets[key] = b
+	}
+	return b
+}
+
+// sweep removes the buckets refilled since their last use, so the idle clients aren't kept in memory.
+func (l *RequestLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < bucketSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if b.full(now) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Handler wraps the handler of the HTTP JSON-RPC server with the limits, the rate limits use the IP of the
+// client. The requests forwarded by the websocket server are only subject to the response size limit.
+func (l *RequestLimiter) Handler(next http.Handler) http.Handler {
+	return l.handler(next, l.Check, writeLimitError)
+}
+
+// GraphQLHandler wraps the handler of the GraphQL service with the body size, response size and rate limits, a
+// query takes a single token of the client IP. The allowed methods are checked by the resolvers with MethodAllowed.
+func (l *RequestLimiter) GraphQLHandler(next http.Handler) http.Handler {
+	check := func(ip string, _ []byte) *limitError {
+		return l.checkRate(ip, []rpcRequest{{}})
+	}
+	return l.handler(next, check, writeGraphQLLimitError)
+}
+
+// handler wraps next with the limits, the body is checked by check and the rejections are written by writeError.
+func (l *RequestLimiter) handler(
+	next http.Handler,
+	check func(ip string, body []byte) *limitError,
+	writeError func(w http.ResponseWriter, body []byte, lerr *limitError),
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestContentLength+1))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(body) > maxRequestContentLength {
+			http.Error(w, "content length too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get(internalRequestHeader)), []byte(l.token)) != 1 {
+			if lerr := check(clientIP(r.RemoteAddr), body); lerr != nil {
+				writeError(w, body, lerr)
+				return
+			}
+		}
+
+		if l.responseMaxSize == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		buf := &responseBuffer{ResponseWriter: w, status: http.StatusOK, max: l.responseMaxSize}
+		next.ServeHTTP(buf, r)
+		if buf.exceeded {
+			w.Header().Del("Content-Length")
+			writeError(w, body, &limitError{
+				code:    errCodeResponseTooLarge,
+				status:  http.StatusOK,
+				message: "response too large, the limit is " + strconv.Itoa(l.responseMaxSize) + " bytes",
+			})
+			return
+		}
+		w.WriteHeader(buf.status)
+		_, _ = w.Write(buf.buf.Bytes())
+	})
+}
+
+// forward marks a request forwarded by the websocket server.
+func (l *RequestLimiter) forward(req *http.Request) {
+	req.Header.Set(internalRequestHeader, l.token)
+}
+
+// responseBuffer holds the response of the JSON-RPC server until it's complete, the content is dropped once
+// it exceeds the max size.
+type responseBuffer struct {
+	http.ResponseWriter
+	status   int
+	buf      bytes.Buffer
+	max      int
+	exceeded bool
+}
+
+func (b *responseBuffer) WriteHeader(status int) {
+	b.status = status
+}
+
+func (b *responseBuffer) Write(p []byte) (int, error) {
+	if b.exceeded {
+		return len(p), nil
+	}
+	if b.buf.Len()+len(p) > b.max {
+		b.exceeded = true
+		b.buf.Reset()
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+// limitErrorResponse returns the JSON-RPC error response of the rejected body, it carries the id of a single
+// request.
+func limitErrorResponse(body []byte, lerr *limitError) interface{} {
+	id := json.RawMessage("null")
+	if reqs, batch := parseRequests(body); !batch && len(reqs) == 1 && len(reqs[0].ID) > 0 {
+		id = reqs[0].ID
+	}
+
+	return struct {
+		Jsonrpc string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+		Error   struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}{
+		Jsonrpc: "2.0",
+		ID:      id,
+		Error: struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		}{lerr.code, lerr.message},
+	}
+}
+
+func writeLimitError(w http.ResponseWriter, body []byte, lerr *limitError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(lerr.status)
+	_ = json.NewEncoder(w).Encode(limitErrorResponse(body, lerr))
+}
+
+// writeGraphQLLimitError writes the limit error as the errors of a GraphQL response.
+func writeGraphQLLimitError(w http.ResponseWriter, _ []byte, lerr *limitError) {
+	type graphQLError struct {
+		Message string `json:"message"`
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(lerr.status)
+	_ = json.NewEncoder(w).Encode(struct {
+		Errors []graphQLError `json:"errors"`
+	}{[]graphQLError{{lerr.message}}})
+}
+
+// clientIP returns the IP of the remote address of a request.
+func clientIP(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
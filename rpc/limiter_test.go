@@ -0,0 +1,240 @@
+package rpc
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/evmos/ethermint/server/config"
+)
+
+func newTestLimiter(t *testing.T, cfg config.JSONRPCConfig) (*RequestLimiter, *time.Time) {
+	l, err := NewRequestLimiter(cfg)
+	require.NoError(t, err)
+	now := time.Unix(1000, 0)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestRequestLimiterBatch(t *testing.T) {
+	l, _ := newTestLimiter(t, config.JSONRPCConfig{BatchRequestLimit: 2})
+
+	req := `{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"}`
+	require.Nil(t, l.Check("1.1.1.1", []byte("["+req+","+req+"]")))
+
+	lerr := l.Check("1.1.1.1", []byte("["+req+","+req+","+req+"]"))
+	require.NotNil(t, lerr)
+	require.Equal(t, errCodeInvalidRequest, lerr.code)
+}
+
+func TestRequestLimiterMethods(t *testing.T) {
+	l, _ := newTestLimiter(t, config.JSONRPCConfig{
+		AllowedMethods: []string{"eth_*", "net_version"},
+		DeniedMethods:  []string{"eth_sign", "eth_sendTransaction", "eth_accounts"},
+	})
+
+	testCases := []struct {
+		method  string
+		allowed bool
+	}{
+		{"eth_call", true},
+		{"net_version", true},
+		{"eth_sign", false},
+		{"eth_accounts", false},
+		{"net_listening", false},
+		{"debug_traceTransaction", false},
+	}
+	for _, tc := range testCases {
+		lerr := l.Check("1.1.1.1", []byte(`{"jsonrpc":"2.0","id":1,"method":"`+tc.method+`"}`))
+		if tc.allowed {
+			require.Nil(t, lerr, tc.method)
+			continue
+		}
+		require.NotNil(t, lerr, tc.method)
+		require.Equal(t, errCodeMethodNotAllowed, lerr.code)
+		require.Contains(t, lerr.message, tc.method)
+	}
+
+	// a batch calling a denied method is rejected
+	lerr := l.Check("1.1.1.1", []byte(`[{"jsonrpc":"2.0","id":1,"method":"eth_call"},{"jsonrpc":"2.0","id":2,"method":"eth_sign"}]`))
+	require.NotNil(t, lerr)
+	require.Equal(t, errCodeMethodNotAllowed, lerr.code)
+
+	// the denied methods are found next to the elements that can't be decoded and before trailing data
+	sign := `{"jsonrpc":"2.0","id":1,"method":"eth_sign"}`
+	invalid := `{"jsonrpc":"2.0","id":2,"method":1}`
+	for _, body := range []string{
+		"[" + sign + "," + invalid + "]",
+		"[" + invalid + "," + sign + "]",
+		sign + " x",
+		" [" + sign + "] " + sign,
+	} {
+		lerr := l.Check("1.1.1.1", []byte(body))
+		require.NotNil(t, lerr, body)
+		require.Equal(t, errCodeMethodNotAllowed, lerr.code, body)
+	}
+}
+
+func TestRequestLimiterRate(t *testing.T) {
+	l, now := newTestLimiter(t, config.JSONRPCConfig{
+		RateLimit:        1,
+		RateLimitBurst:   3,
+		MethodRateLimits: []string{"debug_*=0.5:1"},
+	})
+
+	call := []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_call"}`)
+	trace := []byte(`{"jsonrpc":"2.0","id":1,"method":"debug_traceTransaction"}`)
+
+	// the method limit is tighter than the IP limit
+	require.Nil(t, l.Check("1.1.1.1", trace))
+	lerr := l.Check("1.1.1.1", trace)
+	require.NotNil(t, lerr)
+	require.Equal(t, errCodeLimitExceeded, lerr.code)
+
+	// the rejected request took no token of the IP bucket
+	require.Nil(t, l.Check("1.1.1.1", call))
+	require.Nil(t, l.Check("1.1.1.1", call))
+	require.NotNil(t, l.Check("1.1.1.1", call))
+
+	// the limits are per IP
+	require.Nil(t, l.Check("2.2.2.2", trace))
+
+	// the buckets are refilled over time
+	*now = now.Add(2 * time.Second)
+	require.Nil(t, l.Check("1.1.1.1", trace))
+	require.Nil(t, l.Check("1.1.1.1", call))
+
+	// each request of a batch takes a token, a rejected batch takes none
+	*now = now.Add(time.Minute)
+	require.NotNil(t, l.Check("1.1.1.1", []byte("["+string(call)+","+string(call)+","+string(call)+","+string(call)+"]")))
+	require.NotNil(t, l.Check("1.1.1.1", []byte("["+string(call)+","+string(trace)+","+string(trace)+"]")))
+	require.Nil(t, l.Check("1.1.1.1", []byte("["+string(call)+","+string(call)+","+string(trace)+"]")))
+	require.NotNil(t, l.Check("1.1.1.1", call))
+
+	// the idle buckets are removed
+	*now = now.Add(time.Hour)
+	require.Nil(t, l.Check("3.3.3.3", call))
+	require.Len(t, l.buckets, 1)
+}
+
+func TestRequestLimiterCheckMethod(t *testing.T) {
+	l, _ := newTestLimiter(t, config.JSONRPCConfig{
+		DeniedMethods:    []string{"debug_traceTransaction"},
+		MethodRateLimits: []string{"debug_*=0.5:1"},
+	})
+
+	lerr := l.CheckMethod("1.1.1.1", "debug_traceTransaction")
+	require.NotNil(t, lerr)
+	require.Equal(t, errCodeMethodNotAllowed, lerr.code)
+
+	// the method shares the rate limits of the JSON-RPC requests
+	require.Nil(t, l.CheckMethod("1.1.1.1", "debug_traceBlockByNumber"))
+	lerr = l.Check("1.1.1.1", []byte(`{"jsonrpc":"2.0","id":1,"method":"debug_traceBlockByNumber"}`))
+	require.NotNil(t, lerr)
+	require.Equal(t, errCodeLimitExceeded, lerr.code)
+}
+
+func TestRequestLimiterMalformed(t *testing.T) {
+	call := `{"jsonrpc":"2.0","id":1,"method":"eth_call"}`
+	invalid := `{"jsonrpc":"2.0","id":2,"method":1}`
+
+	// only the first value of the body is read and the elements that can't be decoded count in the batch limit
+	l, _ := newTestLimiter(t, config.JSONRPCConfig{BatchRequestLimit: 2})
+	require.Nil(t, l.Check("1.1.1.1", []byte("["+call+"] ["+call+","+call+","+call+"]")))
+	lerr := l.Check("1.1.1.1", []byte("["+call+","+invalid+",null]"))
+	require.NotNil(t, lerr)
+	require.Equal(t, errCodeInvalidRequest, lerr.code)
+
+	// and take a token of the rate limits
+	l, _ = newTestLimiter(t, config.JSONRPCConfig{RateLimit: 1, RateLimitBurst: 2})
+	require.Nil(t, l.Check("1.1.1.1", []byte(call+" x")))
+	lerr = l.Check("1.1.1.1", []byte("["+call+","+invalid+"]"))
+	require.NotNil(t, lerr)
+	require.Equal(t, errCodeLimitExceeded, lerr.code)
+
+	// bodies that aren't JSON contain no request
+	reqs, batch := parseRequests([]byte("[" + call + ","))
+	require.Empty(t, reqs)
+	require.True(t, batch)
+}
+
+func TestRequestLimiterHandler(t *testing.T) {
+	l, _ := newTestLimiter(t, config.JSONRPCConfig{
+		RateLimit:       1,
+		RateLimitBurst:  1,
+		ResponseMaxSize: 16,
+	})
+
+	var response string
+	handler := l.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+		_, _ = w.Write([]byte(response))
+	}))
+
+	send := func(forwarded bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(`{"jsonrpc":"2.0","id":7,"method":"eth_call"}`))
+		req.RemoteAddr = "1.1.1.1:1234"
+		if forwarded {
+			l.forward(req)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	response = "ok"
+	rec := send(false)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "ok", rec.Body.String())
+
+	rec = send(false)
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.Contains(t, rec.Body.String(), `"id":7`)
+
+	// the requests forwarded by the websocket server aren't rate limited here
+	rec = send(true)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	response = strings.Repeat("x", 17)
+	rec = send(true)
+	require.Contains(t, rec.Body.String(), "response too large")
+}
+
+func TestRequestLimiterGraphQLHandler(t *testing.T) {
+	l, _ := newTestLimiter(t, config.JSONRPCConfig{
+		RateLimit:      1,
+		RateLimitBurst: 1,
+		AllowedMethods: []string{"eth_call"},
+	})
+
+	handler := l.GraphQLHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+		_, _ = w.Write([]byte("ok"))
+	}))
+
+	send := func(remoteAddr, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/graphql", strings.NewReader(body))
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// a query isn't a JSON-RPC request, it's not subject to the allowed methods
+	query := `{"query":"{ chainID }"}`
+	rec := send("1.1.1.1:1234", query)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "ok", rec.Body.String())
+
+	rec = send("1.1.1.1:1234", query)
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.JSONEq(t, `{"errors":[{"message":"rate limit exceeded"}]}`, rec.Body.String())
+
+	rec = send("2.2.2.2:1234", `{"query":"`+strings.Repeat("x", maxRequestContentLength)+`"}`)
+	require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
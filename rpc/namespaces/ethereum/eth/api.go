@@ -17,6 +17,7 @@ package eth
 
 import (
 	"context"
+	"errors"
 
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 
@@ -29,6 +30,7 @@ import (
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/evmos/ethermint/rpc/backend"
+	"github.com/evmos/ethermint/rpc/namespaces/ethereum/eth/filters"
 
 	rpctypes "github.com/evmos/ethermint/rpc/types"
 	ethermint "github.com/evmos/ethermint/types"
@@ -46,6 +48,7 @@ type EthereumAPI interface {
 	//
 	// Retrieves information from a particular block in the blockchain.
 	BlockNumber() (hexutil.Uint64, error)
+	EarliestStateBlockNumber() (hexutil.Uint64, error)
 	GetBlockByNumber(ethBlockNum rpctypes.BlockNumber, fullTx bool) (map[string]interface{}, error)
 	GetBlockByHash(hash common.Hash, fullTx bool) (map[string]interface{}, error)
 	GetBlockTransactionCountByHash(hash common.Hash) *hexutil.Uint
@@ -60,13 +63,14 @@ type EthereumAPI interface {
 	GetTransactionReceipt(hash common.Hash) (map[string]interface{}, error)
 	GetTransactionByBlockHashAndIndex(hash common.Hash, idx hexutil.Uint) (*rpctypes.RPCTransaction, error)
 	GetTransactionByBlockNumberAndIndex(blockNum rpctypes.BlockNumber, idx hexutil.Uint) (*rpctypes.RPCTransaction, error)
-	// eth_getBlockReceipts
+	GetBlockReceipts(blockNrOrHash rpctypes.BlockNumberOrHash) ([]map[string]interface{}, error)
 
 	// Writing Transactions
 	//
 	// Allows developers to both send ETH from one address to another, write data
 	// on-chain, and interact with smart contracts.
 	SendRawTransaction(data hexutil.Bytes) (common.Hash, error)
+	SendRawTransactionSync(ctx context.Context, data hexutil.Bytes, timeoutMs *hexutil.Uint64) (map[string]interface{}, error)
 	SendTransaction(args evmtypes.TransactionArgs) (common.Hash, error)
 	// eth_sendPrivateTransaction
 	// eth_cancel	PrivateTransaction
@@ -79,6 +83,7 @@ type EthereumAPI interface {
 	GetStorageAt(address common.Address, key string, blockNrOrHash rpctypes.BlockNumberOrHash) (hexutil.Bytes, error)
 	GetCode(address common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (hexutil.Bytes, error)
 	GetProof(address common.Address, storageKeys []string, blockNrOrHash rpctypes.BlockNumberOrHash) (*rpctypes.AccountResult, error)
+	GetStorageStats(address common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (*rpctypes.StorageStatsResult, error)
 
 	// EVM/Smart Contract Execution
 	//
@@ -134,14 +139,17 @@ type PublicAPI struct {
 	ctx     context.Context
 	logger  log.Logger
 	backend backend.EVMBackend
+	events  *filters.EventSystem
 }
 
-// NewPublicAPI creates an instance of the public ETH Web3 API.
-func NewPublicAPI(logger log.Logger, backend backend.EVMBackend) *PublicAPI {
+// NewPublicAPI creates an instance of the public ETH Web3 API, the event system is used to wait for the inclusion
+// of the transactions sent by `eth_sendRawTransactionSync`, which is not supported without it.
+func NewPublicAPI(logger log.Logger, backend backend.EVMBackend, events *filters.EventSystem) *PublicAPI {
 	api := &PublicAPI{
 		ctx:     context.Background(),
 		logger:  logger.With("client", "json-rpc"),
 		backend: backend,
+		events:  events,
 	}
 
 	return api
@@ -157,6 +165,13 @@ func (e *PublicAPI) BlockNumber() (hexutil.Uint64, error) {
 	return e.backend.BlockNumber()
 }
 
+// EarliestStateBlockNumber returns the earliest block number whose state can be queried on this node,
+// requests for older blocks should be routed to an archive node.
+func (e *PublicAPI) EarliestStateBlockNumber() (hexutil.Uint64, error) {
+	e.logger.Debug("eth_earliestStateBlockNumber")
+	return e.backend.EarliestStateBlockNumber()
+}
+
 // GetBlockByNumber returns the block identified by number.
 func (e *PublicAPI) GetBlockByNumber(ethBlockNum rpctypes.BlockNumber, fullTx bool) (map[string]interface{}, error) {
 	e.logger.Debug("eth_getBlockByNumber", "number", ethBlockNum, "full", fullTx)
@@ -196,6 +211,12 @@ func (e *PublicAPI) GetTransactionReceipt(hash common.Hash) (map[string]interfac
 	return e.backend.GetTransactionReceipt(hash)
 }
 
+// GetBlockReceipts returns the receipts of all the transactions of the block identified by number or hash.
+func (e *PublicAPI) GetBlockReceipts(blockNrOrHash rpctypes.BlockNumberOrHash) ([]map[string]interface{}, error) {
+	e.logger.Debug("eth_getBlockReceipts", "block number or hash", blockNrOrHash)
+	return e.backend.GetBlockReceipts(blockNrOrHash)
+}
+
 // GetBlockTransactionCountByHash returns the number of transactions in the block identified by hash.
 func (e *PublicAPI) GetBlockTransactionCountByHash(hash common.Hash) *hexutil.Uint {
 	e.logger.Debug("eth_getBlockTransactionCountByHash", "hash", hash.Hex())
@@ -230,6 +251,32 @@ func (e *PublicAPI) SendRawTransaction(data hexutil.Bytes) (common.Hash, error)
 	return e.backend.SendRawTransaction(data)
 }
 
+// SendRawTransactionSync sends a raw Ethereum transaction and waits for its inclusion in a block, it returns the
+// receipt of the transaction or an error carrying its hash if it's not included before the timeout.
+func (e *PublicAPI) SendRawTransactionSync(
+	ctx context.Context,
+	data hexutil.Bytes,
+	timeoutMs *hexutil.Uint64,
+) (map[string]interface{}, error) {
+	e.logger.Debug("eth_sendRawTransactionSync", "length", len(data))
+	if e.events == nil {
+		return nil, errors.New("eth_sendRawTransactionSync is not supported without the event system")
+	}
+
+	// the Tx events are published when the transactions are included in a block, the subscription is
+	// set up before the submission so the event can't be missed.
+	txSub, cancelSubs, err := e.events.SubscribePendingTxs()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		cancelSubs()
+		txSub.Unsubscribe(e.events)
+	}()
+
+	return e.backend.SendRawTransactionSync(ctx, data, timeoutMs, txSub)
+}
+
 // SendTransaction sends an Ethereum transaction.
 func (e *PublicAPI) SendTransaction(args evmtypes.TransactionArgs) (common.Hash, error) {
 	e.logger.Debug("eth_sendTransaction", "args", args.String())
@@ -264,6 +311,13 @@ func (e *PublicAPI) GetCode(address common.Address, blockNrOrHash rpctypes.Block
 	return e.backend.GetCode(address, blockNrOrHash)
 }
 
+// GetStorageStats returns the number of storage slots and the storage size of the contract
+// at the given address and block number.
+func (e *PublicAPI) GetStorageStats(address common.Address, blockNrOrHash rpctypes.BlockNumberOrHash) (*rpctypes.StorageStatsResult, error) {
+	e.logger.Debug("eth_getStorageStats", "address", address.Hex(), "block number or hash", blockNrOrHash)
+	return e.backend.GetStorageStats(address, blockNrOrHash)
+}
+
 // GetProof returns an account object with proof and any storage proofs
 func (e *PublicAPI) GetProof(address common.Address,
 	storageKeys []string,
@@ -55,7 +55,7 @@ func (suite *EthRpcTestSuite) GetEthPublicAPI() *eth.PublicAPI {
 }
 
 func (suite *EthRpcTestSuite) GetEthPublicAPIAt(height int64) *eth.PublicAPI {
-	return eth.NewPublicAPI(log.NewNopLogger(), suite.CITS.RpcBackendAt(height))
+	return eth.NewPublicAPI(log.NewNopLogger(), suite.CITS.RpcBackendAt(height), nil)
 }
 
 func (suite *EthRpcTestSuite) GetTxReceipt(txHash common.Hash) *ethtypes.Receipt {
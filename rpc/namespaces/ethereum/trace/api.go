@@ -0,0 +1,57 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package trace
+
+import (
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/evmos/ethermint/rpc/backend"
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+)
+
+// API is the OpenEthereum compatible `trace` namespace, it serves the flat call traces
+// built from the callTracer output.
+type API struct {
+	logger  log.Logger
+	backend backend.EVMBackend
+}
+
+// NewAPI creates a new API definition for the trace namespace.
+func NewAPI(logger log.Logger, backend backend.EVMBackend) *API {
+	return &API{
+		logger:  logger.With("api", "trace"),
+		backend: backend,
+	}
+}
+
+// Block returns the flat call traces of all the transactions in the block.
+func (a *API) Block(blockNr rpctypes.BlockNumber) ([]*rpctypes.ParityTrace, error) {
+	a.logger.Debug("trace_block", "number", blockNr)
+	return a.backend.TraceBlockFlat(blockNr)
+}
+
+// Transaction returns the flat call traces of the transaction.
+func (a *API) Transaction(hash common.Hash) ([]*rpctypes.ParityTrace, error) {
+	a.logger.Debug("trace_transaction", "hash", hash)
+	return a.backend.TraceTransactionFlat(hash)
+}
+
+// Filter returns the flat call traces in the block range matching the from and to addresses.
+func (a *API) Filter(args rpctypes.TraceFilterArgs) ([]*rpctypes.ParityTrace, error) {
+	a.logger.Debug("trace_filter", "args", args)
+	return a.backend.TraceFilter(args)
+}
@@ -0,0 +1,227 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+
+// Package proof verifies the ICS-23 proofs of the eth_getProof results against a trusted app hash, allowing the
+// light clients to validate the account state offline.
+package proof
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	crypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	enccodec "github.com/evmos/ethermint/encoding/codec"
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+	ethermint "github.com/evmos/ethermint/types"
+	evmtypes "github.com/evmos/ethermint/x/evm/types"
+)
+
+// Verifier verifies the proofs of the eth_getProof results
+type Verifier struct {
+	cdc      codec.Codec
+	runtime  *merkle.ProofRuntime
+	evmDenom string
+}
+
+// NewVerifier creates a Verifier of the proofs of a chain using the given EVM denom. The accounts stored in the auth
+// store are decoded with the account and key types of the SDK, the auth and vesting modules and ethermint.
+func NewVerifier(evmDenom string) *Verifier {
+	registry := codectypes.NewInterfaceRegistry()
+	enccodec.RegisterInterfaces(registry)
+	authtypes.RegisterInterfaces(registry)
+	vestingtypes.RegisterInterfaces(registry)
+
+	return &Verifier{
+		cdc:      codec.NewProtoCodec(registry),
+		runtime:  rootmulti.DefaultProofRuntime(),
+		evmDenom: evmDenom,
+	}
+}
+
+// Verify verifies the proofs of an eth_getProof result against the trusted app hash of the block header at the
+// trusted height, which must be the block following the proven state.
+func (v *Verifier) Verify(res *rpctypes.AccountResult, trustedAppHash []byte, trustedHeight int64) error {
+	if res == nil {
+		return errors.New("empty account result")
+	}
+	if trustedHeight <= 0 || uint64(res.Height)+1 != uint64(trustedHeight) {
+		return fmt.Errorf("proofs of height %d can't be verified at height %d", uint64(res.Height), trustedHeight)
+	}
+	return v.VerifyAccountResult(res, trustedAppHash)
+}
+
+// VerifyAccountResult verifies the proofs of an eth_getProof result against the app hash of the header following
+// the queried block, and checks that the nonce, code hash, balance and storage values of the result are the
+// proven ones.
+func (v *Verifier) VerifyAccountResult(res *rpctypes.AccountResult, appHash []byte) error {
+	if res == nil {
+		return errors.New("empty account result")
+	}
+	if res.ProofType != rpctypes.ProofTypeICS23 {
+		return fmt.Errorf("unsupported proof type %q", res.ProofType)
+	}
+	if res.VirtualFrontierContract == nil {
+		return errors.New("missing virtual frontier contract proof")
+	}
+
+	address := res.Address
+
+	// account
+	accountKey := authtypes.AddressStoreKey(sdk.AccAddress(address.Bytes()))
+	if err := v.verify(appHash, authtypes.StoreKey, accountKey, res.AccountValue, res.AccountProof); err != nil {
+		return fmt.Errorf("account proof: %w", err)
+	}
+
+	// virtual frontier contract
+	vfcKey := evmtypes.VirtualFrontierContractKey(address)
+	vfc := res.VirtualFrontierContract
+	if err := v.verify(appHash, evmtypes.StoreKey, vfcKey, vfc.Value, vfc.Proof); err != nil {
+		return fmt.Errorf("virtual frontier contract proof: %w", err)
+	}
+
+	nonce, codeHash, err := v.accountState(res.AccountValue, len(vfc.Value) > 0)
+	if err != nil {
+		return err
+	}
+	if uint64(res.Nonce) != nonce {
+		return fmt.Errorf("nonce mismatch: expected %d, got %d", nonce, uint64(res.Nonce))
+	}
+	if res.CodeHash != codeHash {
+		return fmt.Errorf("code hash mismatch: expected %s, got %s", codeHash, res.CodeHash)
+	}
+
+	// balance, the zero balances aren't stored
+	balanceKey := append(banktypes.CreateAccountBalancesPrefix(address.Bytes()), []byte(v.evmDenom)...)
+	balanceValue, err := balanceBytes(res.Balance)
+	if err != nil {
+		return err
+	}
+	if err := v.verify(appHash, banktypes.StoreKey, balanceKey, balanceValue, res.BalanceProof); err != nil {
+		return fmt.Errorf("balance proof: %w", err)
+	}
+
+	// storage, the empty slots aren't stored
+	for _, storage := range res.StorageProof {
+		if storage.Value == nil {
+			return fmt.Errorf("storage proof of key %s: missing value", storage.Key)
+		}
+		var value []byte
+		if storage.Value.ToInt().Sign() != 0 {
+			value = common.BigToHash(storage.Value.ToInt()).Bytes()
+		}
+		stateKey := evmtypes.StateKey(address, common.HexToHash(storage.Key).Bytes())
+		if err := v.verify(appHash, evmtypes.StoreKey, stateKey, value, storage.Proof); err != nil {
+			return fmt.Errorf("storage proof of key %s: %w", storage.Key, err)
+		}
+	}
+
+	return nil
+}
+
+// accountState returns the nonce and code hash of the account stored in the auth store, following the EVM keeper.
+func (v *Verifier) accountState(bz []byte, isVFC bool) (uint64, common.Hash, error) {
+	if len(bz) == 0 {
+		return 0, common.BytesToHash(evmtypes.EmptyCodeHash), nil
+	}
+
+	var account authtypes.AccountI
+	if err := v.cdc.UnmarshalInterface(bz, &account); err != nil {
+		return 0, common.Hash{}, fmt.Errorf("failed to decode account: %w", err)
+	}
+
+	codeHash := common.BytesToHash(evmtypes.EmptyCodeHash)
+	if ethAccount, ok := account.(ethermint.EthAccountI); ok {
+		codeHash = ethAccount.GetCodeHash()
+	} else if isVFC {
+		codeHash = common.BytesToHash(evmtypes.VFBCCodeHash)
+	}
+
+	return account.GetSequence(), codeHash, nil
+}
+
+// verify checks the existence of the value at the key of the store, or the absence of the key if the value is empty.
+func (v *Verifier) verify(appHash []byte, storeKey string, key, value []byte, proofs []string) error {
+	ops, err := ProofOps(storeKey, key, proofs)
+	if err != nil {
+		return err
+	}
+
+	keyPath := merkle.KeyPath{}.
+		AppendKey([]byte(storeKey), merkle.KeyEncodingURL).
+		AppendKey(key, merkle.KeyEncodingHex).
+		String()
+
+	if len(value) == 0 {
+		return v.runtime.VerifyAbsence(ops, appHash, keyPath)
+	}
+	return v.runtime.VerifyValue(ops, appHash, keyPath, value)
+}
+
+// ProofOps rebuilds the ICS-23 proof ops of the key of the store from the hex encoded proofs of an AccountResult,
+// see rpctypes.ProofTypeICS23.
+func ProofOps(storeKey string, key []byte, proofs []string) (*crypto.ProofOps, error) {
+	if len(proofs) != 2 {
+		return nil, fmt.Errorf("expected 2 proof ops, got %d", len(proofs))
+	}
+
+	data := make([][]byte, len(proofs))
+	for i, proof := range proofs {
+		if strings.TrimSpace(proof) == "" {
+			return nil, fmt.Errorf("empty proof op %d", i)
+		}
+		bz, err := hexutil.Decode(proof)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proof op %d: %w", i, err)
+		}
+		data[i] = bz
+	}
+
+	return &crypto.ProofOps{
+		Ops: []crypto.ProofOp{
+			{Type: storetypes.ProofOpIAVLCommitment, Key: key, Data: data[0]},
+			{Type: storetypes.ProofOpSimpleMerkleCommitment, Key: []byte(storeKey), Data: data[1]},
+		},
+	}, nil
+}
+
+// balanceBytes returns the bank store encoding of a balance, empty for a zero balance
+func balanceBytes(balance *hexutil.Big) ([]byte, error) {
+	if balance == nil {
+		return nil, errors.New("missing balance")
+	}
+	amount := balance.ToInt()
+	if amount.Sign() < 0 {
+		return nil, fmt.Errorf("negative balance %s", amount)
+	}
+	if amount.Sign() == 0 {
+		return nil, nil
+	}
+	return sdkmath.NewIntFromBigInt(amount).Marshal()
+}
@@ -0,0 +1,108 @@
+//go:build norace
+// +build norace
+
+package proof_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/evmos/ethermint/rpc/proof"
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+	"github.com/evmos/ethermint/tests"
+	"github.com/evmos/ethermint/testutil/network"
+	evmtypes "github.com/evmos/ethermint/x/evm/types"
+)
+
+type ProofTestSuite struct {
+	suite.Suite
+
+	network  *network.Network
+	client   *gethrpc.Client
+	verifier *proof.Verifier
+}
+
+func (s *ProofTestSuite) SetupSuite() {
+	s.T().Log("setting up integration test suite")
+
+	cfg := network.DefaultConfig()
+	cfg.NumValidators = 1
+
+	var err error
+	s.network, err = network.New(s.T(), s.T().TempDir(), cfg)
+	s.Require().NoError(err)
+
+	// proof queries at height <= 2 are not supported
+	_, err = s.network.WaitForHeight(3)
+	s.Require().NoError(err)
+
+	s.client, err = gethrpc.Dial("http://" + s.network.Validators[0].AppConfig.JSONRPC.Address)
+	s.Require().NoError(err)
+
+	s.verifier = proof.NewVerifier(evmtypes.DefaultEVMDenom)
+}
+
+func (s *ProofTestSuite) TearDownSuite() {
+	s.T().Log("tearing down integration test suite")
+	s.client.Close()
+	s.network.Cleanup()
+}
+
+// getProof returns the eth_getProof result of the address at the latest height, and the app hash of the next block
+func (s *ProofTestSuite) getProof(address common.Address, storageKeys []string) (*rpctypes.AccountResult, []byte, int64) {
+	height, err := s.network.LatestHeight()
+	s.Require().NoError(err)
+
+	var res rpctypes.AccountResult
+	err = s.client.CallContext(context.Background(), &res, "eth_getProof", address, storageKeys, hexutil.EncodeUint64(uint64(height)))
+	s.Require().NoError(err)
+	s.Require().Equal(uint64(height), uint64(res.Height))
+
+	trustedHeight := height + 1
+	_, err = s.network.WaitForHeight(trustedHeight)
+	s.Require().NoError(err)
+
+	block, err := s.network.Validators[0].RPCClient.Block(context.Background(), &trustedHeight)
+	s.Require().NoError(err)
+
+	return &res, block.Block.AppHash, trustedHeight
+}
+
+func (s *ProofTestSuite) TestVerifyValidatorAccount() {
+	address := common.BytesToAddress(s.network.Validators[0].Address)
+
+	res, appHash, trustedHeight := s.getProof(address, []string{"0x0"})
+	s.Require().NotZero(res.Nonce)
+	s.Require().Positive(res.Balance.ToInt().Sign())
+	s.Require().NoError(s.verifier.Verify(res, appHash, trustedHeight))
+
+	// the proofs only verify against the app hash of the next block
+	s.Require().Error(s.verifier.Verify(res, appHash, trustedHeight-1))
+
+	res.Balance = (*hexutil.Big)(new(big.Int).Add(res.Balance.ToInt(), big.NewInt(1)))
+	s.Require().Error(s.verifier.Verify(res, appHash, trustedHeight))
+}
+
+func (s *ProofTestSuite) TestVerifyMissingAccount() {
+	address := tests.GenerateAddress()
+
+	res, appHash, trustedHeight := s.getProof(address, []string{"0x0", "0x1"})
+	s.Require().Empty(res.AccountValue)
+	s.Require().Len(res.StorageProof, 2)
+	s.Require().NoError(s.verifier.Verify(res, appHash, trustedHeight))
+
+	// the proof of absence doesn't prove another account
+	res.Address = common.BytesToAddress(sdk.AccAddress(s.network.Validators[0].Address))
+	s.Require().Error(s.verifier.Verify(res, appHash, trustedHeight))
+}
+
+func TestProofTestSuite(t *testing.T) {
+	suite.Run(t, new(ProofTestSuite))
+}
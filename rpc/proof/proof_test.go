@@ -0,0 +1,271 @@
+package proof
+
+import (
+	"math/big"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	dbm "github.com/cometbft/cometbft-db"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/evmos/ethermint/crypto/ethsecp256k1"
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+	"github.com/evmos/ethermint/tests"
+	ethermint "github.com/evmos/ethermint/types"
+	evmtypes "github.com/evmos/ethermint/x/evm/types"
+)
+
+const testDenom = "aphoton"
+
+// testChain is a multistore holding the auth, bank and evm stores of a chain
+type testChain struct {
+	store   *rootmulti.Store
+	keys    map[string]*storetypes.KVStoreKey
+	appHash []byte
+}
+
+func newTestChain(t *testing.T) *testChain {
+	store := rootmulti.NewStore(dbm.NewMemDB(), log.NewNopLogger())
+	keys := sdk.NewKVStoreKeys(authtypes.StoreKey, banktypes.StoreKey, evmtypes.StoreKey)
+	for _, key := range keys {
+		store.MountStoreWithDB(key, storetypes.StoreTypeIAVL, nil)
+	}
+	require.NoError(t, store.LoadLatestVersion())
+
+	// the stores are never empty on chain
+	for name, key := range keys {
+		store.GetKVStore(key).Set([]byte{0xff}, []byte(name))
+	}
+
+	return &testChain{store: store, keys: keys}
+}
+
+func (c *testChain) set(storeKey string, key, value []byte) {
+	c.store.GetKVStore(c.keys[storeKey]).Set(key, value)
+}
+
+func (c *testChain) commit() {
+	c.appHash = c.store.Commit().Hash
+}
+
+// prove queries the value and proofs of the key like the JSON-RPC backend does
+func (c *testChain) prove(t *testing.T, storeKey string, key []byte) ([]byte, []string) {
+	res := c.store.Query(abci.RequestQuery{
+		Path:   "/" + storeKey + "/key",
+		Data:   key,
+		Height: c.store.LastCommitID().Version,
+		Prove:  true,
+	})
+	require.Equal(t, uint32(0), res.Code, res.Log)
+
+	proofs := []string{}
+	for _, op := range res.ProofOps.Ops {
+		proofs = append(proofs, hexutil.Encode(op.Data))
+	}
+	return res.Value, proofs
+}
+
+// accountResult builds the eth_getProof result of the address
+func (c *testChain) accountResult(
+	t *testing.T, address common.Address, nonce uint64, codeHash []byte, balance int64, slots map[common.Hash]int64,
+) *rpctypes.AccountResult {
+	accountValue, accountProof := c.prove(t, authtypes.StoreKey, authtypes.AddressStoreKey(address.Bytes()))
+	balanceKey := append(banktypes.CreateAccountBalancesPrefix(address.Bytes()), []byte(testDenom)...)
+	_, balanceProof := c.prove(t, banktypes.StoreKey, balanceKey)
+	vfcValue, vfcProof := c.prove(t, evmtypes.StoreKey, evmtypes.VirtualFrontierContractKey(address))
+
+	storageProofs := []rpctypes.StorageResult{}
+	for slot, value := range slots {
+		_, proof := c.prove(t, evmtypes.StoreKey, evmtypes.StateKey(address, slot.Bytes()))
+		storageProofs = append(storageProofs, rpctypes.StorageResult{
+			Key:   slot.Hex(),
+			Value: (*hexutil.Big)(big.NewInt(value)),
+			Proof: proof,
+		})
+	}
+
+	return &rpctypes.AccountResult{
+		Address:      address,
+		AccountProof: accountProof,
+		Balance:      (*hexutil.Big)(big.NewInt(balance)),
+		CodeHash:     common.BytesToHash(codeHash),
+		Nonce:        hexutil.Uint64(nonce),
+		StorageProof: storageProofs,
+		ProofType:    rpctypes.ProofTypeICS23,
+		Height:       hexutil.Uint64(c.store.LastCommitID().Version),
+		AccountValue: accountValue,
+		BalanceProof: balanceProof,
+		VirtualFrontierContract: &rpctypes.VFCProofResult{
+			Value: vfcValue,
+			Proof: vfcProof,
+		},
+	}
+}
+
+func TestVerifyAccountResult(t *testing.T) {
+	verifier := NewVerifier(testDenom)
+	contract := tests.GenerateAddress()
+	vfc := tests.GenerateAddress()
+	missing := tests.GenerateAddress()
+	slot := common.HexToHash("0x1")
+	emptySlot := common.HexToHash("0x2")
+	codeHash := common.HexToHash("0x1234")
+
+	chain := newTestChain(t)
+
+	// the accounts which sent txs store their public key
+	privKey, err := ethsecp256k1.GenerateKey()
+	require.NoError(t, err)
+	ethAccount := &ethermint.EthAccount{
+		BaseAccount: authtypes.NewBaseAccount(contract.Bytes(), privKey.PubKey(), 1, 3),
+		CodeHash:    codeHash.Hex(),
+	}
+	bz, err := verifier.cdc.MarshalInterface(authtypes.AccountI(ethAccount))
+	require.NoError(t, err)
+	chain.set(authtypes.StoreKey, authtypes.AddressStoreKey(contract.Bytes()), bz)
+	amount, err := sdkmath.NewInt(100).Marshal()
+	require.NoError(t, err)
+	chain.set(banktypes.StoreKey, append(banktypes.CreateAccountBalancesPrefix(contract.Bytes()), []byte(testDenom)...), amount)
+	chain.set(evmtypes.StoreKey, evmtypes.StateKey(contract, slot.Bytes()), common.BigToHash(big.NewInt(7)).Bytes())
+
+	vfcAccount := authtypes.NewBaseAccount(sdk.AccAddress(vfc.Bytes()), nil, 2, 0)
+	bz, err = verifier.cdc.MarshalInterface(authtypes.AccountI(vfcAccount))
+	require.NoError(t, err)
+	chain.set(authtypes.StoreKey, authtypes.AddressStoreKey(vfc.Bytes()), bz)
+	chain.set(evmtypes.StoreKey, evmtypes.VirtualFrontierContractKey(vfc), []byte{1})
+
+	chain.commit()
+
+	testCases := []struct {
+		name    string
+		result  func() *rpctypes.AccountResult
+		expPass bool
+	}{
+		{
+			"pass - contract account",
+			func() *rpctypes.AccountResult {
+				return chain.accountResult(t, contract, 3, codeHash.Bytes(), 100, map[common.Hash]int64{slot: 7, emptySlot: 0})
+			},
+			true,
+		},
+		{
+			"pass - virtual frontier contract",
+			func() *rpctypes.AccountResult {
+				return chain.accountResult(t, vfc, 0, evmtypes.VFBCCodeHash, 0, nil)
+			},
+			true,
+		},
+		{
+			"pass - missing account",
+			func() *rpctypes.AccountResult {
+				return chain.accountResult(t, missing, 0, evmtypes.EmptyCodeHash, 0, map[common.Hash]int64{slot: 0})
+			},
+			true,
+		},
+		{
+			"fail - wrong nonce",
+			func() *rpctypes.AccountResult {
+				return chain.accountResult(t, contract, 4, codeHash.Bytes(), 100, nil)
+			},
+			false,
+		},
+		{
+			"fail - wrong code hash",
+			func() *rpctypes.AccountResult {
+				return chain.accountResult(t, vfc, 0, evmtypes.EmptyCodeHash, 0, nil)
+			},
+			false,
+		},
+		{
+			"fail - wrong balance",
+			func() *rpctypes.AccountResult {
+				return chain.accountResult(t, contract, 3, codeHash.Bytes(), 101, nil)
+			},
+			false,
+		},
+		{
+			"fail - wrong storage value",
+			func() *rpctypes.AccountResult {
+				return chain.accountResult(t, contract, 3, codeHash.Bytes(), 100, map[common.Hash]int64{slot: 8})
+			},
+			false,
+		},
+		{
+			"fail - hidden virtual frontier contract",
+			func() *rpctypes.AccountResult {
+				res := chain.accountResult(t, vfc, 0, evmtypes.EmptyCodeHash, 0, nil)
+				res.VirtualFrontierContract.Value = nil
+				return res
+			},
+			false,
+		},
+		{
+			"fail - proof of another account",
+			func() *rpctypes.AccountResult {
+				res := chain.accountResult(t, missing, 0, evmtypes.EmptyCodeHash, 0, nil)
+				res.Address = contract
+				return res
+			},
+			false,
+		},
+		{
+			"fail - unsupported proof type",
+			func() *rpctypes.AccountResult {
+				res := chain.accountResult(t, contract, 3, codeHash.Bytes(), 100, nil)
+				res.ProofType = ""
+				return res
+			},
+			false,
+		},
+		{
+			"fail - missing proofs",
+			func() *rpctypes.AccountResult {
+				res := chain.accountResult(t, contract, 3, codeHash.Bytes(), 100, nil)
+				res.AccountProof = []string{""}
+				return res
+			},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifier.VerifyAccountResult(tc.result(), chain.appHash)
+			if tc.expPass {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+
+	// the proofs don't verify against another app hash
+	res := chain.accountResult(t, contract, 3, codeHash.Bytes(), 100, nil)
+	chain.set(evmtypes.StoreKey, []byte{0xfe}, []byte{1})
+	chain.commit()
+	require.Error(t, verifier.VerifyAccountResult(res, chain.appHash))
+}
+
+func TestVerify(t *testing.T) {
+	verifier := NewVerifier(testDenom)
+	address := tests.GenerateAddress()
+
+	chain := newTestChain(t)
+	chain.commit()
+	version := chain.store.LastCommitID().Version
+
+	res := chain.accountResult(t, address, 0, evmtypes.EmptyCodeHash, 0, nil)
+	require.NoError(t, verifier.Verify(res, chain.appHash, version+1))
+	require.Error(t, verifier.Verify(res, chain.appHash, version))
+	require.Error(t, verifier.Verify(res, chain.appHash, version+2))
+	require.Error(t, verifier.Verify(nil, chain.appHash, version+1))
+}
@@ -27,6 +27,12 @@ import (
 	evmtypes "github.com/evmos/ethermint/x/evm/types"
 )
 
+// TxEventSubscription is a subscription to the events of the cosmos transactions included in the blocks.
+type TxEventSubscription interface {
+	Event() <-chan tmrpctypes.ResultEvent
+	Err() <-chan error
+}
+
 // EventFormat is the format version of the events.
 //
 // To fix the issue of tx exceeds block gas limit, we changed the event format in a breaking way.
@@ -16,14 +16,27 @@
 package types
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/types/tx"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/proto/tendermint/crypto"
 
+	errorsmod "cosmossdk.io/errors"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
 	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	errortypes "github.com/cosmos/cosmos-sdk/types/errors"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	evmtypes "github.com/evmos/ethermint/x/evm/types"
 	feemarkettypes "github.com/evmos/ethermint/x/feemarket/types"
@@ -41,9 +54,15 @@ type QueryClient struct {
 
 // NewQueryClient creates a new gRPC query client
 func NewQueryClient(clientCtx client.Context) *QueryClient {
+	// the client context doesn't support the server streaming queries, use the gRPC connection if available
+	var evmConn gogogrpc.ClientConn = abciQueryConn{clientCtx}
+	if clientCtx.GRPCClient != nil {
+		evmConn = clientCtx.GRPCClient
+	}
+
 	return &QueryClient{
 		ServiceClient: tx.NewServiceClient(clientCtx),
-		QueryClient:   evmtypes.NewQueryClient(clientCtx),
+		QueryClient:   evmtypes.NewQueryClient(prunedStateConn{evmConn}),
 		FeeMarket:     feemarkettypes.NewQueryClient(clientCtx),
 	}
 }
@@ -69,10 +88,116 @@ func (QueryClient) GetProof(clientCtx client.Context, storeKey string, key []byt
 		Prove:  true,
 	}
 
-	abciRes, err := clientCtx.QueryABCI(abciReq)
+	abciRes, err := queryABCI(clientCtx, abciReq)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	return abciRes.Value, abciRes.ProofOps, nil
 }
+
+// queryABCI performs the ABCI query like the client context, the failed queries at a height whose state has been
+// pruned return ErrStatePruned, the other ones return the same gRPC errors as the client context.
+func queryABCI(clientCtx client.Context, req abci.RequestQuery) (abci.ResponseQuery, error) {
+	node, err := clientCtx.GetNode()
+	if err != nil {
+		return abci.ResponseQuery{}, err
+	}
+
+	result, err := node.ABCIQueryWithOptions(context.Background(), req.Path, req.Data, rpcclient.ABCIQueryOptions{
+		Height: req.Height,
+		Prove:  req.Prove,
+	})
+	if err != nil {
+		return abci.ResponseQuery{}, err
+	}
+
+	res := result.Response
+	switch {
+	case res.IsOK():
+		return res, nil
+	case res.Codespace == evmtypes.ModuleName && res.Code == evmtypes.ErrStatePruned.ABCICode():
+		return abci.ResponseQuery{}, errorsmod.Wrap(evmtypes.ErrStatePruned, res.Log)
+	case res.Code == errortypes.ErrInvalidRequest.ABCICode():
+		return abci.ResponseQuery{}, status.Error(codes.InvalidArgument, res.Log)
+	case res.Code == errortypes.ErrUnauthorized.ABCICode():
+		return abci.ResponseQuery{}, status.Error(codes.Unauthenticated, res.Log)
+	case res.Code == errortypes.ErrKeyNotFound.ABCICode():
+		return abci.ResponseQuery{}, status.Error(codes.NotFound, res.Log)
+	default:
+		return abci.ResponseQuery{}, status.Error(codes.Unknown, res.Log)
+	}
+}
+
+// abciQueryConn performs the unary queries through ABCI like the client context, but keeps the errors of the
+// queries at a pruned height typed, the client context only keeps the code and the log of the failed queries.
+type abciQueryConn struct {
+	client.Context
+}
+
+// Invoke implements gogogrpc.ClientConn
+func (c abciQueryConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	req, ok := args.(codec.ProtoMarshaler)
+	if !ok {
+		return fmt.Errorf("invalid query request type %T", args)
+	}
+	res, ok := reply.(codec.ProtoMarshaler)
+	if !ok {
+		return fmt.Errorf("invalid query response type %T", reply)
+	}
+
+	reqBz, err := req.Marshal()
+	if err != nil {
+		return err
+	}
+
+	height := c.Height
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if heights := md.Get(grpctypes.GRPCBlockHeightHeader); len(heights) > 0 {
+		height, err = strconv.ParseInt(heights[0], 10, 64)
+		if err != nil {
+			return err
+		}
+		if height < 0 {
+			return errorsmod.Wrapf(errortypes.ErrInvalidRequest, "height (%d) from %q must be >= 0", height, grpctypes.GRPCBlockHeightHeader)
+		}
+	}
+
+	abciRes, err := queryABCI(c.Context, abci.RequestQuery{Path: method, Data: reqBz, Height: height})
+	if err != nil {
+		return err
+	}
+	if err := res.Unmarshal(abciRes.Value); err != nil {
+		return err
+	}
+
+	// set the height header like the client context
+	for _, opt := range opts {
+		if header, ok := opt.(grpc.HeaderCallOption); ok {
+			*header.HeaderAddr = metadata.Pairs(grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(abciRes.Height, 10))
+		}
+	}
+
+	if c.InterfaceRegistry != nil {
+		return codectypes.UnpackInterfaces(reply, c.InterfaceRegistry)
+	}
+	return nil
+}
+
+// prunedStateConn converts the status errors of the evm queries on pruned heights into evmtypes.ErrStatePruned
+type prunedStateConn struct {
+	gogogrpc.ClientConn
+}
+
+// Invoke implements gogogrpc.ClientConn
+func (c prunedStateConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	return evmtypes.ConvertPrunedStateError(c.ClientConn.Invoke(ctx, method, args, reply, opts...))
+}
+
+// NewStream implements gogogrpc.ClientConn
+func (c prunedStateConn) NewStream(
+	ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	stream, err := c.ClientConn.NewStream(ctx, desc, method, opts...)
+	return stream, evmtypes.ConvertPrunedStateError(err)
+}
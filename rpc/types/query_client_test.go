@@ -0,0 +1,115 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	errortypes "github.com/cosmos/cosmos-sdk/types/errors"
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/evmos/ethermint/rpc/backend/mocks"
+	evmtypes "github.com/evmos/ethermint/x/evm/types"
+)
+
+// errConn is a client connection failing every query with the given error
+type errConn struct {
+	gogogrpc.ClientConn
+	err error
+}
+
+func (c errConn) Invoke(context.Context, string, interface{}, interface{}, ...grpc.CallOption) error {
+	return c.err
+}
+
+func TestPrunedStateConn(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		pruned bool
+	}{
+		{"pruned height", evmtypes.NewStatePrunedStatusError(1), true},
+		{
+			"pruned height reported by the query router",
+			status.Error(codes.InvalidArgument, "failed to load state at height 1; version does not exist (latest height: 10): invalid request"),
+			false,
+		},
+		{"other error", status.Error(codes.InvalidArgument, "invalid address"), false},
+	}
+
+	for _, tc := range testCases {
+		client := evmtypes.NewQueryClient(prunedStateConn{errConn{err: tc.err}})
+		_, err := client.Params(context.Background(), &evmtypes.QueryParamsRequest{})
+		require.Error(t, err, tc.name)
+		require.Equal(t, tc.pruned, evmtypes.IsPrunedStateError(err), tc.name)
+	}
+
+	require.NoError(t, prunedStateConn{errConn{}}.Invoke(context.Background(), "", nil, nil))
+}
+
+func TestABCIQueryConn(t *testing.T) {
+	testCases := []struct {
+		name     string
+		response abci.ResponseQuery
+		pruned   bool
+		code     codes.Code
+	}{
+		{
+			"pruned height",
+			abci.ResponseQuery{Codespace: evmtypes.ModuleName, Code: evmtypes.ErrStatePruned.ABCICode(), Log: "height 1"},
+			true,
+			codes.Unknown,
+		},
+		{
+			"invalid request",
+			abci.ResponseQuery{Codespace: errortypes.RootCodespace, Code: errortypes.ErrInvalidRequest.ABCICode()},
+			false,
+			codes.InvalidArgument,
+		},
+		{
+			"other module error with the same code",
+			abci.ResponseQuery{Codespace: "bank", Code: evmtypes.ErrStatePruned.ABCICode()},
+			false,
+			codes.Unknown,
+		},
+	}
+
+	for _, tc := range testCases {
+		// the query is performed at the height of the request header
+		node := new(mocks.Client)
+		node.On("ABCIQueryWithOptions", mock.Anything, "/ethermint.evm.v1.Query/Params", mock.Anything, rpcclient.ABCIQueryOptions{Height: 1}).
+			Return(&coretypes.ResultABCIQuery{Response: tc.response}, nil)
+
+		queryClient := evmtypes.NewQueryClient(abciQueryConn{client.Context{}.WithClient(node)})
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "x-cosmos-block-height", "1")
+		_, err := queryClient.Params(ctx, &evmtypes.QueryParamsRequest{})
+		require.Error(t, err, tc.name)
+		require.Equal(t, tc.pruned, evmtypes.IsPrunedStateError(err), tc.name)
+		if !tc.pruned {
+			require.Equal(t, tc.code, status.Code(err), tc.name)
+		}
+	}
+
+	var header metadata.MD
+	node := new(mocks.Client)
+	params := evmtypes.DefaultParams()
+	value, err := (&evmtypes.QueryParamsResponse{Params: params}).Marshal()
+	require.NoError(t, err)
+	node.On("ABCIQueryWithOptions", mock.Anything, "/ethermint.evm.v1.Query/Params", mock.Anything, mock.Anything).
+		Return(&coretypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: value, Height: 5}}, nil)
+
+	queryClient := evmtypes.NewQueryClient(abciQueryConn{client.Context{}.WithClient(node)})
+	res, err := queryClient.Params(context.Background(), &evmtypes.QueryParamsRequest{}, grpc.Header(&header))
+	require.NoError(t, err)
+	require.Equal(t, params.EvmDenom, res.Params.EvmDenom)
+	require.Equal(t, []string{"5"}, header.Get("x-cosmos-block-height"))
+}
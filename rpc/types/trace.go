@@ -0,0 +1,159 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package types
+
+import (
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Flat call trace types, following the OpenEthereum trace module.
+const (
+	ParityTraceTypeCall    = "call"
+	ParityTraceTypeCreate  = "create"
+	ParityTraceTypeSuicide = "suicide"
+)
+
+// ParityTrace is a flat call trace in the format of the OpenEthereum `trace_*` apis.
+type ParityTrace struct {
+	Action              ParityTraceAction  `json:"action"`
+	BlockHash           common.Hash        `json:"blockHash"`
+	BlockNumber         uint64             `json:"blockNumber"`
+	Error               string             `json:"error,omitempty"`
+	Result              *ParityTraceResult `json:"result"`
+	Subtraces           int                `json:"subtraces"`
+	TraceAddress        []int              `json:"traceAddress"`
+	TransactionHash     common.Hash        `json:"transactionHash"`
+	TransactionPosition uint64             `json:"transactionPosition"`
+	Type                string             `json:"type"`
+}
+
+// ParityTraceAction is the action of a flat call trace, the fields are set according to the trace type.
+type ParityTraceAction struct {
+	// call and create
+	CallType       string          `json:"callType,omitempty"`
+	CreationMethod string          `json:"creationMethod,omitempty"`
+	From           *common.Address `json:"from,omitempty"`
+	To             *common.Address `json:"to,omitempty"`
+	Gas            *hexutil.Uint64 `json:"gas,omitempty"`
+	Input          *hexutil.Bytes  `json:"input,omitempty"`
+	Init           *hexutil.Bytes  `json:"init,omitempty"`
+	Value          *hexutil.Big    `json:"value,omitempty"`
+	// suicide
+	Address       *common.Address `json:"address,omitempty"`
+	RefundAddress *common.Address `json:"refundAddress,omitempty"`
+	Balance       *hexutil.Big    `json:"balance,omitempty"`
+}
+
+// ParityTraceResult is the result of a successful call or create flat trace.
+type ParityTraceResult struct {
+	GasUsed hexutil.Uint64  `json:"gasUsed"`
+	Output  *hexutil.Bytes  `json:"output,omitempty"`
+	Address *common.Address `json:"address,omitempty"`
+	Code    *hexutil.Bytes  `json:"code,omitempty"`
+}
+
+// FromAddress returns the sender of the traced action: the caller, the creator
+// or the self-destructed contract.
+func (t *ParityTrace) FromAddress() *common.Address {
+	if t.Type == ParityTraceTypeSuicide {
+		return t.Action.Address
+	}
+	return t.Action.From
+}
+
+// ToAddress returns the receiver of the traced action: the callee, the created
+// contract or the refund address of the self-destructed contract.
+func (t *ParityTrace) ToAddress() *common.Address {
+	switch t.Type {
+	case ParityTraceTypeCreate:
+		if t.Result == nil {
+			return nil
+		}
+		return t.Result.Address
+	case ParityTraceTypeSuicide:
+		return t.Action.RefundAddress
+	default:
+		return t.Action.To
+	}
+}
+
+// TraceFilterArgs represents the arguments of `trace_filter`.
+type TraceFilterArgs struct {
+	FromBlock   *BlockNumber     `json:"fromBlock"`
+	ToBlock     *BlockNumber     `json:"toBlock"`
+	FromAddress []common.Address `json:"fromAddress"`
+	ToAddress   []common.Address `json:"toAddress"`
+	After       *uint64          `json:"after"`
+	Count       *uint64          `json:"count"`
+}
+
+// Match returns true if the trace is sent from one of the FromAddress and to one of the ToAddress,
+// an empty address list matches any address.
+func (args *TraceFilterArgs) Match(trace *ParityTrace) bool {
+	return matchAddress(trace.FromAddress(), args.FromAddress) && matchAddress(trace.ToAddress(), args.ToAddress)
+}
+
+// Enough returns true once n matching traces cover the After and Count of the filter, the remaining traces are not
+// returned so the search can stop. It's always false without a Count.
+func (args *TraceFilterArgs) Enough(n int) bool {
+	if args.Count == nil {
+		return false
+	}
+	after := uint64(0)
+	if args.After != nil {
+		after = *args.After
+	}
+	if after > math.MaxUint64-*args.Count {
+		return false
+	}
+	return uint64(n) >= after+*args.Count
+}
+
+func matchAddress(address *common.Address, addresses []common.Address) bool {
+	if len(addresses) == 0 {
+		return true
+	}
+	if address == nil {
+		return false
+	}
+	for _, addr := range addresses {
+		if addr == *address {
+			return true
+		}
+	}
+	return false
+}
+
+// TraceIndexer defines the interface of the persistent index of flat call traces,
+// it lets `trace_filter` find the traces without replaying the blocks.
+type TraceIndexer interface {
+	// FirstIndexedTraceBlock returns -1 if the trace index is empty
+	FirstIndexedTraceBlock() (int64, error)
+	// LastIndexedTraceBlock returns -1 if the trace index is empty
+	LastIndexedTraceBlock() (int64, error)
+	// IndexBlockTraces stores the flat call traces of a block
+	IndexBlockTraces(height int64, traces []*ParityTrace) error
+	// IndexFailedTraceBlock records a block failed to be traced, the queries of the block fail with the reason
+	IndexFailedTraceBlock(height int64, reason string) error
+	// GetBlockTraces returns the flat call traces of an indexed block
+	GetBlockTraces(height int64) ([]*ParityTrace, error)
+	// FilterTraces returns the traces of the indexed blocks in range [fromBlock, toBlock] matching the filter addresses,
+	// it stops once the filter has Enough traces.
+	FilterTraces(fromBlock, toBlock int64, args *TraceFilterArgs) ([]*ParityTrace, error)
+}
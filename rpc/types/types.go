@@ -20,12 +20,21 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/evmos/ethermint/x/evm/statedb"
+	evmtypes "github.com/evmos/ethermint/x/evm/types"
 )
 
 // Copied the Account and StorageResult types since they are registered under an
 // internal pkg on geth.
 
+// ProofTypeICS23 is the format of the proofs of an AccountResult. Each proof lists the hex encoded data of the
+// ICS-23 commitment proof ops of the ABCI store query: the IAVL proof of the key in the module store, followed by
+// the proof of the module store root in the multistore. The proofs of a block are verified against the app hash
+// of the next block header. The proof of a missing key is a non-existence proof.
+const ProofTypeICS23 = "ics23"
+
 // AccountResult struct for account proof
 type AccountResult struct {
 	Address      common.Address  `json:"address"`
@@ -35,6 +44,24 @@ type AccountResult struct {
 	Nonce        hexutil.Uint64  `json:"nonce"`
 	StorageHash  common.Hash     `json:"storageHash"`
 	StorageProof []StorageResult `json:"storageProof"`
+	// ProofType is the format of the proofs, see ProofTypeICS23.
+	ProofType string `json:"proofType"`
+	// Height is the block height of the proven state, the proofs are verified against the app hash of the next block.
+	Height hexutil.Uint64 `json:"height"`
+	// AccountValue is the account stored in the auth store proven by AccountProof, empty if the account doesn't exist.
+	AccountValue hexutil.Bytes `json:"accountValue"`
+	// BalanceProof proves the balance of the EVM denom in the bank store.
+	BalanceProof []string `json:"balanceProof"`
+	// VirtualFrontierContract proves the virtual frontier contract of the address in the evm store, the code hash
+	// of an account without EVM code hash depends on it.
+	VirtualFrontierContract *VFCProofResult `json:"virtualFrontierContract"`
+}
+
+// VFCProofResult defines the format of the virtual frontier contract proof of an AccountResult
+type VFCProofResult struct {
+	// Value is the stored VirtualFrontierContract, empty if the address isn't a virtual frontier contract.
+	Value hexutil.Bytes `json:"value"`
+	Proof []string      `json:"proof"`
 }
 
 // StorageResult defines the format for storage proof return
@@ -44,6 +71,43 @@ type StorageResult struct {
 	Proof []string     `json:"proof"`
 }
 
+// StorageStatsResult defines the format for the storage usage of a contract
+type StorageStatsResult struct {
+	Address common.Address `json:"address"`
+	Slots   hexutil.Uint64 `json:"slots"`
+	Bytes   hexutil.Uint64 `json:"bytes"`
+}
+
+// StorageRangeResult defines the format of the 'debug_storageRangeAt' result
+type StorageRangeResult struct {
+	Storage map[common.Hash]StorageEntry `json:"storage"`
+	NextKey *common.Hash                 `json:"nextKey"` // nil if Storage includes the last key of the contract
+}
+
+// AccountRangeResult defines the format of the 'debug_accountRange' result, it follows geth's dump
+// with the storage of each account capped to a page.
+type AccountRangeResult struct {
+	Root     string                                 `json:"root"`
+	Accounts map[common.Address]AccountRangeAccount `json:"accounts"`
+	// Next can be set to represent that this dump is only partial, and Next
+	// is where an iterator should be positioned in order to continue the dump.
+	Next []byte `json:"next,omitempty"` // nil if no more accounts
+}
+
+// AccountRangeAccount defines the format of an account of the 'debug_accountRange' result
+type AccountRangeAccount struct {
+	state.DumpAccount
+	// StorageNextKey is the key to continue the storage of the account with 'debug_storageRangeAt',
+	// nil if Storage includes the last slot of the account
+	StorageNextKey *common.Hash `json:"storageNextKey,omitempty"`
+}
+
+// StorageEntry defines the format of a storage slot, indexed by the hash of its key
+type StorageEntry struct {
+	Key   *common.Hash `json:"key"`
+	Value common.Hash  `json:"value"`
+}
+
 // RPCTransaction represents a transaction that will serialize to the RPC representation of a transaction
 type RPCTransaction struct {
 	BlockHash        *common.Hash         `json:"blockHash"`
@@ -68,20 +132,41 @@ type RPCTransaction struct {
 }
 
 // StateOverride is the collection of overridden accounts.
-type StateOverride map[common.Address]OverrideAccount
+type StateOverride = statedb.StateOverride
 
 // OverrideAccount indicates the overriding fields of account during the execution of
 // a message call.
-// Note, state and stateDiff can't be specified at the same time. If state is
-// set, message execution will only use the data in the given state. Otherwise
-// if statDiff is set, all diff will be applied first and then execute the call
-// message.
-type OverrideAccount struct {
-	Nonce     *hexutil.Uint64              `json:"nonce"`
-	Code      *hexutil.Bytes               `json:"code"`
-	Balance   **hexutil.Big                `json:"balance"`
-	State     *map[common.Hash]common.Hash `json:"state"`
-	StateDiff *map[common.Hash]common.Hash `json:"stateDiff"`
+type OverrideAccount = statedb.OverrideAccount
+
+// TraceCallConfig is the config for traceCall API. It holds one more
+// field to override the state for tracing.
+type TraceCallConfig struct {
+	evmtypes.TraceConfig
+	StateOverrides *StateOverride `json:"stateOverrides"`
+}
+
+// BadBlockTxTraceResult is the trace of an ethereum transaction of a bad block,
+// along with its DeliverTx outcome recorded by the node.
+type BadBlockTxTraceResult struct {
+	evmtypes.TxTraceResult
+	TxHash   common.Hash `json:"txHash"`
+	Rejected bool        `json:"rejected"`
+	Log      string      `json:"log,omitempty"`
+}
+
+// TraceBlockStreamResult is the trace of an ethereum transaction of a block streamed by the
+// `traceBlock` subscription.
+type TraceBlockStreamResult struct {
+	evmtypes.TxTraceResult
+	TxIndex uint64      `json:"txIndex"`
+	TxHash  common.Hash `json:"txHash"`
+}
+
+// TraceBlockStreamEnd is the last notification of the `traceBlock` subscription.
+type TraceBlockStreamEnd struct {
+	Done    bool   `json:"done"`
+	TxCount int    `json:"txCount"`
+	Error   string `json:"error,omitempty"`
 }
 
 type FeeHistoryResult struct {
@@ -41,6 +41,7 @@ import (
 	rpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
 	tmtypes "github.com/cometbft/cometbft/types"
 
+	"github.com/evmos/ethermint/rpc/backend"
 	"github.com/evmos/ethermint/rpc/ethereum/pubsub"
 	rpcfilters "github.com/evmos/ethermint/rpc/namespaces/ethereum/eth/filters"
 	"github.com/evmos/ethermint/rpc/types"
@@ -86,19 +87,38 @@ type websocketsServer struct {
 	certFile string
 	keyFile  string
 	api      *pubSubAPI
+	limiter  *RequestLimiter
 	logger   log.Logger
 }
 
-func NewWebsocketsServer(clientCtx client.Context, logger log.Logger, tmWSClient *rpcclient.WSClient, cfg *config.Config) WebsocketsServer {
+// NewWebsocketsServer returns the websocket server of the JSON-RPC server serving the namespaces, the traceBlock
+// subscription is only available when the debug namespace is served.
+func NewWebsocketsServer(
+	clientCtx client.Context,
+	logger log.Logger,
+	tmWSClient *rpcclient.WSClient,
+	cfg *config.Config,
+	evmBackend backend.EVMBackend,
+	limiter *RequestLimiter,
+	namespaces []string,
+) WebsocketsServer {
 	logger = logger.With("api", "websocket-server")
 	_, port, _ := net.SplitHostPort(cfg.JSONRPC.Address)
 
+	api := newPubSubAPI(clientCtx, logger, tmWSClient, evmBackend)
+	for _, namespace := range namespaces {
+		if namespace == DebugNamespace {
+			api.traceBlockEnabled = true
+		}
+	}
+
 	return &websocketsServer{
 		rpcAddr:  "localhost:" + port, // FIXME: this shouldn't be hardcoded to localhost
 		wsAddr:   cfg.JSONRPC.WsAddress,
 		certFile: cfg.TLS.CertificatePath,
 		keyFile:  cfg.TLS.KeyPath,
-		api:      newPubSubAPI(clientCtx, logger, tmWSClient),
+		api:      api,
+		limiter:  limiter,
 		logger:   logger,
 	}
 }
@@ -158,6 +178,11 @@ func (s *websocketsServer) sendErrResponse(wsConn *wsConn, msg string) {
 	_ = wsConn.WriteJSON(res)
 }
 
+// sendLimitErrResponse sends the error of a message rejected by the limits of the server.
+func (s *websocketsServer) sendLimitErrResponse(wsConn *wsConn, mb []byte, lerr *limitError) {
+	_ = wsConn.WriteJSON(limitErrorResponse(mb, lerr))
+}
+
 type wsConn struct {
 	conn *websocket.Conn
 	mux  *sync.Mutex
@@ -201,6 +226,11 @@ func (s *websocketsServer) readLoop(wsConn *wsConn) {
 			return
 		}
 
+		if lerr := s.limiter.Check(clientIP(wsConn.conn.RemoteAddr().String()), mb); lerr != nil {
+			s.sendLimitErrResponse(wsConn, mb, lerr)
+			continue
+		}
+
 		if isBatch(mb) {
 			if err := s.tcpGetAndSendResponse(wsConn, mb); err != nil {
 				s.sendErrResponse(wsConn, err.Error())
@@ -241,8 +271,19 @@ func (s *websocketsServer) readLoop(wsConn *wsConn) {
 				continue
 			}
 
+			// the traceBlock subscription is limited as the debug method it streams
+			if params[0] == "traceBlock" && s.api.traceBlockEnabled {
+				ip := clientIP(wsConn.conn.RemoteAddr().String())
+				if lerr := s.limiter.CheckMethod(ip, traceBlockMethod); lerr != nil {
+					s.sendLimitErrResponse(wsConn, mb, lerr)
+					continue
+				}
+			}
+
 			subID := rpc.NewID()
-			unsubFn, err := s.api.subscribe(wsConn, subID, params)
+			// closed once the subscription id is sent, the notifications must not precede it
+			ready := make(chan struct{})
+			unsubFn, err := s.api.subscribe(wsConn, subID, params, ready)
 			if err != nil {
 				s.sendErrResponse(wsConn, err.Error())
 				continue
@@ -255,7 +296,9 @@ func (s *websocketsServer) readLoop(wsConn *wsConn) {
 				Result:  subID,
 			}
 
-			if err := wsConn.WriteJSON(res); err != nil {
+			err = wsConn.WriteJSON(res)
+			close(ready)
+			if err != nil {
 				break
 			}
 		case "eth_unsubscribe":
@@ -320,6 +363,7 @@ func (s *websocketsServer) tcpGetAndSendResponse(wsConn *wsConn, mb []byte) erro
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	s.limiter.forward(req)
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
@@ -342,24 +386,32 @@ func (s *websocketsServer) tcpGetAndSendResponse(wsConn *wsConn, mb []byte) erro
 	return wsConn.WriteJSON(wsSend)
 }
 
+// traceBlockMethod is the method streamed by the traceBlock subscription, its limits apply to the subscription.
+const traceBlockMethod = "debug_traceBlockByNumber"
+
 // pubSubAPI is the eth_ prefixed set of APIs in the Web3 JSON-RPC spec
 type pubSubAPI struct {
 	events    *rpcfilters.EventSystem
 	logger    log.Logger
 	clientCtx client.Context
+	backend   backend.EVMBackend
+
+	// traceBlockEnabled is true when the debug namespace is served
+	traceBlockEnabled bool
 }
 
 // newPubSubAPI creates an instance of the ethereum PubSub API.
-func newPubSubAPI(clientCtx client.Context, logger log.Logger, tmWSClient *rpcclient.WSClient) *pubSubAPI {
+func newPubSubAPI(clientCtx client.Context, logger log.Logger, tmWSClient *rpcclient.WSClient, evmBackend backend.EVMBackend) *pubSubAPI {
 	logger = logger.With("module", "websocket-client")
 	return &pubSubAPI{
 		events:    rpcfilters.NewEventSystem(logger, tmWSClient),
 		logger:    logger,
 		clientCtx: clientCtx,
+		backend:   evmBackend,
 	}
 }
 
-func (api *pubSubAPI) subscribe(wsConn *wsConn, subID rpc.ID, params []interface{}) (pubsub.UnsubscribeFunc, error) {
+func (api *pubSubAPI) subscribe(wsConn *wsConn, subID rpc.ID, params []interface{}, ready <-chan struct{}) (pubsub.UnsubscribeFunc, error) {
 	method, ok := params[0].(string)
 	if !ok {
 		return nil, errors.New("invalid parameters")
@@ -378,6 +430,11 @@ func (api *pubSubAPI) subscribe(wsConn *wsConn, subID rpc.ID, params []interface
 		return api.subscribePendingTransactions(wsConn, subID)
 	case "syncing":
 		return api.subscribeSyncing(wsConn, subID)
+	case "traceBlock":
+		if !api.traceBlockEnabled {
+			return nil, errors.Errorf("unsupported method %s", method)
+		}
+		return api.subscribeTraceBlock(wsConn, subID, params[1:], ready)
 	default:
 		return nil, errors.Errorf("unsupported method %s", method)
 	}
@@ -681,6 +738,80 @@ func (api *pubSubAPI) subscribePendingTransactions(wsConn *wsConn, subID rpc.ID)
 	return unsubFn, nil
 }
 
+// subscribeTraceBlock traces the block given by the first parameter with the optional trace config of
+// the second parameter, it sends the trace result of each ethereum transaction as it completes, followed
+// by a last notification marked as done. The tracing waits for the notifications to be written, so a slow
+// peer holds back the tracing instead of buffering the results.
+func (api *pubSubAPI) subscribeTraceBlock(wsConn *wsConn, subID rpc.ID, params []interface{}, ready <-chan struct{}) (pubsub.UnsubscribeFunc, error) {
+	if len(params) == 0 {
+		return nil, errors.New("missing block number parameter")
+	}
+	var blockNum types.BlockNumber
+	if err := remarshalParam(params[0], &blockNum); err != nil {
+		return nil, errors.Wrap(err, "invalid block number parameter")
+	}
+	config := &evmtypes.TraceConfig{}
+	if len(params) > 1 && params[1] != nil {
+		if err := remarshalParam(params[1], config); err != nil {
+			return nil, errors.Wrap(err, "invalid trace config parameter")
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	notify := func(result interface{}) error {
+		return wsConn.WriteJSON(&SubscriptionNotification{
+			Jsonrpc: "2.0",
+			Method:  "eth_subscription",
+			Params: &SubscriptionResult{
+				Subscription: subID,
+				Result:       result,
+			},
+		})
+	}
+
+	go func() {
+		defer cancel()
+		select {
+		case <-ready:
+		case <-ctx.Done():
+			return
+		}
+
+		count, err := api.backend.StreamTraceBlock(ctx, blockNum, config, func(result *types.TraceBlockStreamResult) error {
+			return notify(result)
+		})
+		if ctx.Err() != nil {
+			// unsubscribed
+			return
+		}
+		end := &types.TraceBlockStreamEnd{Done: true, TxCount: count}
+		if err != nil {
+			api.logger.Debug("trace block subscription failed", "subscription-id", subID, "block", blockNum, "error", err.Error())
+			end.Error = err.Error()
+		}
+		if err := notify(end); err != nil {
+			api.logger.Debug("error writing trace block result, will drop peer", "error", err.Error())
+
+			try(func() {
+				if err != websocket.ErrCloseSent {
+					_ = wsConn.Close()
+				}
+			}, api.logger, "closing websocket peer sub")
+		}
+	}()
+
+	return pubsub.UnsubscribeFunc(cancel), nil
+}
+
+// remarshalParam decodes a json decoded subscription parameter into v.
+func remarshalParam(param interface{}, v interface{}) error {
+	bz, err := json.Marshal(param)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bz, v)
+}
+
 func (api *pubSubAPI) subscribeSyncing(_ *wsConn, _ rpc.ID) (pubsub.UnsubscribeFunc, error) {
 	return nil, errors.New("syncing subscription is not implemented")
 }
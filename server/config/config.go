@@ -19,15 +19,18 @@ import (
 	"errors"
 	"fmt"
 	"path"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 
-	"github.com/cometbft/cometbft/libs/strings"
+	tmstrings "github.com/cometbft/cometbft/libs/strings"
 
 	errorsmod "cosmossdk.io/errors"
 	"github.com/cosmos/cosmos-sdk/server/config"
 	errortypes "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 const (
@@ -40,6 +43,12 @@ const (
 	// DefaultJSONRPCWsAddress is the default address the JSON-RPC WebSocket server binds to.
 	DefaultJSONRPCWsAddress = "127.0.0.1:8546"
 
+	// DefaultJSONRPCAuthAddress is the default address the JWT authenticated JSON-RPC server binds to.
+	DefaultJSONRPCAuthAddress = "127.0.0.1:8551"
+
+	// DefaultJSONRPCAuthJWTSecret is the default path of the JWT secret file, relative to the node home.
+	DefaultJSONRPCAuthJWTSecret = "config/jwtsecret"
+
 	// DefaultJsonRPCMetricsAddress is the default address the JSON-RPC Metrics server binds to.
 	DefaultJSONRPCMetricsAddress = "127.0.0.1:6065"
 
@@ -51,6 +60,21 @@ const (
 
 	DefaultMaxTxGasWanted = 0
 
+	// DefaultEVMQueryTimeout is the default deadline of the EVM executing gRPC queries
+	DefaultEVMQueryTimeout = 10 * time.Second
+
+	// DefaultEVMQueryGasCap is the default gas cap of the EVM executing gRPC queries
+	DefaultEVMQueryGasCap uint64 = 25000000
+
+	// DefaultBlockTracer is the default tracer of the block trace files
+	DefaultBlockTracer = "callTracer"
+
+	// DefaultBlockTraceBlocksPerFile is the default number of blocks covered by a block trace file
+	DefaultBlockTraceBlocksPerFile int64 = 1000
+
+	// DefaultBlockTraceMaxFiles is the default number of block trace files kept
+	DefaultBlockTraceMaxFiles = 100
+
 	DefaultGasCap uint64 = 25000000
 
 	DefaultFilterCap int32 = 200
@@ -61,8 +85,26 @@ const (
 
 	DefaultBlockRangeCap int32 = 10000
 
+	// DefaultTraceFilterRangeCap is the default max block range replayed by `trace_filter`
+	DefaultTraceFilterRangeCap int32 = 100
+
+	// DefaultTraceCacheMaxSize is the default max total size in bytes of the cached trace results
+	DefaultTraceCacheMaxSize int64 = 1 << 30
+
+	// DefaultTraceCacheMaxEntrySize is the default max size in bytes of a cached trace result
+	DefaultTraceCacheMaxEntrySize int64 = 8 << 20
+
 	DefaultEVMTimeout = 5 * time.Second
 
+	// DefaultSendTxSyncTimeout is the default max wait of `eth_sendRawTransactionSync` for the tx inclusion
+	DefaultSendTxSyncTimeout = 10 * time.Second
+
+	// DefaultBatchRequestLimit is the default max number of requests of a JSON-RPC batch
+	DefaultBatchRequestLimit = 1000
+
+	// DefaultResponseMaxSize is the default max size in bytes of a JSON-RPC response
+	DefaultResponseMaxSize = 25 * 1000 * 1000
+
 	// default 1.0 eth
 	DefaultTxFeeCap float64 = 1.0
 
@@ -79,6 +121,8 @@ const (
 
 var evmTracers = []string{"json", "markdown", "struct", "access_list"}
 
+var blockTracers = []string{"callTracer", "prestateTracer", "4byteTracer"}
+
 // Config defines the server's top level configuration. It includes the default app config
 // from the SDK as well as the EVM configuration to enable the JSON-RPC APIs.
 type Config struct {
@@ -96,6 +140,25 @@ type EVMConfig struct {
 	Tracer string `mapstructure:"tracer"`
 	// MaxTxGasWanted defines the gas wanted for each eth tx returned in ante handler in check tx mode.
 	MaxTxGasWanted uint64 `mapstructure:"max-tx-gas-wanted"`
+	// QueryTimeout defines the deadline of the gRPC queries executing the EVM (eth_call, estimate gas and traces),
+	// regardless of the entry point. 0 means no deadline.
+	QueryTimeout time.Duration `mapstructure:"query-timeout"`
+	// QueryGasCap defines the gas cap of the gRPC queries executing the EVM (eth_call and estimate gas),
+	// regardless of the entry point. 0 means no cap.
+	QueryGasCap uint64 `mapstructure:"query-gas-cap"`
+	// BlockTraceDir defines the directory of the block trace files, relative to the node home if not absolute.
+	// The delivered transactions are traced to the files as the blocks commit. Empty means disabled.
+	// It can't be combined with Tracer, since a delivered transaction is traced by a single tracer.
+	BlockTraceDir string `mapstructure:"block-trace-dir"`
+	// BlockTracer defines the native tracer of the block trace files.
+	BlockTracer string `mapstructure:"block-tracer"`
+	// BlockTraceAddresses defines the contract addresses filtering the traced transactions,
+	// only the transactions creating or calling one of them are written. Empty means all transactions.
+	BlockTraceAddresses []string `mapstructure:"block-trace-addresses"`
+	// BlockTraceBlocksPerFile defines the number of blocks covered by a block trace file before it's rotated.
+	BlockTraceBlocksPerFile int64 `mapstructure:"block-trace-blocks-per-file"`
+	// BlockTraceMaxFiles defines the number of block trace files kept, the oldest are removed first. 0 means all.
+	BlockTraceMaxFiles int `mapstructure:"block-trace-max-files"`
 }
 
 // JSONRPCConfig defines configuration for the EVM RPC server.
@@ -112,6 +175,8 @@ type JSONRPCConfig struct {
 	EVMTimeout time.Duration `mapstructure:"evm-timeout"`
 	// TxFeeCap is the global tx-fee cap for send transaction
 	TxFeeCap float64 `mapstructure:"txfee-cap"`
+	// SendTxSyncTimeout is the max wait of `eth_sendRawTransactionSync` for the tx inclusion, 0 uses DefaultSendTxSyncTimeout.
+	SendTxSyncTimeout time.Duration `mapstructure:"send-tx-sync-timeout"`
 	// FilterCap is the global cap for total number of filters that can be created.
 	FilterCap int32 `mapstructure:"filter-cap"`
 	// FeeHistoryCap is the global cap for total number of blocks that can be fetched
@@ -134,10 +199,101 @@ type JSONRPCConfig struct {
 	MaxOpenConnections int `mapstructure:"max-open-connections"`
 	// EnableIndexer defines if enable the custom indexer service.
 	EnableIndexer bool `mapstructure:"enable-indexer"`
+	// EnableTraceIndexer defines if enable the flat call trace index used by `trace_filter`, it requires EnableIndexer.
+	EnableTraceIndexer bool `mapstructure:"enable-trace-indexer"`
+	// TraceFilterRangeCap defines the max block range replayed by `trace_filter` when the range is not covered by the trace index.
+	TraceFilterRangeCap int32 `mapstructure:"trace-filter-range-cap"`
+	// EnableTraceCache defines if enable the on-disk cache of the `debug_traceTransaction` results.
+	EnableTraceCache bool `mapstructure:"enable-trace-cache"`
+	// TraceCacheMaxSize defines the max total size in bytes of the cached trace results, the least recently used are evicted first.
+	TraceCacheMaxSize int64 `mapstructure:"trace-cache-max-size"`
+	// TraceCacheMaxEntrySize defines the max size in bytes of a cached trace result, the larger results are not cached.
+	TraceCacheMaxEntrySize int64 `mapstructure:"trace-cache-max-entry-size"`
 	// MetricsAddress defines the metrics server to listen on
 	MetricsAddress string `mapstructure:"metrics-address"`
 	// FixRevertGasRefundHeight defines the upgrade height for fix of revert gas refund logic when transaction reverted
 	FixRevertGasRefundHeight int64 `mapstructure:"fix-revert-gas-refund-height"`
+	// BatchRequestLimit defines the max number of requests of a batch, 0 means no limit.
+	BatchRequestLimit int `mapstructure:"batch-request-limit"`
+	// ResponseMaxSize defines the max size in bytes of a response, 0 means no limit.
+	ResponseMaxSize int `mapstructure:"response-max-size"`
+	// RateLimit defines the number of requests per second allowed for a client IP, 0 means no limit.
+	RateLimit float64 `mapstructure:"rate-limit"`
+	// RateLimitBurst defines the number of requests a client IP can send at once above the rate limit.
+	RateLimitBurst int `mapstructure:"rate-limit-burst"`
+	// MethodRateLimits defines the rate limits of the methods for a client IP, in the `method=rate:burst` format.
+	// A method ending with `*` matches all the methods with the prefix, e.g. `debug_*=1:5`.
+	MethodRateLimits []string `mapstructure:"method-rate-limits"`
+	// AllowedMethods defines the methods served by the HTTP and WebSocket servers among the enabled namespaces,
+	// empty means all. A method ending with `*` matches all the methods with the prefix, e.g. `eth_get*`.
+	AllowedMethods []string `mapstructure:"allowed-methods"`
+	// DeniedMethods defines the methods rejected by the HTTP and WebSocket servers, it takes precedence over
+	// AllowedMethods. A method ending with `*` matches all the methods with the prefix.
+	DeniedMethods []string `mapstructure:"denied-methods"`
+	// EnableAuth defines if the JWT authenticated HTTP and WebSocket server should be enabled, the AuthAPI
+	// namespaces are then served only by it.
+	EnableAuth bool `mapstructure:"enable-auth"`
+	// AuthAddress defines the JWT authenticated server to listen on
+	AuthAddress string `mapstructure:"auth-address"`
+	// AuthJWTSecret defines the path of the hex encoded HS256 secret of the JWT tokens, relative to the node home
+	// if not absolute. A new secret is generated if the file doesn't exist.
+	AuthJWTSecret string `mapstructure:"auth-jwt-secret"`
+	// AuthAPI defines the privileged namespaces of API served only by the JWT authenticated server,
+	// it serves all the API namespaces.
+	AuthAPI []string `mapstructure:"auth-api"`
+	// EnableGraphQL defines if the GraphQL service of the go-ethereum schema should be served on the `/graphql`
+	// path of the HTTP server. A query takes a token of the rate limit, the calls, gas estimations, log queries and
+	// sent transactions follow the allowed methods of eth_call, eth_estimateGas, eth_getLogs and eth_sendRawTransaction.
+	EnableGraphQL bool `mapstructure:"enable-graphql"`
+}
+
+// MatchMethod returns true if the JSON-RPC method matches the pattern, a pattern ending with `*` matches
+// all the methods with the prefix.
+func MatchMethod(pattern, method string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(method, strings.TrimSuffix(pattern, "*"))
+	}
+	return method == pattern
+}
+
+// MethodRateLimit is the rate limit of the methods matching a pattern for a client IP.
+type MethodRateLimit struct {
+	// Pattern is the method name, or a prefix of the method names when it ends with `*`.
+	Pattern string
+	// Rate is the number of requests per second.
+	Rate float64
+	// Burst is the number of requests allowed at once above the rate.
+	Burst int
+}
+
+// Matches returns true if the method matches the pattern of the rate limit.
+func (l MethodRateLimit) Matches(method string) bool {
+	return MatchMethod(l.Pattern, method)
+}
+
+// ParseMethodRateLimits parses the method rate limits in the `method=rate:burst` format.
+func ParseMethodRateLimits(entries []string) ([]MethodRateLimit, error) {
+	limits := make([]MethodRateLimit, 0, len(entries))
+	for _, entry := range entries {
+		pattern, limit, ok := strings.Cut(entry, "=")
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("invalid method rate limit '%s', expected 'method=rate:burst'", entry)
+		}
+		rateStr, burstStr, ok := strings.Cut(limit, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid method rate limit '%s', expected 'method=rate:burst'", entry)
+		}
+		rate, err := strconv.ParseFloat(rateStr, 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid rate of the method rate limit '%s'", entry)
+		}
+		burst, err := strconv.Atoi(burstStr)
+		if err != nil || burst <= 0 {
+			return nil, fmt.Errorf("invalid burst of the method rate limit '%s'", entry)
+		}
+		limits = append(limits, MethodRateLimit{Pattern: pattern, Rate: rate, Burst: burst})
+	}
+	return limits, nil
 }
 
 // TLSConfig defines the certificate and matching private key for the server.
@@ -198,15 +354,45 @@ func DefaultEVMConfig() *EVMConfig {
 	return &EVMConfig{
 		Tracer:         DefaultEVMTracer,
 		MaxTxGasWanted: DefaultMaxTxGasWanted,
+		QueryTimeout:   DefaultEVMQueryTimeout,
+		QueryGasCap:    DefaultEVMQueryGasCap,
+
+		BlockTracer:             DefaultBlockTracer,
+		BlockTraceBlocksPerFile: DefaultBlockTraceBlocksPerFile,
+		BlockTraceMaxFiles:      DefaultBlockTraceMaxFiles,
 	}
 }
 
-// Validate returns an error if the tracer type is invalid.
+// Validate returns an error if the tracer type, the query timeout or the block trace options are invalid.
 func (c EVMConfig) Validate() error {
-	if c.Tracer != "" && !strings.StringInSlice(c.Tracer, evmTracers) {
+	if c.Tracer != "" && !tmstrings.StringInSlice(c.Tracer, evmTracers) {
 		return fmt.Errorf("invalid tracer type %s, available types: %v", c.Tracer, evmTracers)
 	}
 
+	if c.QueryTimeout < 0 {
+		return errors.New("EVM query timeout duration cannot be negative")
+	}
+
+	if c.BlockTraceDir != "" {
+		if c.Tracer != "" {
+			return fmt.Errorf("EVM tracer %s can't be enabled along with the block trace files", c.Tracer)
+		}
+		if !tmstrings.StringInSlice(c.BlockTracer, blockTracers) {
+			return fmt.Errorf("invalid block tracer %s, available tracers: %v", c.BlockTracer, blockTracers)
+		}
+		if c.BlockTraceBlocksPerFile <= 0 {
+			return errors.New("EVM block trace blocks per file must be positive")
+		}
+		if c.BlockTraceMaxFiles < 0 {
+			return errors.New("EVM block trace max files cannot be negative")
+		}
+		for _, addr := range c.BlockTraceAddresses {
+			if !common.IsHexAddress(addr) {
+				return fmt.Errorf("invalid block trace address %s", addr)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -217,7 +403,13 @@ func GetDefaultAPINamespaces() []string {
 
 // GetAPINamespaces returns the all the available JSON-RPC API namespaces.
 func GetAPINamespaces() []string {
-	return []string{"web3", "eth", "personal", "net", "txpool", "debug", "miner"}
+	return []string{"web3", "eth", "personal", "net", "txpool", "debug", "miner", "trace"}
+}
+
+// GetDefaultAuthAPINamespaces returns the default list of the privileged JSON-RPC namespaces served only by the
+// JWT authenticated server when it's enabled.
+func GetDefaultAuthAPINamespaces() []string {
+	return []string{"personal", "miner", "debug", "trace"}
 }
 
 // DefaultJSONRPCConfig returns an EVM config with the JSON-RPC API enabled by default
@@ -230,6 +422,7 @@ func DefaultJSONRPCConfig() *JSONRPCConfig {
 		GasCap:                   DefaultGasCap,
 		EVMTimeout:               DefaultEVMTimeout,
 		TxFeeCap:                 DefaultTxFeeCap,
+		SendTxSyncTimeout:        DefaultSendTxSyncTimeout,
 		FilterCap:                DefaultFilterCap,
 		FeeHistoryCap:            DefaultFeeHistoryCap,
 		BlockRangeCap:            DefaultBlockRangeCap,
@@ -239,8 +432,25 @@ func DefaultJSONRPCConfig() *JSONRPCConfig {
 		AllowUnprotectedTxs:      DefaultAllowUnprotectedTxs,
 		MaxOpenConnections:       DefaultMaxOpenConnections,
 		EnableIndexer:            false,
+		EnableTraceIndexer:       false,
+		TraceFilterRangeCap:      DefaultTraceFilterRangeCap,
+		EnableTraceCache:         false,
+		TraceCacheMaxSize:        DefaultTraceCacheMaxSize,
+		TraceCacheMaxEntrySize:   DefaultTraceCacheMaxEntrySize,
 		MetricsAddress:           DefaultJSONRPCMetricsAddress,
 		FixRevertGasRefundHeight: DefaultFixRevertGasRefundHeight,
+		BatchRequestLimit:        DefaultBatchRequestLimit,
+		ResponseMaxSize:          DefaultResponseMaxSize,
+		RateLimit:                0,
+		RateLimitBurst:           0,
+		MethodRateLimits:         []string{},
+		AllowedMethods:           []string{},
+		DeniedMethods:            []string{},
+		EnableAuth:               false,
+		AuthAddress:              DefaultJSONRPCAuthAddress,
+		AuthJWTSecret:            DefaultJSONRPCAuthJWTSecret,
+		AuthAPI:                  GetDefaultAuthAPINamespaces(),
+		EnableGraphQL:            false,
 	}
 }
 
@@ -266,6 +476,11 @@ func (c JSONRPCConfig) Validate() error {
 		return errors.New("JSON-RPC EVM timeout duration cannot be negative")
 	}
 
+	// a zero send tx sync timeout uses the default one
+	if c.SendTxSyncTimeout < 0 {
+		return errors.New("JSON-RPC send tx sync timeout duration cannot be negative")
+	}
+
 	if c.LogsCap < 0 {
 		return errors.New("JSON-RPC logs cap cannot be negative")
 	}
@@ -274,6 +489,60 @@ func (c JSONRPCConfig) Validate() error {
 		return errors.New("JSON-RPC block range cap cannot be negative")
 	}
 
+	if c.TraceFilterRangeCap < 0 {
+		return errors.New("JSON-RPC trace filter range cap cannot be negative")
+	}
+
+	if c.EnableTraceIndexer && !c.EnableIndexer {
+		return errors.New("JSON-RPC trace indexer requires the custom indexer to be enabled")
+	}
+
+	if c.TraceCacheMaxSize < 0 || c.TraceCacheMaxEntrySize < 0 {
+		return errors.New("JSON-RPC trace cache sizes cannot be negative")
+	}
+
+	if c.EnableTraceCache && (c.TraceCacheMaxSize == 0 || c.TraceCacheMaxEntrySize == 0) {
+		return errors.New("JSON-RPC trace cache sizes cannot be 0 when the trace cache is enabled")
+	}
+
+	if c.TraceCacheMaxEntrySize > c.TraceCacheMaxSize {
+		return errors.New("JSON-RPC trace cache max entry size cannot be greater than the max size")
+	}
+
+	if c.BatchRequestLimit < 0 {
+		return errors.New("JSON-RPC batch request limit cannot be negative")
+	}
+
+	if c.ResponseMaxSize < 0 {
+		return errors.New("JSON-RPC response max size cannot be negative")
+	}
+
+	if c.RateLimit < 0 || c.RateLimitBurst < 0 {
+		return errors.New("JSON-RPC rate limit and burst cannot be negative")
+	}
+
+	if c.RateLimit > 0 && c.RateLimitBurst == 0 {
+		return errors.New("JSON-RPC rate limit burst cannot be 0 when the rate limit is enabled")
+	}
+
+	if _, err := ParseMethodRateLimits(c.MethodRateLimits); err != nil {
+		return fmt.Errorf("JSON-RPC %w", err)
+	}
+
+	for _, method := range append(append([]string{}, c.AllowedMethods...), c.DeniedMethods...) {
+		if strings.TrimSpace(method) == "" {
+			return errors.New("JSON-RPC allowed and denied methods cannot be empty")
+		}
+	}
+
+	if c.EnableAuth && c.AuthAddress == "" {
+		return errors.New("cannot enable the JSON-RPC auth server without an address")
+	}
+
+	if c.EnableAuth && c.AuthJWTSecret == "" {
+		return errors.New("cannot enable the JSON-RPC auth server without a JWT secret file")
+	}
+
 	if c.HTTPTimeout < 0 {
 		return errors.New("JSON-RPC HTTP timeout duration cannot be negative")
 	}
@@ -332,6 +601,14 @@ func GetConfig(v *viper.Viper) (Config, error) {
 		EVM: EVMConfig{
 			Tracer:         v.GetString("evm.tracer"),
 			MaxTxGasWanted: v.GetUint64("evm.max-tx-gas-wanted"),
+			QueryTimeout:   v.GetDuration("evm.query-timeout"),
+			QueryGasCap:    v.GetUint64("evm.query-gas-cap"),
+
+			BlockTraceDir:           v.GetString("evm.block-trace-dir"),
+			BlockTracer:             v.GetString("evm.block-tracer"),
+			BlockTraceAddresses:     v.GetStringSlice("evm.block-trace-addresses"),
+			BlockTraceBlocksPerFile: v.GetInt64("evm.block-trace-blocks-per-file"),
+			BlockTraceMaxFiles:      v.GetInt("evm.block-trace-max-files"),
 		},
 		JSONRPC: JSONRPCConfig{
 			Enable:                   v.GetBool("json-rpc.enable"),
@@ -343,14 +620,32 @@ func GetConfig(v *viper.Viper) (Config, error) {
 			FeeHistoryCap:            v.GetInt32("json-rpc.feehistory-cap"),
 			TxFeeCap:                 v.GetFloat64("json-rpc.txfee-cap"),
 			EVMTimeout:               v.GetDuration("json-rpc.evm-timeout"),
+			SendTxSyncTimeout:        v.GetDuration("json-rpc.send-tx-sync-timeout"),
 			LogsCap:                  v.GetInt32("json-rpc.logs-cap"),
 			BlockRangeCap:            v.GetInt32("json-rpc.block-range-cap"),
 			HTTPTimeout:              v.GetDuration("json-rpc.http-timeout"),
 			HTTPIdleTimeout:          v.GetDuration("json-rpc.http-idle-timeout"),
 			MaxOpenConnections:       v.GetInt("json-rpc.max-open-connections"),
 			EnableIndexer:            v.GetBool("json-rpc.enable-indexer"),
+			EnableTraceIndexer:       v.GetBool("json-rpc.enable-trace-indexer"),
+			TraceFilterRangeCap:      v.GetInt32("json-rpc.trace-filter-range-cap"),
+			EnableTraceCache:         v.GetBool("json-rpc.enable-trace-cache"),
+			TraceCacheMaxSize:        v.GetInt64("json-rpc.trace-cache-max-size"),
+			TraceCacheMaxEntrySize:   v.GetInt64("json-rpc.trace-cache-max-entry-size"),
 			MetricsAddress:           v.GetString("json-rpc.metrics-address"),
 			FixRevertGasRefundHeight: v.GetInt64("json-rpc.fix-revert-gas-refund-height"),
+			BatchRequestLimit:        v.GetInt("json-rpc.batch-request-limit"),
+			ResponseMaxSize:          v.GetInt("json-rpc.response-max-size"),
+			RateLimit:                v.GetFloat64("json-rpc.rate-limit"),
+			RateLimitBurst:           v.GetInt("json-rpc.rate-limit-burst"),
+			MethodRateLimits:         v.GetStringSlice("json-rpc.method-rate-limits"),
+			AllowedMethods:           v.GetStringSlice("json-rpc.allowed-methods"),
+			DeniedMethods:            v.GetStringSlice("json-rpc.denied-methods"),
+			EnableAuth:               v.GetBool("json-rpc.enable-auth"),
+			AuthAddress:              v.GetString("json-rpc.auth-address"),
+			AuthJWTSecret:            v.GetString("json-rpc.auth-jwt-secret"),
+			AuthAPI:                  v.GetStringSlice("json-rpc.auth-api"),
+			EnableGraphQL:            v.GetBool("json-rpc.enable-graphql"),
 		},
 		TLS: TLSConfig{
 			CertificatePath: v.GetString("tls.certificate-path"),
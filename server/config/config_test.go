@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -12,3 +13,78 @@ func TestDefaultConfig(t *testing.T) {
 	require.Equal(t, cfg.JSONRPC.Address, DefaultJSONRPCAddress)
 	require.Equal(t, cfg.JSONRPC.WsAddress, DefaultJSONRPCWsAddress)
 }
+
+func TestParseMethodRateLimits(t *testing.T) {
+	testCases := []struct {
+		name    string
+		entries []string
+		exp     []MethodRateLimit
+		expPass bool
+	}{
+		{"empty", nil, []MethodRateLimit{}, true},
+		{
+			"method and prefix",
+			[]string{"eth_getLogs=2:5", "debug_*=0.5:1"},
+			[]MethodRateLimit{{"eth_getLogs", 2, 5}, {"debug_*", 0.5, 1}},
+			true,
+		},
+		{"missing burst", []string{"eth_getLogs=2"}, nil, false},
+		{"missing method", []string{"=2:5"}, nil, false},
+		{"invalid rate", []string{"eth_getLogs=x:5"}, nil, false},
+		{"zero burst", []string{"eth_getLogs=2:0"}, nil, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			limits, err := ParseMethodRateLimits(tc.entries)
+			if !tc.expPass {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, len(tc.exp), len(limits))
+			for i := range tc.exp {
+				require.Equal(t, tc.exp[i], limits[i])
+			}
+		})
+	}
+}
+
+func TestMethodRateLimitMatches(t *testing.T) {
+	require.True(t, MethodRateLimit{Pattern: "eth_getLogs"}.Matches("eth_getLogs"))
+	require.False(t, MethodRateLimit{Pattern: "eth_getLogs"}.Matches("eth_getLogsX"))
+	require.True(t, MethodRateLimit{Pattern: "debug_*"}.Matches("debug_traceTransaction"))
+	require.False(t, MethodRateLimit{Pattern: "debug_*"}.Matches("eth_call"))
+}
+
+func TestValidateMethodFilters(t *testing.T) {
+	cfg := DefaultJSONRPCConfig()
+	cfg.AllowedMethods = []string{"eth_*"}
+	cfg.DeniedMethods = []string{"eth_sign"}
+	require.NoError(t, cfg.Validate())
+
+	cfg.DeniedMethods = []string{""}
+	require.Error(t, cfg.Validate())
+}
+
+func TestValidateBlockTrace(t *testing.T) {
+	cfg := DefaultEVMConfig()
+	cfg.BlockTraceDir = "data/block-traces"
+	require.NoError(t, cfg.Validate())
+
+	// the block tracer would replace the tracer of the delivered transactions
+	cfg.Tracer = "json"
+	require.Error(t, cfg.Validate())
+}
+
+func TestValidateSendTxSyncTimeout(t *testing.T) {
+	cfg := DefaultJSONRPCConfig()
+	require.NoError(t, cfg.Validate())
+
+	// zero uses the default timeout
+	cfg.SendTxSyncTimeout = 0
+	require.NoError(t, cfg.Validate())
+
+	cfg.SendTxSyncTimeout = -time.Second
+	require.Error(t, cfg.Validate())
+}
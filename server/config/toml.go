@@ -31,6 +31,33 @@ tracer = "{{ .EVM.Tracer }}"
 # MaxTxGasWanted defines the gas wanted for each eth tx returned in ante handler in check tx mode.
 max-tx-gas-wanted = {{ .EVM.MaxTxGasWanted }}
 
+# QueryTimeout is the deadline of the gRPC queries executing the EVM (eth_call, estimate gas and traces),
+# it applies to every entry point, including the JSON-RPC server. 0 means no deadline.
+query-timeout = "{{ .EVM.QueryTimeout }}"
+
+# QueryGasCap is the gas cap of the gRPC queries executing the EVM (eth_call and estimate gas),
+# it applies to every entry point, including the JSON-RPC server. 0 means no cap.
+query-gas-cap = {{ .EVM.QueryGasCap }}
+
+# BlockTraceDir is the directory of the block trace files, relative to the node home if not absolute.
+# When set, the delivered transactions are traced and written to JSONL files as the blocks commit. Empty means disabled.
+# It can't be combined with the tracer above.
+block-trace-dir = "{{ .EVM.BlockTraceDir }}"
+
+# BlockTracer is the native tracer of the block trace files.
+# Valid tracers are: callTracer|prestateTracer|4byteTracer
+block-tracer = "{{ .EVM.BlockTracer }}"
+
+# BlockTraceAddresses are the contract addresses filtering the traced transactions, only the transactions creating or
+# calling one of them are written. Empty means all transactions.
+block-trace-addresses = [{{range $index, $elmt := .EVM.BlockTraceAddresses}}{{if $index}}, {{end}}"{{$elmt}}"{{end}}]
+
+# BlockTraceBlocksPerFile is the number of blocks covered by a block trace file before it's rotated.
+block-trace-blocks-per-file = {{ .EVM.BlockTraceBlocksPerFile }}
+
+# BlockTraceMaxFiles is the number of block trace files kept, the oldest are removed first. 0 means all.
+block-trace-max-files = {{ .EVM.BlockTraceMaxFiles }}
+
 ###############################################################################
 ###                           JSON RPC Configuration                        ###
 ###############################################################################
@@ -59,6 +86,10 @@ evm-timeout = "{{ .JSONRPC.EVMTimeout }}"
 # TxFeeCap is the global tx-fee cap for send transaction. Default: 1eth.
 txfee-cap = {{ .JSONRPC.TxFeeCap }}
 
+# SendTxSyncTimeout is the max wait of 'eth_sendRawTransactionSync' for the tx inclusion,
+# it also caps the timeout given by the request, 0 uses the default. Default: 10s.
+send-tx-sync-timeout = "{{ .JSONRPC.SendTxSyncTimeout }}"
+
 # FilterCap sets the global cap for total number of filters that can be created
 filter-cap = {{ .JSONRPC.FilterCap }}
 
@@ -88,6 +119,21 @@ max-open-connections = {{ .JSONRPC.MaxOpenConnections }}
 # EnableIndexer enables the custom transaction indexer for the EVM (ethereum transactions).
 enable-indexer = {{ .JSONRPC.EnableIndexer }}
 
+# EnableTraceIndexer enables the index of the flat call traces used by 'trace_filter', it requires 'enable-indexer'.
+enable-trace-indexer = {{ .JSONRPC.EnableTraceIndexer }}
+
+# TraceFilterRangeCap defines the max block range replayed by 'trace_filter' when the range is not covered by the trace index.
+trace-filter-range-cap = {{ .JSONRPC.TraceFilterRangeCap }}
+
+# EnableTraceCache enables the on-disk cache of the 'debug_traceTransaction' results, keyed by the tx hash and the tracer config.
+enable-trace-cache = {{ .JSONRPC.EnableTraceCache }}
+
+# TraceCacheMaxSize defines the max total size in bytes of the cached trace results, the least recently used are evicted first.
+trace-cache-max-size = {{ .JSONRPC.TraceCacheMaxSize }}
+
+# TraceCacheMaxEntrySize defines the max size in bytes of a cached trace result, the larger results are not cached.
+trace-cache-max-entry-size = {{ .JSONRPC.TraceCacheMaxEntrySize }}
+
 # MetricsAddress defines the EVM Metrics server address to bind to. Pass --metrics in CLI to enable
 # Prometheus metrics path: /debug/metrics/prometheus
 metrics-address = "{{ .JSONRPC.MetricsAddress }}"
@@ -95,6 +141,50 @@ metrics-address = "{{ .JSONRPC.MetricsAddress }}"
 # Upgrade height for fix of revert gas refund logic when transaction reverted.
 fix-revert-gas-refund-height = {{ .JSONRPC.FixRevertGasRefundHeight }}
 
+# BatchRequestLimit defines the max number of requests of a batch (0=unlimited), for the HTTP and WebSocket servers.
+batch-request-limit = {{ .JSONRPC.BatchRequestLimit }}
+
+# ResponseMaxSize defines the max size in bytes of a response (0=unlimited), the larger responses are replaced by an error.
+response-max-size = {{ .JSONRPC.ResponseMaxSize }}
+
+# RateLimit defines the number of requests per second allowed for a client IP (0=unlimited),
+# each request of a batch is counted. It applies to the HTTP and WebSocket servers.
+rate-limit = {{ .JSONRPC.RateLimit }}
+
+# RateLimitBurst defines the number of requests a client IP can send at once above the rate limit.
+rate-limit-burst = {{ .JSONRPC.RateLimitBurst }}
+
+# MethodRateLimits defines additional rate limits of the methods for a client IP, in the "method=rate:burst" format.
+# A method ending with '*' matches all the methods with the prefix, the first matching limit applies.
+# Example: ["debug_*=1:5", "eth_getLogs=10:20"]
+method-rate-limits = [{{range $index, $elmt := .JSONRPC.MethodRateLimits}}{{if $index}}, {{end}}"{{$elmt}}"{{end}}]
+
+# AllowedMethods defines the methods served among the enabled namespaces (empty=all), for the HTTP and WebSocket servers.
+# A method ending with '*' matches all the methods with the prefix. Example: ["eth_*", "net_version"]
+allowed-methods = [{{range $index, $elmt := .JSONRPC.AllowedMethods}}{{if $index}}, {{end}}"{{$elmt}}"{{end}}]
+
+# DeniedMethods defines the methods rejected by the HTTP and WebSocket servers, it takes precedence over the allowed methods.
+# Example: ["eth_sign", "eth_sendTransaction", "eth_accounts"]
+denied-methods = [{{range $index, $elmt := .JSONRPC.DeniedMethods}}{{if $index}}, {{end}}"{{$elmt}}"{{end}}]
+
+# EnableAuth defines if the JWT authenticated HTTP and WebSocket server should be enabled.
+# The auth-api namespaces are then served only by it, the requests require a HS256 bearer token.
+enable-auth = {{ .JSONRPC.EnableAuth }}
+
+# Address defines the JWT authenticated HTTP and WebSocket server address to bind to.
+auth-address = "{{ .JSONRPC.AuthAddress }}"
+
+# AuthJWTSecret defines the path of the hex encoded JWT secret file, relative to the node home if not absolute.
+# A new secret is generated if the file doesn't exist.
+auth-jwt-secret = "{{ .JSONRPC.AuthJWTSecret }}"
+
+# AuthAPI defines the privileged namespaces of api served only by the JWT authenticated server, it serves all the api namespaces.
+auth-api = "{{range $index, $elmt := .JSONRPC.AuthAPI}}{{if $index}},{{$elmt}}{{else}}{{$elmt}}{{end}}{{end}}"
+
+# EnableGraphQL defines if the GraphQL service of the go-ethereum schema should be served on the /graphql path of the HTTP server.
+# The rate and size limits apply to it and the matching eth_ methods of the allowed and denied methods to its fields.
+enable-graphql = {{ .JSONRPC.EnableGraphQL }}
+
 ###############################################################################
 ###                             TLS Configuration                           ###
 ###############################################################################
@@ -57,6 +57,7 @@ const (
 	JSONRPCGasCap              = "json-rpc.gas-cap"
 	JSONRPCEVMTimeout          = "json-rpc.evm-timeout"
 	JSONRPCTxFeeCap            = "json-rpc.txfee-cap"
+	JSONRPCSendTxSyncTimeout   = "json-rpc.send-tx-sync-timeout"
 	JSONRPCFilterCap           = "json-rpc.filter-cap"
 	JSONRPCLogsCap             = "json-rpc.logs-cap"
 	JSONRPCBlockRangeCap       = "json-rpc.block-range-cap"
@@ -65,6 +66,23 @@ const (
 	JSONRPCAllowUnprotectedTxs = "json-rpc.allow-unprotected-txs"
 	JSONRPCMaxOpenConnections  = "json-rpc.max-open-connections"
 	JSONRPCEnableIndexer       = "json-rpc.enable-indexer"
+	JSONRPCEnableTraceIndexer  = "json-rpc.enable-trace-indexer"
+	JSONRPCTraceFilterRangeCap = "json-rpc.trace-filter-range-cap"
+	JSONRPCEnableTraceCache    = "json-rpc.enable-trace-cache"
+	JSONRPCTraceCacheMaxSize   = "json-rpc.trace-cache-max-size"
+	JSONRPCTraceCacheMaxEntry  = "json-rpc.trace-cache-max-entry-size"
+	JSONRPCBatchRequestLimit   = "json-rpc.batch-request-limit"
+	JSONRPCResponseMaxSize     = "json-rpc.response-max-size"
+	JSONRPCRateLimit           = "json-rpc.rate-limit"
+	JSONRPCRateLimitBurst      = "json-rpc.rate-limit-burst"
+	JSONRPCMethodRateLimits    = "json-rpc.method-rate-limits"
+	JSONRPCAllowedMethods      = "json-rpc.allowed-methods"
+	JSONRPCDeniedMethods       = "json-rpc.denied-methods"
+	JSONRPCEnableAuth          = "json-rpc.enable-auth"
+	JSONRPCAuthAddress         = "json-rpc.auth-address"
+	JSONRPCAuthJWTSecret       = "json-rpc.auth-jwt-secret"
+	JSONRPCAuthAPI             = "json-rpc.auth-api"
+	JSONRPCEnableGraphQL       = "json-rpc.enable-graphql"
 	// JSONRPCEnableMetrics enables EVM RPC metrics server.
 	// Set to `metrics` which is hardcoded flag from go-ethereum.
 	// https://github.com/ethereum/go-ethereum/blob/master/metrics/metrics.go#L35-L55
@@ -76,6 +94,14 @@ const (
 const (
 	EVMTracer         = "evm.tracer"
 	EVMMaxTxGasWanted = "evm.max-tx-gas-wanted"
+	EVMQueryTimeout   = "evm.query-timeout"
+	EVMQueryGasCap    = "evm.query-gas-cap"
+
+	EVMBlockTraceDir           = "evm.block-trace-dir"
+	EVMBlockTracer             = "evm.block-tracer"
+	EVMBlockTraceAddresses     = "evm.block-trace-addresses"
+	EVMBlockTraceBlocksPerFile = "evm.block-trace-blocks-per-file"
+	EVMBlockTraceMaxFiles      = "evm.block-trace-max-files"
 )
 
 // TLS flags
@@ -17,17 +17,22 @@ package server
 
 import (
 	"net/http"
+	"path/filepath"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
 	"github.com/rs/cors"
 
+	rpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/server"
 	"github.com/cosmos/cosmos-sdk/server/types"
 	ethlog "github.com/ethereum/go-ethereum/log"
 	ethrpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/evmos/ethermint/rpc"
+	"github.com/evmos/ethermint/rpc/backend"
+	"github.com/evmos/ethermint/rpc/graphql"
 
 	"github.com/evmos/ethermint/server/config"
 	ethermint "github.com/evmos/ethermint/types"
@@ -40,6 +45,7 @@ func StartJSONRPC(ctx *server.Context,
 	tmEndpoint string,
 	config *config.Config,
 	indexer ethermint.EVMTxIndexer,
+	traceCache *backend.TraceCache,
 ) (*http.Server, chan struct{}, error) {
 	tmWsClient := ConnectTmWS(tmRPCAddr, tmEndpoint, ctx.Logger)
 
@@ -56,26 +62,33 @@ func StartJSONRPC(ctx *server.Context,
 		return nil
 	}))
 
-	rpcServer := ethrpc.NewServer()
-
 	allowUnprotectedTxs := config.JSONRPC.AllowUnprotectedTxs
-	rpcAPIArr := config.JSONRPC.API
+	rpcAPIArr, authAPIArr := config.JSONRPC.API, []string(nil)
+	if config.JSONRPC.EnableAuth {
+		rpcAPIArr, authAPIArr = splitAuthNamespaces(config.JSONRPC.API, config.JSONRPC.AuthAPI)
+	}
 
-	apis := rpc.GetRPCAPIs(ctx, clientCtx, tmWsClient, allowUnprotectedTxs, indexer, rpcAPIArr)
+	rpcServer, err := newRPCServer(ctx, clientCtx, tmWsClient, allowUnprotectedTxs, indexer, traceCache, rpcAPIArr)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	for _, api := range apis {
-		if err := rpcServer.RegisterName(api.Namespace, api.Service); err != nil {
-			ctx.Logger.Error(
-				"failed to register service in JSON RPC namespace",
-				"namespace", api.Namespace,
-				"service", api.Service,
-			)
-			return nil, nil, err
-		}
+	limiter, err := rpc.NewRequestLimiter(config.JSONRPC)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	r := mux.NewRouter()
-	r.HandleFunc("/", rpcServer.ServeHTTP).Methods("POST")
+	r.Handle("/", limiter.Handler(rpcServer)).Methods("POST")
+
+	if config.JSONRPC.EnableGraphQL {
+		evmBackend := backend.NewBackend(ctx, ctx.Logger.With("api", "graphql"), clientCtx, allowUnprotectedTxs, indexer, traceCache)
+		graphqlHandler, err := graphql.NewHandler(ctx.Logger, evmBackend, limiter.MethodAllowed)
+		if err != nil {
+			return nil, nil, err
+		}
+		r.Handle("/graphql", limiter.GraphQLHandler(graphqlHandler)).Methods("POST")
+	}
 
 	handlerWithCors := cors.Default()
 	if config.API.EnableUnsafeCORS {
@@ -90,39 +103,158 @@ func StartJSONRPC(ctx *server.Context,
 		WriteTimeout:      config.JSONRPC.HTTPTimeout,
 		IdleTimeout:       config.JSONRPC.HTTPIdleTimeout,
 	}
-	httpSrvDone := make(chan struct{}, 1)
-
-	ln, err := Listen(httpSrv.Addr, config)
+	httpSrvDone, err := serveHTTP(ctx, "JSON-RPC", httpSrv, config)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	ctx.Logger.Info("Starting JSON WebSocket server", "address", config.JSONRPC.WsAddress)
+
+	// allocate separate WS connection to Tendermint
+	tmWsClient = ConnectTmWS(tmRPCAddr, tmEndpoint, ctx.Logger)
+	evmBackend := backend.NewBackend(ctx, ctx.Logger, clientCtx, allowUnprotectedTxs, indexer, traceCache)
+	wsSrv := rpc.NewWebsocketsServer(clientCtx, ctx.Logger, tmWsClient, config, evmBackend, limiter, rpcAPIArr)
+	wsSrv.Start()
+
+	if config.JSONRPC.EnableAuth {
+		// allocate separate WS connection to Tendermint
+		tmWsClient = ConnectTmWS(tmRPCAddr, tmEndpoint, ctx.Logger)
+		authSrv, err := startAuthJSONRPC(ctx, clientCtx, tmWsClient, config, indexer, traceCache, authAPIArr)
+		if err != nil {
+			return nil, nil, err
+		}
+		httpSrv.RegisterOnShutdown(func() {
+			_ = authSrv.Close()
+		})
+	}
+	return httpSrv, httpSrvDone, nil
+}
+
+// startAuthJSONRPC starts the JWT authenticated JSON-RPC server, it serves the namespaces over HTTP and WebSocket
+// on the same address.
+func startAuthJSONRPC(ctx *server.Context,
+	clientCtx client.Context,
+	tmWsClient *rpcclient.WSClient,
+	config *config.Config,
+	indexer ethermint.EVMTxIndexer,
+	traceCache *backend.TraceCache,
+	namespaces []string,
+) (*http.Server, error) {
+	secretPath := config.JSONRPC.AuthJWTSecret
+	if !filepath.IsAbs(secretPath) {
+		secretPath = filepath.Join(ctx.Config.RootDir, secretPath)
+	}
+	secret, err := rpc.LoadJWTSecret(secretPath)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcServer, err := newRPCServer(ctx, clientCtx, tmWsClient, config.JSONRPC.AllowUnprotectedTxs, indexer, traceCache, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	wsHandler := rpcServer.WebsocketHandler([]string{"*"})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if websocket.IsWebSocketUpgrade(r) {
+			wsHandler.ServeHTTP(w, r)
+			return
+		}
+		rpcServer.ServeHTTP(w, r)
+	})
+
+	authSrv := &http.Server{
+		Addr:              config.JSONRPC.AuthAddress,
+		Handler:           rpc.NewJWTHandler(secret, handler),
+		ReadHeaderTimeout: config.JSONRPC.HTTPTimeout,
+		ReadTimeout:       config.JSONRPC.HTTPTimeout,
+		WriteTimeout:      config.JSONRPC.HTTPTimeout,
+		IdleTimeout:       config.JSONRPC.HTTPIdleTimeout,
+	}
+
+	if _, err := serveHTTP(ctx, "JSON-RPC auth", authSrv, config); err != nil {
+		return nil, err
+	}
+	return authSrv, nil
+}
+
+// newRPCServer returns a JSON-RPC server with the APIs of the namespaces registered.
+func newRPCServer(ctx *server.Context,
+	clientCtx client.Context,
+	tmWsClient *rpcclient.WSClient,
+	allowUnprotectedTxs bool,
+	indexer ethermint.EVMTxIndexer,
+	traceCache *backend.TraceCache,
+	namespaces []string,
+) (*ethrpc.Server, error) {
+	rpcServer := ethrpc.NewServer()
+
+	apis := rpc.GetRPCAPIs(ctx, clientCtx, tmWsClient, allowUnprotectedTxs, indexer, traceCache, namespaces)
+
+	for _, api := range apis {
+		if err := rpcServer.RegisterName(api.Namespace, api.Service); err != nil {
+			ctx.Logger.Error(
+				"failed to register service in JSON RPC namespace",
+				"namespace", api.Namespace,
+				"service", api.Service,
+			)
+			return nil, err
+		}
+	}
+	return rpcServer, nil
+}
+
+// serveHTTP starts serving the HTTP server, the returned channel is closed when the server is shut down.
+func serveHTTP(ctx *server.Context, name string, srv *http.Server, config *config.Config) (chan struct{}, error) {
+	srvDone := make(chan struct{}, 1)
+
+	ln, err := Listen(srv.Addr, config)
+	if err != nil {
+		return nil, err
+	}
+
 	errCh := make(chan error)
 	go func() {
-		ctx.Logger.Info("Starting JSON-RPC server", "address", config.JSONRPC.Address)
-		if err := httpSrv.Serve(ln); err != nil {
+		ctx.Logger.Info("Starting "+name+" server", "address", srv.Addr)
+		if err := srv.Serve(ln); err != nil {
 			if err == http.ErrServerClosed {
-				close(httpSrvDone)
+				close(srvDone)
 				return
 			}
 
-			ctx.Logger.Error("failed to start JSON-RPC server", "error", err.Error())
+			ctx.Logger.Error("failed to start "+name+" server", "error", err.Error())
 			errCh <- err
 		}
 	}()
 
 	select {
 	case err := <-errCh:
-		ctx.Logger.Error("failed to boot JSON-RPC server", "error", err.Error())
-		return nil, nil, err
-	case <-time.After(types.ServerStartTime): // assume JSON RPC server started successfully
+		ctx.Logger.Error("failed to boot "+name+" server", "error", err.Error())
+		return nil, err
+	case <-time.After(types.ServerStartTime): // assume server started successfully
 	}
+	return srvDone, nil
+}
 
-	ctx.Logger.Info("Starting JSON WebSocket server", "address", config.JSONRPC.WsAddress)
+// splitAuthNamespaces returns the namespaces of the public server, the api ones without the privileged ones, and
+// the namespaces of the JWT authenticated server, all the api ones. The privileged namespaces missing from api
+// aren't served.
+func splitAuthNamespaces(api, authAPI []string) (public, auth []string) {
+	privileged := make(map[string]bool, len(authAPI))
+	for _, ns := range authAPI {
+		privileged[ns] = true
+	}
 
-	// allocate separate WS connection to Tendermint
-	tmWsClient = ConnectTmWS(tmRPCAddr, tmEndpoint, ctx.Logger)
-	wsSrv := rpc.NewWebsocketsServer(clientCtx, ctx.Logger, tmWsClient, config)
-	wsSrv.Start()
-	return httpSrv, httpSrvDone, nil
+	served := make(map[string]bool, len(api))
+	for _, ns := range api {
+		if served[ns] {
+			continue
+		}
+		served[ns] = true
+		auth = append(auth, ns)
+		if !privileged[ns] {
+			public = append(public, ns)
+		}
+	}
+	return public, auth
 }
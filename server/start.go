@@ -63,7 +63,9 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/evmos/ethermint/indexer"
+	"github.com/evmos/ethermint/rpc/backend"
 	ethdebug "github.com/evmos/ethermint/rpc/namespaces/ethereum/debug"
+	rpctypes "github.com/evmos/ethermint/rpc/types"
 	"github.com/evmos/ethermint/server/config"
 	srvflags "github.com/evmos/ethermint/server/flags"
 	ethermint "github.com/evmos/ethermint/types"
@@ -203,6 +205,7 @@ which accepts a path for the resulting pprof file.
 	cmd.Flags().Float64(srvflags.JSONRPCTxFeeCap, config.DefaultTxFeeCap, "Sets a cap on transaction fee that can be sent via the RPC APIs (1 = default 1 photon)") //nolint:lll
 	cmd.Flags().Int32(srvflags.JSONRPCFilterCap, config.DefaultFilterCap, "Sets the global cap for total number of filters that can be created")
 	cmd.Flags().Duration(srvflags.JSONRPCEVMTimeout, config.DefaultEVMTimeout, "Sets a timeout used for eth_call (0=infinite)")
+	cmd.Flags().Duration(srvflags.JSONRPCSendTxSyncTimeout, config.DefaultSendTxSyncTimeout, "Sets the max wait of `eth_sendRawTransactionSync` for the tx inclusion")
 	cmd.Flags().Duration(srvflags.JSONRPCHTTPTimeout, config.DefaultHTTPTimeout, "Sets a read/write timeout for json-rpc http server (0=infinite)")
 	cmd.Flags().Duration(srvflags.JSONRPCHTTPIdleTimeout, config.DefaultHTTPIdleTimeout, "Sets a idle timeout for json-rpc http server (0=infinite)")
 	cmd.Flags().Bool(srvflags.JSONRPCAllowUnprotectedTxs, config.DefaultAllowUnprotectedTxs, "Allow for unprotected (non EIP155 signed) transactions to be submitted via the node's RPC when the global parameter is disabled") //nolint:lll
@@ -210,10 +213,34 @@ which accepts a path for the resulting pprof file.
 	cmd.Flags().Int32(srvflags.JSONRPCBlockRangeCap, config.DefaultBlockRangeCap, "Sets the max block range allowed for `eth_getLogs` query")
 	cmd.Flags().Int(srvflags.JSONRPCMaxOpenConnections, config.DefaultMaxOpenConnections, "Sets the maximum number of simultaneous connections for the server listener") //nolint:lll
 	cmd.Flags().Bool(srvflags.JSONRPCEnableIndexer, false, "Enable the custom tx indexer for json-rpc")
+	cmd.Flags().Bool(srvflags.JSONRPCEnableTraceIndexer, false, "Enable the flat call trace indexer for `trace_filter`, requires the custom tx indexer")
+	cmd.Flags().Int32(srvflags.JSONRPCTraceFilterRangeCap, config.DefaultTraceFilterRangeCap, "Sets the max block range replayed by `trace_filter` when not covered by the trace index")
+	cmd.Flags().Bool(srvflags.JSONRPCEnableTraceCache, false, "Enable the on-disk cache of the `debug_traceTransaction` results")
+	cmd.Flags().Int64(srvflags.JSONRPCTraceCacheMaxSize, config.DefaultTraceCacheMaxSize, "Sets the max total size in bytes of the cached trace results")
+	cmd.Flags().Int64(srvflags.JSONRPCTraceCacheMaxEntry, config.DefaultTraceCacheMaxEntrySize, "Sets the max size in bytes of a cached trace result")
+	cmd.Flags().Int(srvflags.JSONRPCBatchRequestLimit, config.DefaultBatchRequestLimit, "Sets the max number of requests of a batch (0=unlimited)")
+	cmd.Flags().Int(srvflags.JSONRPCResponseMaxSize, config.DefaultResponseMaxSize, "Sets the max size in bytes of a response (0=unlimited)")
+	cmd.Flags().Float64(srvflags.JSONRPCRateLimit, 0, "Sets the number of requests per second allowed for a client IP (0=unlimited)")
+	cmd.Flags().Int(srvflags.JSONRPCRateLimitBurst, 0, "Sets the number of requests a client IP can send at once above the rate limit")
+	cmd.Flags().StringSlice(srvflags.JSONRPCMethodRateLimits, []string{}, "Sets the rate limits of the methods for a client IP, in the method=rate:burst format")
+	cmd.Flags().StringSlice(srvflags.JSONRPCAllowedMethods, []string{}, "Sets the methods served among the enabled namespaces, a trailing * matches a prefix (empty=all)")
+	cmd.Flags().StringSlice(srvflags.JSONRPCDeniedMethods, []string{}, "Sets the methods rejected by the JSON-RPC servers, a trailing * matches a prefix")
+	cmd.Flags().Bool(srvflags.JSONRPCEnableAuth, false, "Define if the JWT authenticated JSON-RPC server should be enabled, it then serves the auth-api namespaces exclusively")
+	cmd.Flags().String(srvflags.JSONRPCAuthAddress, config.DefaultJSONRPCAuthAddress, "the JWT authenticated JSON-RPC HTTP and WS server address to listen on")
+	cmd.Flags().String(srvflags.JSONRPCAuthJWTSecret, config.DefaultJSONRPCAuthJWTSecret, "Sets the path of the hex encoded JWT secret file, relative to the node home, generated if missing")
+	cmd.Flags().StringSlice(srvflags.JSONRPCAuthAPI, config.GetDefaultAuthAPINamespaces(), "Defines the privileged namespaces of the JSON-RPC API served only by the JWT authenticated server")
+	cmd.Flags().Bool(srvflags.JSONRPCEnableGraphQL, false, "Define if the GraphQL service should be served on the /graphql path of the JSON-RPC server")
 	cmd.Flags().Bool(srvflags.JSONRPCEnableMetrics, false, "Define if EVM rpc metrics server should be enabled")
 
 	cmd.Flags().String(srvflags.EVMTracer, config.DefaultEVMTracer, "the EVM tracer type to collect execution traces from the EVM transaction execution (json|struct|access_list|markdown)") //nolint:lll
 	cmd.Flags().Uint64(srvflags.EVMMaxTxGasWanted, config.DefaultMaxTxGasWanted, "the gas wanted for each eth tx returned in ante handler in check tx mode")                                 //nolint:lll
+	cmd.Flags().Duration(srvflags.EVMQueryTimeout, config.DefaultEVMQueryTimeout, "Sets a deadline for the gRPC queries executing the EVM (0=infinite)")
+	cmd.Flags().Uint64(srvflags.EVMQueryGasCap, config.DefaultEVMQueryGasCap, "Sets a cap on gas for the gRPC queries executing the EVM (0=infinite)")
+	cmd.Flags().String(srvflags.EVMBlockTraceDir, "", "Sets the directory of the block trace files, the delivered transactions are traced to them as the blocks commit (empty=disabled)")
+	cmd.Flags().String(srvflags.EVMBlockTracer, config.DefaultBlockTracer, "the native tracer of the block trace files (callTracer|prestateTracer|4byteTracer)")
+	cmd.Flags().StringSlice(srvflags.EVMBlockTraceAddresses, nil, "Only writes the traces of the transactions creating or calling one of the contract addresses (empty=all)")
+	cmd.Flags().Int64(srvflags.EVMBlockTraceBlocksPerFile, config.DefaultBlockTraceBlocksPerFile, "Sets the number of blocks covered by a block trace file before it's rotated")
+	cmd.Flags().Int(srvflags.EVMBlockTraceMaxFiles, config.DefaultBlockTraceMaxFiles, "Sets the number of block trace files kept (0=all)")
 
 	cmd.Flags().String(srvflags.TLSCertPath, "", "the cert.pem file path for the server TLS configuration")
 	cmd.Flags().String(srvflags.TLSKeyPath, "", "the key.pem file path for the server TLS configuration")
@@ -369,6 +396,7 @@ func startInProcess(ctx *server.Context, clientCtx client.Context, opts StartOpt
 		logger.Info("starting node in query only mode; Tendermint is disabled")
 		config.GRPC.Enable = true
 		config.JSONRPC.EnableIndexer = false
+		config.JSONRPC.EnableTraceIndexer = false
 	} else {
 		logger.Info("starting node with ABCI Tendermint in-process")
 
@@ -561,12 +589,57 @@ func startInProcess(ctx *server.Context, clientCtx client.Context, opts StartOpt
 
 		clientCtx := clientCtx.WithChainID(genDoc.ChainID)
 
+		// the backends of the json-rpc namespaces share the cache
+		var traceCache *backend.TraceCache
+		if config.JSONRPC.EnableTraceCache {
+			traceCacheDB, err := OpenTraceCacheDB(home, server.GetAppDBBackend(ctx.Viper))
+			if err != nil {
+				logger.Error("failed to open trace cache DB", "error", err.Error())
+				return err
+			}
+			traceCache, err = backend.NewTraceCache(traceCacheDB, config.JSONRPC.TraceCacheMaxSize, config.JSONRPC.TraceCacheMaxEntrySize)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := traceCache.Close(); err != nil {
+					logger.Error("failed to close the trace cache DB", "error", err.Error())
+				}
+			}()
+		}
+
 		tmEndpoint := "/websocket"
 		tmRPCAddr := cfg.RPC.ListenAddress
-		httpSrv, httpSrvDone, err = StartJSONRPC(ctx, clientCtx, tmRPCAddr, tmEndpoint, &config, idxer)
+		httpSrv, httpSrvDone, err = StartJSONRPC(ctx, clientCtx, tmRPCAddr, tmEndpoint, &config, idxer, traceCache)
 		if err != nil {
 			return err
 		}
+
+		if config.JSONRPC.EnableTraceIndexer {
+			traceIdxer, ok := idxer.(rpctypes.TraceIndexer)
+			if !ok {
+				return fmt.Errorf("the evm indexer %T doesn't support trace indexing", idxer)
+			}
+			traceIdxLogger := ctx.Logger.With("indexer", "evm-trace")
+			evmBackend := backend.NewBackend(ctx, traceIdxLogger, clientCtx, config.JSONRPC.AllowUnprotectedTxs, idxer, traceCache)
+			traceIndexerService := NewEVMTraceIndexerService(idxer, traceIdxer, func(height int64) ([]*rpctypes.ParityTrace, error) {
+				return evmBackend.TraceBlockFlat(rpctypes.BlockNumber(height))
+			}, clientCtx.Client.(rpcclient.Client))
+			traceIndexerService.SetLogger(traceIdxLogger)
+
+			errCh := make(chan error)
+			go func() {
+				if err := traceIndexerService.Start(); err != nil {
+					errCh <- err
+				}
+			}()
+
+			select {
+			case err := <-errCh:
+				return err
+			case <-time.After(types.ServerStartTime): // assume server started successfully
+			}
+		}
 		defer func() {
 			shutdownCtx, cancelFn := context.WithTimeout(context.Background(), 10*time.Second)
 			defer cancelFn()
@@ -653,6 +726,12 @@ func OpenIndexerDB(rootDir string, backendType dbm.BackendType) (dbm.DB, error)
 	return dbm.NewDB("evmindexer", backendType, dataDir)
 }
 
+// OpenTraceCacheDB opens the json-rpc trace cache db, using the same db backend as the main app
+func OpenTraceCacheDB(rootDir string, backendType dbm.BackendType) (dbm.DB, error) {
+	dataDir := filepath.Join(rootDir, "data")
+	return dbm.NewDB("tracecache", backendType, dataDir)
+}
+
 func openTraceWriter(traceWriterFile string) (w io.Writer, err error) {
 	if traceWriterFile == "" {
 		return
@@ -0,0 +1,171 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	errorsmod "cosmossdk.io/errors"
+	"github.com/cometbft/cometbft/libs/service"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	"github.com/cometbft/cometbft/types"
+
+	rpctypes "github.com/evmos/ethermint/rpc/types"
+	ethermint "github.com/evmos/ethermint/types"
+)
+
+const (
+	TraceIndexerServiceName = "EVMTraceIndexerService"
+
+	// TraceIndexerRetryInterval is the wait time before retrying a block failed to be traced
+	TraceIndexerRetryInterval = time.Second
+	// TraceIndexerMaxRetries is the number of attempts to trace a block before it's recorded as failed and skipped
+	TraceIndexerMaxRetries = 5
+)
+
+// BlockTracer returns the flat call traces of a block.
+type BlockTracer func(height int64) ([]*rpctypes.ParityTrace, error)
+
+// EVMTraceIndexerService indexes the flat call traces of the blocks for the `trace_filter` json-rpc api.
+type EVMTraceIndexerService struct {
+	service.BaseService
+
+	txIdxr    ethermint.EVMTxIndexer
+	traceIdxr rpctypes.TraceIndexer
+	tracer    BlockTracer
+	client    rpcclient.Client
+}
+
+// NewEVMTraceIndexerService returns a new service instance.
+func NewEVMTraceIndexerService(
+	txIdxr ethermint.EVMTxIndexer,
+	traceIdxr rpctypes.TraceIndexer,
+	tracer BlockTracer,
+	client rpcclient.Client,
+) *EVMTraceIndexerService {
+	is := &EVMTraceIndexerService{txIdxr: txIdxr, traceIdxr: traceIdxr, tracer: tracer, client: client}
+	is.BaseService = *service.NewBaseService(nil, TraceIndexerServiceName, is)
+	return is
+}
+
+// OnStart implements service.Service by subscribing for new blocks and indexing
+// the traces of them. Unlike the tx indexer, a block failed to be traced is retried
+// before being skipped, and the skipped block is recorded as failed, so the queries
+// covering it fail instead of silently missing its traces. An empty trace index is
+// backfilled from the first block of the tx index.
+func (tis *EVMTraceIndexerService) OnStart() error {
+	ctx := context.Background()
+	status, err := tis.client.Status(ctx)
+	if err != nil {
+		return err
+	}
+	latestBlock := status.SyncInfo.LatestBlockHeight
+	newBlockSignal := make(chan struct{}, 1)
+
+	blockHeadersChan, err := tis.client.Subscribe(
+		ctx,
+		TraceIndexerServiceName,
+		types.QueryForEvent(types.EventNewBlockHeader).String(),
+		0)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			msg := <-blockHeadersChan
+			eventDataHeader, ok := msg.Data.(types.EventDataNewBlockHeader)
+			if !ok {
+				tis.Logger.Error("unexpected new block header event data", "type", fmt.Sprintf("%T", msg.Data))
+				continue
+			}
+			if eventDataHeader.Header.Height > latestBlock {
+				latestBlock = eventDataHeader.Header.Height
+				// notify
+				select {
+				case newBlockSignal <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	lastBlock, err := tis.traceIdxr.LastIndexedTraceBlock()
+	if err != nil {
+		return err
+	}
+	if lastBlock == -1 {
+		lastBlock, err = tis.backfillStart(latestBlock)
+		if err != nil {
+			return err
+		}
+	}
+	// retries counts the failed attempts of the block next to lastBlock
+	retries := 0
+	for {
+		if latestBlock <= lastBlock {
+			// nothing to index. wait for signal of new block
+			select {
+			case <-newBlockSignal:
+			case <-time.After(NewBlockWaitTimeout):
+			}
+			continue
+		}
+		for i := lastBlock + 1; i <= latestBlock; i++ {
+			if err := tis.indexBlock(i); err != nil {
+				retries++
+				if retries < TraceIndexerMaxRetries {
+					tis.Logger.Error("failed to index block traces, retrying", "height", i, "retries", retries, "err", err)
+					time.Sleep(TraceIndexerRetryInterval)
+					break
+				}
+				tis.Logger.Error("failed to index block traces, skipping", "height", i, "err", err)
+				if err := tis.traceIdxr.IndexFailedTraceBlock(i, err.Error()); err != nil {
+					tis.Logger.Error("failed to record failed trace block", "height", i, "err", err)
+					time.Sleep(TraceIndexerRetryInterval)
+					break
+				}
+			}
+			retries = 0
+			lastBlock = i
+		}
+	}
+}
+
+// backfillStart returns the block preceding the first block to index when the trace index is empty, the blocks
+// of the tx index are traced so the trace index covers the same range, if the tx index is empty too only the
+// new blocks are indexed.
+func (tis *EVMTraceIndexerService) backfillStart(latestBlock int64) (int64, error) {
+	first, err := tis.txIdxr.FirstIndexedBlock()
+	if err != nil {
+		return 0, err
+	}
+	if first == -1 || first > latestBlock {
+		return latestBlock, nil
+	}
+	return first - 1, nil
+}
+
+// indexBlock traces the block and indexes the traces
+func (tis *EVMTraceIndexerService) indexBlock(height int64) error {
+	traces, err := tis.tracer(height)
+	if err != nil {
+		return errorsmod.Wrap(err, "failed to trace block")
+	}
+	return tis.traceIdxr.IndexBlockTraces(height, traces)
+}
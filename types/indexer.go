@@ -25,6 +25,8 @@ import (
 type EVMTxIndexer interface {
 	// LastIndexedBlock returns -1 if indexer db is empty
 	LastIndexedBlock() (int64, error)
+	// FirstIndexedBlock returns -1 if indexer db is empty
+	FirstIndexedBlock() (int64, error)
 	IndexBlock(*tmtypes.Block, []*abci.ResponseDeliverTx) error
 
 	// GetByTxHash returns nil if tx not found.
@@ -32,3 +34,10 @@ type EVMTxIndexer interface {
 	// GetByBlockAndIndex returns nil if tx not found.
 	GetByBlockAndIndex(int64, int32) (*TxResult, error)
 }
+
+// BadBlockIndexer defines the interface of the indexers recording the bad blocks, the blocks containing eth txs
+// rejected in DeliverTx.
+type BadBlockIndexer interface {
+	// GetBadBlockHeight returns -1 if the block is not a recorded bad block.
+	GetBadBlockHeight(common.Hash) (int64, error)
+}
@@ -88,7 +88,8 @@ func InitGenesis(
 		k.SetCode(ctx, codeHash.Bytes(), code)
 
 		for _, storage := range account.Storage {
-			k.SetState(ctx, address, common.HexToHash(storage.Key), common.HexToHash(storage.Value).Bytes())
+			key := common.HexToHash(storage.Key)
+			k.SetState(ctx, address, key, k.GetState(ctx, address, key).Bytes(), common.HexToHash(storage.Value).Bytes())
 		}
 	}
 
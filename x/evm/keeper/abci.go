@@ -27,6 +27,7 @@ import (
 // BeginBlock sets the sdk Context and EIP155 chain id to the Keeper.
 func (k *Keeper) BeginBlock(ctx sdk.Context, _ abci.RequestBeginBlock) {
 	k.WithChainID(ctx)
+	k.stateCache.reset(ctx.BlockHeight(), ctx.MultiStore())
 
 	if utils.IsEthermintDevChain(ctx) {
 		// trigger VFBC registration on Ethermint devnet for development purpose
@@ -51,5 +52,10 @@ func (k *Keeper) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.Valida
 	bloom := ethtypes.BytesToBloom(k.GetBlockBloomTransient(infCtx).Bytes())
 	k.EmitBlockBloomEvent(infCtx, bloom)
 
+	k.writeBlockTxTraces(infCtx)
+
+	// the state cache is only valid while delivering the current block
+	k.stateCache.reset(0, nil)
+
 	return []abci.ValidatorUpdate{}
 }
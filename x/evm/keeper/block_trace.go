@@ -0,0 +1,170 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package keeper
+
+import (
+	"encoding/json"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+
+	"github.com/evmos/ethermint/x/evm/statedb"
+	"github.com/evmos/ethermint/x/evm/types"
+)
+
+// blockTraceConfig defines the tracing of the delivered transactions into the block trace files.
+type blockTraceConfig struct {
+	tracer string
+	// only the transactions creating or calling one of the addresses are written, all of them if empty
+	addresses map[common.Address]struct{}
+	writer    types.BlockTraceWriter
+}
+
+// SetBlockTracer enables the tracing of the delivered transactions with the named tracer. The traces of the
+// transactions creating or calling one of the addresses (any transaction if empty) are handed to the writer
+// at the end of the block.
+func (k *Keeper) SetBlockTracer(tracer string, addresses []common.Address, writer types.BlockTraceWriter) *Keeper {
+	cfg := &blockTraceConfig{
+		tracer:    tracer,
+		addresses: make(map[common.Address]struct{}, len(addresses)),
+		writer:    writer,
+	}
+	for _, addr := range addresses {
+		cfg.addresses[addr] = struct{}{}
+	}
+	k.blockTrace = cfg
+	return k
+}
+
+// blockTxTracer traces a delivered transaction for the block trace files, it records the addresses
+// created or called by the transaction to apply the address filter.
+type blockTxTracer struct {
+	*types.VFCEffectsTracer
+
+	called map[common.Address]struct{}
+}
+
+// CaptureStart implements vm.EVMLogger interface
+func (t *blockTxTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.called[to] = struct{}{}
+	t.VFCEffectsTracer.CaptureStart(env, from, to, create, input, gas, value)
+}
+
+// CaptureEnter implements vm.EVMLogger interface
+func (t *blockTxTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	t.called[to] = struct{}{}
+	t.VFCEffectsTracer.CaptureEnter(typ, from, to, input, gas, value)
+}
+
+// newBlockTxTracer returns the tracer of a delivered transaction, it returns nil if the block tracing is
+// disabled or the transaction is not delivered.
+func (k *Keeper) newBlockTxTracer(ctx sdk.Context, txConfig statedb.TxConfig) *blockTxTracer {
+	if k.blockTrace == nil || ctx.IsCheckTx() || ctx.IsReCheckTx() {
+		return nil
+	}
+
+	tracer, err := tracers.New(k.blockTrace.tracer, &tracers.Context{
+		BlockHash: txConfig.BlockHash,
+		TxIndex:   int(txConfig.TxIndex),
+		TxHash:    txConfig.TxHash,
+	}, nil)
+	if err != nil {
+		k.Logger(ctx).Error("failed to create the block tracer", "tracer", k.blockTrace.tracer, "error", err.Error())
+		return nil
+	}
+
+	return &blockTxTracer{
+		VFCEffectsTracer: types.NewVFCEffectsTracer(tracer, k.blockTrace.tracer, nil),
+		called:           make(map[common.Address]struct{}),
+	}
+}
+
+// setBlockTxTrace stores the trace of the delivered transaction in the transient store, so that it's
+// discarded with the state changes if the transaction fails, the traces are collected at the end of the block.
+func (k *Keeper) setBlockTxTrace(
+	ctx sdk.Context,
+	tracer *blockTxTracer,
+	msg core.Message,
+	txConfig statedb.TxConfig,
+	res *types.MsgEthereumTxResponse,
+) {
+	if len(k.blockTrace.addresses) > 0 {
+		matched := false
+		for addr := range tracer.called {
+			if _, found := k.blockTrace.addresses[addr]; found {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return
+		}
+	}
+
+	trace := types.BlockTxTrace{
+		BlockHeight: ctx.BlockHeight(),
+		BlockHash:   txConfig.BlockHash,
+		TxIndex:     uint64(txConfig.TxIndex),
+		TxHash:      txConfig.TxHash,
+		From:        msg.From(),
+		To:          msg.To(),
+		GasUsed:     hexutil.Uint64(res.GasUsed),
+		Status:      hexutil.Uint64(ethtypes.ReceiptStatusSuccessful),
+		VmError:     res.VmError,
+	}
+	if res.Failed() {
+		trace.Status = hexutil.Uint64(ethtypes.ReceiptStatusFailed)
+	}
+
+	result, err := tracer.GetResult()
+	if err != nil {
+		trace.Error = err.Error()
+	} else {
+		trace.Result = result
+	}
+
+	bz, err := json.Marshal(&trace)
+	if err != nil {
+		k.Logger(ctx).Error("failed to encode the block tx trace", "hash", txConfig.TxHash.Hex(), "error", err.Error())
+		return
+	}
+
+	store := ctx.TransientStore(k.transientKey)
+	store.Set(append(types.KeyPrefixTransientTxTrace, sdk.Uint64ToBigEndian(uint64(txConfig.TxIndex))...), bz)
+}
+
+// writeBlockTxTraces hands the traces of the transactions delivered in the block to the writer.
+func (k *Keeper) writeBlockTxTraces(ctx sdk.Context) {
+	if k.blockTrace == nil {
+		return
+	}
+
+	store := ctx.TransientStore(k.transientKey)
+	it := sdk.KVStorePrefixIterator(store, types.KeyPrefixTransientTxTrace)
+	defer it.Close()
+
+	var traces [][]byte
+	for ; it.Valid(); it.Next() {
+		traces = append(traces, it.Value())
+	}
+	k.blockTrace.writer.AddBlock(ctx.BlockHeight(), traces)
+}
@@ -0,0 +1,83 @@
+package keeper_test
+
+import (
+	"encoding/json"
+	"math/big"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/evmos/ethermint/x/evm/types"
+)
+
+type blockTraceWriter struct {
+	height int64
+	traces [][]byte
+}
+
+func (w *blockTraceWriter) AddBlock(height int64, traces [][]byte) {
+	w.height = height
+	w.traces = traces
+}
+
+func (suite *KeeperTestSuite) TestBlockTrace() {
+	testCases := []struct {
+		name      string
+		addresses func(contract common.Address) []common.Address
+		expTraces int
+	}{
+		{
+			"all transactions",
+			func(common.Address) []common.Address { return nil },
+			2,
+		},
+		{
+			"transactions creating or calling the contract",
+			func(contract common.Address) []common.Address { return []common.Address{contract} },
+			2,
+		},
+		{
+			"no transaction calling the address",
+			func(common.Address) []common.Address { return []common.Address{common.HexToAddress("0x1")} },
+			0,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+
+			contract := crypto.CreateAddress(suite.address, suite.app.EvmKeeper.GetNonce(suite.ctx, suite.address))
+			writer := &blockTraceWriter{}
+			suite.app.EvmKeeper.SetBlockTracer(types.TracerCall, tc.addresses(contract), writer)
+
+			suite.DeployTestContract(suite.T(), suite.address, big.NewInt(1000))
+			suite.TransferERC20Token(suite.T(), contract, suite.address, common.HexToAddress("0x2"), big.NewInt(10))
+
+			suite.app.EvmKeeper.EndBlock(suite.ctx, abci.RequestEndBlock{})
+			suite.Require().Equal(suite.ctx.BlockHeight(), writer.height)
+			suite.Require().Len(writer.traces, tc.expTraces)
+
+			for _, bz := range writer.traces {
+				var trace types.BlockTxTrace
+				suite.Require().NoError(json.Unmarshal(bz, &trace))
+				suite.Require().Equal(suite.ctx.BlockHeight(), trace.BlockHeight)
+				suite.Require().Equal(suite.address, trace.From)
+				suite.Require().Equal(uint64(1), uint64(trace.Status))
+				suite.Require().NotZero(trace.GasUsed)
+				suite.Require().Empty(trace.Error)
+
+				var frame struct {
+					Type string `json:"type"`
+				}
+				suite.Require().NoError(json.Unmarshal(trace.Result, &frame))
+				if trace.To == nil {
+					suite.Require().Equal("CREATE", frame.Type)
+				} else {
+					suite.Require().Equal(contract, *trace.To)
+					suite.Require().Equal("CALL", frame.Type)
+				}
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@
 package keeper
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -24,18 +25,23 @@ import (
 	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/query"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	"github.com/evmos/ethermint/utils"
 	"math/big"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	sdkmath "cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -53,6 +59,13 @@ var _ types.QueryServer = Keeper{}
 
 const (
 	defaultTraceTimeout = 5 * time.Second
+
+	// AccountRangeMaxStorage is the maximum number of storage slots returned per account by Query/AccountRange
+	AccountRangeMaxStorage = 256
+	// AccountRangeMaxLimit is the maximum number of accounts returned by Query/AccountRange
+	AccountRangeMaxLimit = 100
+	// StorageRangeMaxLimit is the maximum number of storage slots returned by Query/StorageRange
+	StorageRangeMaxLimit = 1024
 )
 
 // Account implements the Query/Account gRPC method
@@ -219,6 +232,226 @@ func (k Keeper) Code(c context.Context, req *types.QueryCodeRequest) (*types.Que
 	}, nil
 }
 
+// StorageStats implements the Query/StorageStats gRPC method
+func (k Keeper) StorageStats(c context.Context, req *types.QueryStorageStatsRequest) (*types.QueryStorageStatsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if err := ethermint.ValidateAddress(req.Address); err != nil {
+		return nil, status.Error(
+			codes.InvalidArgument,
+			types.ErrZeroAddress.Error(),
+		)
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+
+	address := common.HexToAddress(req.Address)
+
+	return &types.QueryStorageStatsResponse{
+		Stats: k.GetStorageStats(ctx, address),
+	}, nil
+}
+
+// StorageRange implements the Query/StorageRange gRPC method
+func (k Keeper) StorageRange(c context.Context, req *types.QueryStorageRangeRequest) (*types.QueryStorageRangeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if err := ethermint.ValidateAddress(req.Address); err != nil {
+		return nil, status.Error(
+			codes.InvalidArgument,
+			types.ErrZeroAddress.Error(),
+		)
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	address := common.HexToAddress(req.Address)
+
+	// replay the predecessors to get the storage at the position of the transaction
+	if len(req.Predecessors) > 0 {
+		var cancel context.CancelFunc
+		ctx = utils.UseZeroGasConfig(ctx) // avoid Cosmos consumes gas unexpectedly.
+		ctx, cancel = k.evmQueryContext(c, ctx)
+		defer cancel()
+
+		ctx = ctx.WithBlockHeight(req.BlockNumber)
+		ctx = ctx.WithBlockTime(req.BlockTime)
+		ctx = ctx.WithHeaderHash(common.Hex2Bytes(req.BlockHash))
+		chainID, err := getChainID(ctx, req.ChainId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		cfg, err := k.EVMConfig(ctx, GetProposerAddress(ctx, req.ProposerAddress), chainID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to load evm config: %s", err.Error())
+		}
+		signer := ethtypes.MakeSigner(cfg.ChainConfig, big.NewInt(ctx.BlockHeight()))
+
+		txConfig := statedb.NewEmptyTxConfig(common.BytesToHash(ctx.HeaderHash().Bytes()))
+		if _, err := k.replayPredecessors(ctx, cfg, signer, txConfig, req.Predecessors); err != nil {
+			return nil, err
+		}
+	}
+
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.AddressStoragePrefix(address))
+	var storage types.Storage
+	pageRes, err := query.Paginate(store, limitPageRequest(req.Pagination, StorageRangeMaxLimit), func(key, value []byte) error {
+		storage = append(storage, types.NewState(common.BytesToHash(key), common.BytesToHash(value)))
+		return nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &types.QueryStorageRangeResponse{
+		Storage:    storage,
+		Pagination: pageRes,
+	}, nil
+}
+
+// AccountRange implements the Query/AccountRange gRPC method, the storage of each account is capped to
+// AccountRangeMaxStorage slots, the rest can be queried with Query/StorageRange from the next storage key.
+func (k Keeper) AccountRange(c context.Context, req *types.QueryAccountRangeRequest) (*types.QueryAccountRangeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	ctx, cancel := k.evmQueryContext(c, ctx)
+	defer cancel()
+
+	res, err := k.accountKeeper.Accounts(c, &authtypes.QueryAccountsRequest{
+		Pagination: limitPageRequest(req.Pagination, AccountRangeMaxLimit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]types.AccountRangeEntry, 0, len(res.Accounts))
+	for _, accAny := range res.Accounts {
+		if err := k.checkQueryAborted(ctx); err != nil {
+			return nil, err
+		}
+
+		var acc authtypes.AccountI
+		if err := k.cdc.UnpackAny(accAny, &acc); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		// skip the accounts without an ethereum address
+		if len(acc.GetAddress()) != common.AddressLength {
+			continue
+		}
+
+		address := common.BytesToAddress(acc.GetAddress())
+		account := k.GetAccountOrEmpty(ctx, address)
+		entry := types.AccountRangeEntry{
+			Address:  address.Hex(),
+			Balance:  account.Balance.String(),
+			Nonce:    account.Nonce,
+			CodeHash: account.CodeHash,
+		}
+		if !req.NoCode && !bytes.Equal(account.CodeHash, types.EmptyCodeHash) {
+			entry.Code = k.GetCode(ctx, common.BytesToHash(account.CodeHash))
+		}
+		if !req.NoStorage {
+			store := prefix.NewStore(ctx.KVStore(k.storeKey), types.AddressStoragePrefix(address))
+			pageRes, err := query.Paginate(store, &query.PageRequest{Limit: AccountRangeMaxStorage}, func(key, value []byte) error {
+				entry.Storage = append(entry.Storage, types.NewState(common.BytesToHash(key), common.BytesToHash(value)))
+				return nil
+			})
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			entry.NextStorageKey = pageRes.NextKey
+		}
+		accounts = append(accounts, entry)
+	}
+
+	return &types.QueryAccountRangeResponse{
+		Accounts:   accounts,
+		Pagination: res.Pagination,
+	}, nil
+}
+
+// limitPageRequest returns the page request with the limit clamped to maxLimit, an unset limit is left to the
+// default limit of the pagination, which doesn't exceed the maximum limits.
+func limitPageRequest(pageReq *query.PageRequest, maxLimit uint64) *query.PageRequest {
+	if pageReq == nil || pageReq.Limit <= maxLimit {
+		return pageReq
+	}
+	res := *pageReq
+	res.Limit = maxLimit
+	return &res
+}
+
+// PendingState implements the Query/PendingState gRPC method
+func (k Keeper) PendingState(c context.Context, req *types.QueryPendingStateRequest) (*types.QueryPendingStateResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	for _, address := range req.Addresses {
+		if err := ethermint.ValidateAddress(address); err != nil {
+			return nil, status.Error(
+				codes.InvalidArgument,
+				types.ErrZeroAddress.Error(),
+			)
+		}
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	ctx = utils.UseZeroGasConfig(ctx) // avoid Cosmos consumes gas unexpectedly.
+	ctx, cancel := k.evmQueryContext(c, ctx)
+	defer cancel()
+
+	chainID, err := getChainID(ctx, req.ChainId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	cfg, err := k.EVMConfig(ctx, GetProposerAddress(ctx, req.ProposerAddress), chainID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	// the pending state is never written to the store
+	ctx, _ = ctx.CacheContext()
+	ctx, changed := withPendingAccounts(ctx)
+	applied, gasUsed, err := k.applyPendingTxs(ctx, cfg, req.Txs)
+	if err != nil {
+		return nil, err
+	}
+	recordBankEventAccounts(ctx.EventManager().Events(), changed)
+
+	res := &types.QueryPendingStateResponse{
+		Accounts:        make([]types.PendingAccount, len(req.Addresses)),
+		TxHashes:        make([]string, len(applied)),
+		GasUsed:         gasUsed,
+		ChangedAccounts: make([]types.PendingAccount, 0, len(changed)),
+	}
+	for i, hash := range applied {
+		res.TxHashes[i] = hash.Hex()
+	}
+	for i, address := range req.Addresses {
+		res.Accounts[i] = k.pendingAccount(ctx, common.HexToAddress(address))
+	}
+
+	addrs := make([]common.Address, 0, len(changed))
+	for addr := range changed {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i].Bytes(), addrs[j].Bytes()) < 0
+	})
+	for _, addr := range addrs {
+		res.ChangedAccounts = append(res.ChangedAccounts, k.pendingAccount(ctx, addr))
+	}
+
+	return res, nil
+}
+
 // Params implements the Query/Params gRPC method
 func (k Keeper) Params(c context.Context, _ *types.QueryParamsRequest) (*types.QueryParamsResponse, error) {
 	ctx := sdk.UnwrapSDKContext(c)
@@ -237,6 +470,8 @@ func (k Keeper) EthCall(c context.Context, req *types.EthCallRequest) (*types.Ms
 
 	ctx := sdk.UnwrapSDKContext(c)
 	ctx = utils.UseZeroGasConfig(ctx) // avoid Cosmos consumes gas unexpectedly.
+	ctx, cancel := k.evmQueryContext(c, ctx)
+	defer cancel()
 
 	var args types.TransactionArgs
 	err := json.Unmarshal(req.Args, &args)
@@ -252,11 +487,19 @@ func (k Keeper) EthCall(c context.Context, req *types.EthCallRequest) (*types.Ms
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	// execute the call on the pending state
+	if len(req.PendingTxs) > 0 {
+		ctx, _ = ctx.CacheContext()
+		if _, _, err := k.applyPendingTxs(ctx, cfg, req.PendingTxs); err != nil {
+			return nil, err
+		}
+	}
+
 	// ApplyMessageWithConfig expect correct nonce set in msg
 	nonce := k.GetNonce(ctx, args.GetFrom())
 	args.Nonce = (*hexutil.Uint64)(&nonce)
 
-	msg, err := args.ToMessage(req.GasCap, cfg.BaseFee)
+	msg, err := args.ToMessage(k.capQueryGas(req.GasCap), cfg.BaseFee)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -265,6 +508,9 @@ func (k Keeper) EthCall(c context.Context, req *types.EthCallRequest) (*types.Ms
 
 	// pass false to not commit StateDB
 	res, err := k.ApplyMessageWithConfig(ctx, msg, nil, false, cfg, txConfig)
+	if err := k.checkQueryAborted(ctx); err != nil {
+		return nil, err
+	}
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -280,6 +526,8 @@ func (k Keeper) EstimateGas(c context.Context, req *types.EthCallRequest) (*type
 
 	ctx := sdk.UnwrapSDKContext(c)
 	ctx = utils.UseZeroGasConfig(ctx) // avoid Cosmos consumes gas unexpectedly.
+	ctx, cancel := k.evmQueryContext(c, ctx)
+	defer cancel()
 
 	chainID, err := getChainID(ctx, req.ChainId)
 	if err != nil {
@@ -289,6 +537,7 @@ func (k Keeper) EstimateGas(c context.Context, req *types.EthCallRequest) (*type
 	if req.GasCap < ethparams.TxGas {
 		return nil, status.Error(codes.InvalidArgument, "gas cap cannot be lower than 21,000")
 	}
+	reqGasCap := k.capQueryGas(req.GasCap)
 
 	var args types.TransactionArgs
 	err = json.Unmarshal(req.Args, &args)
@@ -312,15 +561,15 @@ func (k Keeper) EstimateGas(c context.Context, req *types.EthCallRequest) (*type
 		if params != nil && params.Block != nil && params.Block.MaxGas > 0 {
 			hi = uint64(params.Block.MaxGas)
 		} else {
-			hi = req.GasCap
+			hi = reqGasCap
 		}
 	}
 
 	// TODO: Recap the highest gas limit with account's available balance.
 
 	// Recap the highest gas allowance with specified gascap.
-	if req.GasCap != 0 && hi > req.GasCap {
-		hi = req.GasCap
+	if reqGasCap != 0 && hi > reqGasCap {
+		hi = reqGasCap
 	}
 	gasCap = hi
 	cfg, err := k.EVMConfig(ctx, GetProposerAddress(ctx, req.ProposerAddress), chainID)
@@ -335,7 +584,7 @@ func (k Keeper) EstimateGas(c context.Context, req *types.EthCallRequest) (*type
 	txConfig := statedb.NewEmptyTxConfig(common.BytesToHash(ctx.HeaderHash().Bytes()))
 
 	// convert the tx args to an ethereum message
-	msg, err := args.ToMessage(req.GasCap, cfg.BaseFee)
+	msg, err := args.ToMessage(reqGasCap, cfg.BaseFee)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -362,6 +611,9 @@ func (k Keeper) EstimateGas(c context.Context, req *types.EthCallRequest) (*type
 
 		// pass false to not commit StateDB
 		rsp, err = k.ApplyMessageWithConfig(ctx, msg, nil, false, cfg, txConfig)
+		if errAborted := k.checkQueryAborted(ctx); errAborted != nil {
+			return true, nil, errAborted // Bail out
+		}
 		if err != nil {
 			if errors.Is(err, core.ErrIntrinsicGas) {
 				return true, nil, nil // Special case, raise gas limit
@@ -419,6 +671,8 @@ func (k Keeper) TraceTx(c context.Context, req *types.QueryTraceTxRequest) (*typ
 
 	ctx := sdk.UnwrapSDKContext(c)
 	ctx = utils.UseZeroGasConfig(ctx) // avoid Cosmos consumes gas unexpectedly.
+	ctx, cancel := k.evmQueryContext(c, ctx)
+	defer cancel()
 
 	ctx = ctx.WithBlockHeight(contextHeight)
 	ctx = ctx.WithBlockTime(req.BlockTime)
@@ -433,20 +687,9 @@ func (k Keeper) TraceTx(c context.Context, req *types.QueryTraceTxRequest) (*typ
 	}
 	signer := ethtypes.MakeSigner(cfg.ChainConfig, big.NewInt(ctx.BlockHeight()))
 
-	txConfig := statedb.NewEmptyTxConfig(common.BytesToHash(ctx.HeaderHash().Bytes()))
-	for i, tx := range req.Predecessors {
-		ethTx := tx.AsTransaction()
-		msg, err := ethTx.AsMessage(signer, cfg.BaseFee)
-		if err != nil {
-			continue
-		}
-		txConfig.TxHash = ethTx.Hash()
-		txConfig.TxIndex = uint(i)
-		rsp, err := k.ApplyMessageWithConfig(ctx, msg, types.NewNoOpTracer(), true, cfg, txConfig)
-		if err != nil {
-			continue
-		}
-		txConfig.LogIndex += uint(len(rsp.Logs))
+	txConfig, err := k.replayPredecessors(ctx, cfg, signer, statedb.NewEmptyTxConfig(common.BytesToHash(ctx.HeaderHash().Bytes())), req.Predecessors)
+	if err != nil {
+		return nil, err
 	}
 
 	tx := req.Msg.AsTransaction()
@@ -461,7 +704,10 @@ func (k Keeper) TraceTx(c context.Context, req *types.QueryTraceTxRequest) (*typ
 		_ = json.Unmarshal([]byte(req.TraceConfig.TracerJsonConfig), &tracerConfig)
 	}
 
-	result, _, err := k.traceTx(ctx, cfg, txConfig, signer, tx, req.TraceConfig, false, tracerConfig)
+	result, res, err := k.traceTx(ctx, cfg, txConfig, signer, tx, req.TraceConfig, false, tracerConfig)
+	if err := k.checkQueryAborted(ctx); err != nil {
+		return nil, err
+	}
 	if err != nil {
 		// error will be returned with detail status from traceTx
 		return nil, err
@@ -474,6 +720,10 @@ func (k Keeper) TraceTx(c context.Context, req *types.QueryTraceTxRequest) (*typ
 
 	return &types.QueryTraceTxResponse{
 		Data: resultData,
+		Replay: &types.TraceReplayResult{
+			GasUsed: res.GasUsed,
+			Failed:  res.Failed(),
+		},
 	}, nil
 }
 
@@ -485,8 +735,71 @@ func (k Keeper) TraceBlock(c context.Context, req *types.QueryTraceBlockRequest)
 		return nil, status.Error(codes.InvalidArgument, "empty request")
 	}
 
+	results := make([]*types.TxTraceResult, 0, len(req.Txs))
+	replays := make([]types.TraceReplayResult, 0, len(req.Txs))
+	err := k.traceBlock(c, sdk.UnwrapSDKContext(c), req, func(_ int, result *types.TxTraceResult, replay types.TraceReplayResult) error {
+		results = append(results, result)
+		replays = append(replays, replay)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resultData, err := json.Marshal(results)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryTraceBlockResponse{
+		Data:    resultData,
+		Replays: replays,
+	}, nil
+}
+
+// TraceBlockStream implements the TraceBlock query as a server streaming gRPC method, the trace result
+// of each transaction is sent as soon as it completes, so the size of a response is bounded by a single
+// transaction, and the tracing waits for the client to receive the sent results.
+func (k Keeper) TraceBlockStream(req *types.QueryTraceBlockRequest, stream types.Query_TraceBlockStreamServer) (err error) {
+	if req == nil {
+		return status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	// the streaming handlers are not wrapped by the recovery interceptor of the unary queries
+	defer func() {
+		if r := recover(); r != nil {
+			err = status.Errorf(codes.Internal, "trace block stream panicked: %v", r)
+		}
+	}()
+
+	ctx, err := k.streamQueryContext(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	return k.traceBlock(stream.Context(), ctx, req, func(index int, result *types.TxTraceResult, replay types.TraceReplayResult) error {
+		data, err := json.Marshal(result)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		return stream.Send(&types.QueryTraceBlockStreamResponse{
+			Index:  uint64(index),
+			Data:   data,
+			Replay: replay,
+		})
+	})
+}
+
+// traceBlock traces the transactions of the block request one by one, passing the result of
+// each transaction to fn as it completes.
+func (k Keeper) traceBlock(
+	c context.Context,
+	ctx sdk.Context,
+	req *types.QueryTraceBlockRequest,
+	fn func(index int, result *types.TxTraceResult, replay types.TraceReplayResult) error,
+) error {
 	if req.TraceConfig != nil && req.TraceConfig.Limit < 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "output limit cannot be negative, got %d", req.TraceConfig.Limit)
+		return status.Errorf(codes.InvalidArgument, "output limit cannot be negative, got %d", req.TraceConfig.Limit)
 	}
 
 	// get the context of block beginning
@@ -496,23 +809,22 @@ func (k Keeper) TraceBlock(c context.Context, req *types.QueryTraceBlockRequest)
 		contextHeight = 1
 	}
 
-	ctx := sdk.UnwrapSDKContext(c)
 	ctx = utils.UseZeroGasConfig(ctx) // avoid Cosmos consumes gas unexpectedly.
+	ctx, cancel := k.evmQueryContext(c, ctx)
+	defer cancel()
 	ctx = ctx.WithBlockHeight(contextHeight)
 	ctx = ctx.WithBlockTime(req.BlockTime)
 	ctx = ctx.WithHeaderHash(common.Hex2Bytes(req.BlockHash))
 	chainID, err := getChainID(ctx, req.ChainId)
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, err.Error())
+		return status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	cfg, err := k.EVMConfig(ctx, GetProposerAddress(ctx, req.ProposerAddress), chainID)
 	if err != nil {
-		return nil, status.Error(codes.Internal, "failed to load evm config")
+		return status.Error(codes.Internal, "failed to load evm config")
 	}
 	signer := ethtypes.MakeSigner(cfg.ChainConfig, big.NewInt(ctx.BlockHeight()))
-	txsLength := len(req.Txs)
-	results := make([]*types.TxTraceResult, 0, txsLength)
 
 	txConfig := statedb.NewEmptyTxConfig(common.BytesToHash(ctx.HeaderHash().Bytes()))
 	for i, tx := range req.Txs {
@@ -520,27 +832,114 @@ func (k Keeper) TraceBlock(c context.Context, req *types.QueryTraceBlockRequest)
 		ethTx := tx.AsTransaction()
 		txConfig.TxHash = ethTx.Hash()
 		txConfig.TxIndex = uint(i)
-		traceResult, logIndex, err := k.traceTx(ctx, cfg, txConfig, signer, ethTx, req.TraceConfig, true, nil)
+		replay := types.TraceReplayResult{}
+		traceResult, res, err := k.traceTx(ctx, cfg, txConfig, signer, ethTx, req.TraceConfig, true, nil)
+		if err := k.checkQueryAborted(ctx); err != nil {
+			return err
+		}
 		if err != nil {
 			result.Error = err.Error()
 		} else {
-			txConfig.LogIndex = logIndex
+			txConfig.LogIndex += uint(len(res.Logs))
 			result.Result = traceResult
+			replay.GasUsed = res.GasUsed
+			replay.Failed = res.Failed()
+		}
+		if err := fn(i, &result, replay); err != nil {
+			return err
 		}
-		results = append(results, &result)
 	}
+	return nil
+}
 
-	resultData, err := json.Marshal(results)
+// TraceCall configures a new tracer according to the provided configuration, and
+// executes the given call message on top of the state of the queried block,
+// optionally with the state overrides applied. The return value will be tracer dependent.
+func (k Keeper) TraceCall(c context.Context, req *types.QueryTraceCallRequest) (*types.QueryTraceCallResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if req.TraceConfig != nil && req.TraceConfig.Limit < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "output limit cannot be negative, got %d", req.TraceConfig.Limit)
+	}
+
+	contextHeight := req.BlockNumber
+	if contextHeight < 1 {
+		// 0 is a special value in `ContextWithHeight`
+		contextHeight = 1
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	ctx = utils.UseZeroGasConfig(ctx) // avoid Cosmos consumes gas unexpectedly.
+	ctx, cancel := k.evmQueryContext(c, ctx)
+	defer cancel()
+
+	ctx = ctx.WithBlockHeight(contextHeight)
+	ctx = ctx.WithBlockTime(req.BlockTime)
+	ctx = ctx.WithHeaderHash(common.Hex2Bytes(req.BlockHash))
+
+	var args types.TransactionArgs
+	if err := json.Unmarshal(req.Args, &args); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var stateOverrides statedb.StateOverride
+	if len(req.StateOverrides) > 0 {
+		if err := json.Unmarshal(req.StateOverrides, &stateOverrides); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid state overrides: %s", err.Error())
+		}
+	}
+
+	chainID, err := getChainID(ctx, req.ChainId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	cfg, err := k.EVMConfig(ctx, GetProposerAddress(ctx, req.ProposerAddress), chainID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load evm config: %s", err.Error())
+	}
+	cfg.StateOverrides = stateOverrides
+
+	// ApplyMessageWithConfig expect correct nonce set in msg
+	nonce := k.GetNonce(ctx, args.GetFrom())
+	if override, ok := stateOverrides[args.GetFrom()]; ok && override.Nonce != nil {
+		nonce = uint64(*override.Nonce)
+	}
+	args.Nonce = (*hexutil.Uint64)(&nonce)
+
+	msg, err := args.ToMessage(k.capQueryGas(req.GasCap), cfg.BaseFee)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var tracerConfig json.RawMessage
+	if req.TraceConfig != nil && req.TraceConfig.TracerJsonConfig != "" {
+		// ignore error. default to no traceConfig
+		_ = json.Unmarshal([]byte(req.TraceConfig.TracerJsonConfig), &tracerConfig)
+	}
+
+	txConfig := statedb.NewEmptyTxConfig(common.BytesToHash(ctx.HeaderHash().Bytes()))
+	result, _, err := k.traceMsg(ctx, cfg, txConfig, msg, req.TraceConfig, false, tracerConfig)
+	if err := k.checkQueryAborted(ctx); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		// error will be returned with detail status from traceMsg
+		return nil, err
+	}
+
+	resultData, err := json.Marshal(result)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	return &types.QueryTraceBlockResponse{
+	return &types.QueryTraceCallResponse{
 		Data: resultData,
 	}, nil
 }
 
-// traceTx do trace on one transaction, it returns a tuple: (traceResult, nextLogIndex, error).
+// traceTx do trace on one transaction, it returns a tuple: (traceResult, executionResult, error).
 func (k *Keeper) traceTx(
 	ctx sdk.Context,
 	cfg *statedb.EVMConfig,
@@ -550,7 +949,54 @@ func (k *Keeper) traceTx(
 	traceConfig *types.TraceConfig,
 	commitMessage bool,
 	tracerJSONConfig json.RawMessage,
-) (*interface{}, uint, error) {
+) (*interface{}, *types.MsgEthereumTxResponse, error) {
+	msg, err := tx.AsMessage(signer, cfg.BaseFee)
+	if err != nil {
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return k.traceMsg(ctx, cfg, txConfig, msg, traceConfig, commitMessage, tracerJSONConfig)
+}
+
+// replayPredecessors applies the transactions included in the same block before the requested position,
+// the failed ones are skipped. It returns the tx config of the last replayed transaction.
+func (k *Keeper) replayPredecessors(
+	ctx sdk.Context,
+	cfg *statedb.EVMConfig,
+	signer ethtypes.Signer,
+	txConfig statedb.TxConfig,
+	predecessors []*types.MsgEthereumTx,
+) (statedb.TxConfig, error) {
+	for i, tx := range predecessors {
+		ethTx := tx.AsTransaction()
+		msg, err := ethTx.AsMessage(signer, cfg.BaseFee)
+		if err != nil {
+			continue
+		}
+		txConfig.TxHash = ethTx.Hash()
+		txConfig.TxIndex = uint(i)
+		rsp, err := k.ApplyMessageWithConfig(ctx, msg, types.NewNoOpTracer(), true, cfg, txConfig)
+		if err := k.checkQueryAborted(ctx); err != nil {
+			return txConfig, err
+		}
+		if err != nil {
+			continue
+		}
+		txConfig.LogIndex += uint(len(rsp.Logs))
+	}
+	return txConfig, nil
+}
+
+// traceMsg do trace on one message, it returns a tuple: (traceResult, executionResult, error).
+func (k *Keeper) traceMsg(
+	ctx sdk.Context,
+	cfg *statedb.EVMConfig,
+	txConfig statedb.TxConfig,
+	msg core.Message,
+	traceConfig *types.TraceConfig,
+	commitMessage bool,
+	tracerJSONConfig json.RawMessage,
+) (*interface{}, *types.MsgEthereumTxResponse, error) {
 	ctx = utils.UseZeroGasConfig(ctx) // avoid Cosmos consumes gas unexpectedly.
 
 	// Assemble the structured logger or the JavaScript tracer
@@ -560,10 +1006,6 @@ func (k *Keeper) traceTx(
 		err       error
 		timeout   = defaultTraceTimeout
 	)
-	msg, err := tx.AsMessage(signer, cfg.BaseFee)
-	if err != nil {
-		return nil, 0, status.Error(codes.Internal, err.Error())
-	}
 
 	if traceConfig == nil {
 		traceConfig = &types.TraceConfig{}
@@ -593,14 +1035,17 @@ func (k *Keeper) traceTx(
 
 	if traceConfig.Tracer != "" {
 		if tracer, err = tracers.New(traceConfig.Tracer, tCtx, tracerJSONConfig); err != nil {
-			return nil, 0, status.Error(codes.Internal, err.Error())
+			return nil, nil, status.Error(codes.Internal, err.Error())
 		}
 	}
 
+	// merge the effects of the Virtual Frontier Contract calls, executed outside of the EVM, into the result
+	tracer = types.NewVFCEffectsTracer(tracer, traceConfig.Tracer, tracerJSONConfig)
+
 	// Define a meaningful timeout of a single transaction trace
 	if traceConfig.Timeout != "" {
 		if timeout, err = time.ParseDuration(traceConfig.Timeout); err != nil {
-			return nil, 0, status.Errorf(codes.InvalidArgument, "timeout value: %s", err.Error())
+			return nil, nil, status.Errorf(codes.InvalidArgument, "timeout value: %s", err.Error())
 		}
 	}
 
@@ -617,16 +1062,16 @@ func (k *Keeper) traceTx(
 
 	res, err := k.ApplyMessageWithConfig(ctx, msg, tracer, commitMessage, cfg, txConfig)
 	if err != nil {
-		return nil, 0, status.Error(codes.Internal, err.Error())
+		return nil, nil, status.Error(codes.Internal, err.Error())
 	}
 
 	var result interface{}
 	result, err = tracer.GetResult()
 	if err != nil {
-		return nil, 0, status.Error(codes.Internal, err.Error())
+		return nil, nil, status.Error(codes.Internal, err.Error())
 	}
 
-	return &result, txConfig.LogIndex + uint(len(res.Logs)), nil
+	return &result, res, nil
 }
 
 // BaseFee implements the Query/BaseFee gRPC method
@@ -832,6 +1277,69 @@ func (k Keeper) ListVirtualFrontierBankContracts(ctx context.Context, req *types
 	}, nil
 }
 
+// evmQueryContext bounds the EVM execution of a query with the node level deadline,
+// the execution is also aborted when the request is cancelled by the client.
+func (k Keeper) evmQueryContext(c context.Context, ctx sdk.Context) (sdk.Context, context.CancelFunc) {
+	var (
+		goCtx  context.Context
+		cancel context.CancelFunc
+	)
+	if k.queryTimeout > 0 {
+		goCtx, cancel = context.WithTimeout(c, k.queryTimeout)
+	} else {
+		goCtx, cancel = context.WithCancel(c)
+	}
+	return ctx.WithContext(goCtx), cancel
+}
+
+// streamQueryContext creates the context of a server streaming query at the height of its request
+// header, the query router of the app only sets up the context of the unary queries.
+func (k Keeper) streamQueryContext(c context.Context) (sdk.Context, error) {
+	if k.queryContextFn == nil {
+		return sdk.Context{}, status.Error(codes.Unimplemented, "streaming queries are not supported by the node")
+	}
+
+	var height int64
+	if md, ok := metadata.FromIncomingContext(c); ok {
+		if heightHeaders := md.Get(grpctypes.GRPCBlockHeightHeader); len(heightHeaders) == 1 {
+			var err error
+			height, err = strconv.ParseInt(heightHeaders[0], 10, 64)
+			if err != nil || height < 0 {
+				return sdk.Context{}, status.Errorf(codes.InvalidArgument, "invalid height header %q", heightHeaders[0])
+			}
+		}
+	}
+
+	ctx, err := k.queryContextFn(height, false)
+	if err != nil {
+		return sdk.Context{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return ctx.WithContext(c), nil
+}
+
+// checkQueryAborted returns an error if the EVM execution of the query has been aborted by its context.
+func (k Keeper) checkQueryAborted(ctx sdk.Context) error {
+	err := ctx.Context().Err()
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, context.DeadlineExceeded) && k.queryTimeout > 0:
+		return status.Errorf(codes.DeadlineExceeded, "execution aborted (timeout = %v)", k.queryTimeout)
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "execution aborted (deadline exceeded)")
+	default:
+		return status.Error(codes.Canceled, "execution aborted (request cancelled)")
+	}
+}
+
+// capQueryGas applies the node level gas cap to the gas cap of a query, zero means no cap.
+func (k Keeper) capQueryGas(gasCap uint64) uint64 {
+	if k.queryGasCap > 0 && (gasCap == 0 || gasCap > k.queryGasCap) {
+		return k.queryGasCap
+	}
+	return gasCap
+}
+
 // getChainID parse chainID from current context if not provided
 func getChainID(ctx sdk.Context, chainID int64) (*big.Int, error) {
 	if chainID == 0 {
@@ -1,14 +1,20 @@
 package keeper_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
+	"strings"
+	"time"
 
 	sdkmath "cosmossdk.io/math"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	ethlogger "github.com/ethereum/go-ethereum/eth/tracers/logger"
@@ -17,9 +23,16 @@ import (
 	"github.com/evmos/ethermint/x/evm/statedb"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/evmos/ethermint/server/config"
 	ethermint "github.com/evmos/ethermint/types"
+	"github.com/evmos/ethermint/x/evm/keeper"
 	"github.com/evmos/ethermint/x/evm/types"
 )
 
@@ -289,6 +302,131 @@ func (suite *KeeperTestSuite) TestQueryStorage() {
 	}
 }
 
+func (suite *KeeperTestSuite) TestQueryStorageRange() {
+	suite.SetupTest()
+
+	vmdb := suite.StateDB()
+	for i := 1; i <= 5; i++ {
+		vmdb.SetState(suite.address, common.BigToHash(big.NewInt(int64(i))), common.BigToHash(big.NewInt(int64(i*10))))
+	}
+	suite.Require().NoError(vmdb.Commit())
+
+	ctx := sdk.WrapSDKContext(suite.ctx)
+	_, err := suite.queryClient.StorageRange(ctx, &types.QueryStorageRangeRequest{Address: invalidAddress})
+	suite.Require().Error(err)
+
+	res, err := suite.queryClient.StorageRange(ctx, &types.QueryStorageRangeRequest{
+		Address:    suite.address.String(),
+		Pagination: &query.PageRequest{Limit: 3},
+	})
+	suite.Require().NoError(err)
+	suite.Require().Len(res.Storage, 3)
+	suite.Require().Equal(common.BigToHash(big.NewInt(1)).Hex(), res.Storage[0].Key)
+	suite.Require().Equal(common.BigToHash(big.NewInt(10)).Hex(), res.Storage[0].Value)
+	suite.Require().Equal(common.BigToHash(big.NewInt(4)).Bytes(), res.Pagination.NextKey)
+
+	res, err = suite.queryClient.StorageRange(ctx, &types.QueryStorageRangeRequest{
+		Address:    suite.address.String(),
+		Pagination: &query.PageRequest{Key: res.Pagination.NextKey, Limit: 3},
+	})
+	suite.Require().NoError(err)
+	suite.Require().Len(res.Storage, 2)
+	suite.Require().Equal(common.BigToHash(big.NewInt(5)).Hex(), res.Storage[1].Key)
+	suite.Require().Empty(res.Pagination.NextKey)
+
+	// the limit is clamped to the server side maximum
+	large := tests.GenerateAddress()
+	vmdb = suite.StateDB()
+	for i := 1; i <= keeper.StorageRangeMaxLimit+1; i++ {
+		vmdb.SetState(large, common.BigToHash(big.NewInt(int64(i))), common.BigToHash(big.NewInt(int64(i))))
+	}
+	suite.Require().NoError(vmdb.Commit())
+
+	res, err = suite.queryClient.StorageRange(ctx, &types.QueryStorageRangeRequest{
+		Address:    large.String(),
+		Pagination: &query.PageRequest{Limit: math.MaxUint64},
+	})
+	suite.Require().NoError(err)
+	suite.Require().Len(res.Storage, keeper.StorageRangeMaxLimit)
+	suite.Require().NotEmpty(res.Pagination.NextKey)
+
+	// the predecessors of the transaction are replayed before the storage is read
+	contractAddr := tests.GenerateAddress()
+	vmdb = suite.StateDB()
+	// PUSH1 0x2a PUSH1 0x06 SSTORE STOP
+	vmdb.SetCode(contractAddr, []byte{byte(vm.PUSH1), 0x2a, byte(vm.PUSH1), 0x06, byte(vm.SSTORE), byte(vm.STOP)})
+	suite.Require().NoError(vmdb.Commit())
+
+	chainID := suite.app.EvmKeeper.ChainID()
+	nonce := suite.app.EvmKeeper.GetNonce(suite.ctx, suite.address)
+	predecessor := types.NewTx(chainID, nonce, &contractAddr, nil, 100000, big.NewInt(1), nil, nil, nil, nil)
+	predecessor.From = suite.address.Hex()
+	suite.Require().NoError(predecessor.Sign(ethtypes.LatestSignerForChainID(chainID), suite.signer))
+
+	req := &types.QueryStorageRangeRequest{Address: contractAddr.String(), BlockNumber: suite.ctx.BlockHeight()}
+	res, err = suite.queryClient.StorageRange(ctx, req)
+	suite.Require().NoError(err)
+	suite.Require().Empty(res.Storage)
+
+	req.Predecessors = []*types.MsgEthereumTx{predecessor}
+	res, err = suite.queryClient.StorageRange(ctx, req)
+	suite.Require().NoError(err)
+	suite.Require().Len(res.Storage, 1)
+	suite.Require().Equal(common.BigToHash(big.NewInt(6)).Hex(), res.Storage[0].Key)
+	suite.Require().Equal(common.BigToHash(big.NewInt(0x2a)).Hex(), res.Storage[0].Value)
+}
+
+func (suite *KeeperTestSuite) TestQueryAccountRange() {
+	suite.SetupTest()
+
+	vmdb := suite.StateDB()
+	vmdb.SetCode(suite.address, []byte{1, 2, 3})
+	for i := 1; i <= keeper.AccountRangeMaxStorage+1; i++ {
+		vmdb.SetState(suite.address, common.BigToHash(big.NewInt(int64(i))), common.BigToHash(big.NewInt(int64(i*10))))
+	}
+	suite.Require().NoError(vmdb.Commit())
+
+	findAccount := func(res *types.QueryAccountRangeResponse) *types.AccountRangeEntry {
+		for i := range res.Accounts {
+			if res.Accounts[i].Address == suite.address.Hex() {
+				return &res.Accounts[i]
+			}
+		}
+		return nil
+	}
+
+	ctx := sdk.WrapSDKContext(suite.ctx)
+	res, err := suite.queryClient.AccountRange(ctx, &types.QueryAccountRangeRequest{})
+	suite.Require().NoError(err)
+	entry := findAccount(res)
+	suite.Require().NotNil(entry)
+	suite.Require().Equal([]byte{1, 2, 3}, entry.Code)
+	// the storage is capped, the rest is left to Query/StorageRange
+	suite.Require().Len(entry.Storage, keeper.AccountRangeMaxStorage)
+	suite.Require().Equal(common.BigToHash(big.NewInt(keeper.AccountRangeMaxStorage+1)).Bytes(), entry.NextStorageKey)
+
+	res, err = suite.queryClient.AccountRange(ctx, &types.QueryAccountRangeRequest{NoCode: true, NoStorage: true})
+	suite.Require().NoError(err)
+	entry = findAccount(res)
+	suite.Require().NotNil(entry)
+	suite.Require().Empty(entry.Code)
+	suite.Require().Empty(entry.Storage)
+	suite.Require().Empty(entry.NextStorageKey)
+	suite.Require().Equal(crypto.Keccak256([]byte{1, 2, 3}), entry.CodeHash)
+
+	res, err = suite.queryClient.AccountRange(ctx, &types.QueryAccountRangeRequest{Pagination: &query.PageRequest{Limit: 1}})
+	suite.Require().NoError(err)
+	suite.Require().LessOrEqual(len(res.Accounts), 1)
+	suite.Require().NotEmpty(res.Pagination.NextKey)
+
+	res, err = suite.queryClient.AccountRange(ctx, &types.QueryAccountRangeRequest{
+		NoStorage:  true,
+		Pagination: &query.PageRequest{Limit: math.MaxUint64},
+	})
+	suite.Require().NoError(err)
+	suite.Require().LessOrEqual(len(res.Accounts), keeper.AccountRangeMaxLimit)
+}
+
 func (suite *KeeperTestSuite) TestQueryCode() {
 	var (
 		req     *types.QueryCodeRequest
@@ -970,6 +1108,9 @@ func (suite *KeeperTestSuite) TestTraceTx() {
 					suite.Require().NoError(json.Unmarshal(res.Data, &result))
 					suite.Require().Positive(result.Gas)
 				}
+				suite.Require().NotNil(res.Replay)
+				suite.Require().Positive(res.Replay.GasUsed)
+				suite.Require().False(res.Replay.Failed)
 			} else {
 				suite.Require().Error(err)
 			}
@@ -981,6 +1122,160 @@ func (suite *KeeperTestSuite) TestTraceTx() {
 	suite.enableFeemarket = false // reset flag
 }
 
+func (suite *KeeperTestSuite) TestTraceCall() {
+	var (
+		args           types.TransactionArgs
+		traceConfig    *types.TraceConfig
+		stateOverrides []byte
+	)
+
+	target := tests.GenerateAddress()
+	// returns the balance of the executing contract
+	balanceCode := hexutil.Bytes(common.FromHex("0x303160005260206000f3"))
+	// returns the value of storage slot 0
+	storageCode := hexutil.Bytes(common.FromHex("0x60005460005260206000f3"))
+	slotValue := common.BigToHash(big.NewInt(7))
+
+	testCases := []struct {
+		msg         string
+		malleate    func(contractAddr common.Address)
+		expPass     bool
+		expReturn   string
+		expResponse string
+	}{
+		{
+			msg: "default trace",
+			malleate: func(contractAddr common.Address) {
+				input, err := types.ERC20Contract.ABI.Pack("balanceOf", suite.address)
+				suite.Require().NoError(err)
+				args = types.TransactionArgs{From: &suite.address, To: &contractAddr, Data: (*hexutil.Bytes)(&input)}
+			},
+			expPass:   true,
+			expReturn: common.BigToHash(sdkmath.NewIntWithDecimal(1000, 18).BigInt()).Hex()[2:],
+		},
+		{
+			msg: "named tracer",
+			malleate: func(contractAddr common.Address) {
+				input, err := types.ERC20Contract.ABI.Pack("balanceOf", suite.address)
+				suite.Require().NoError(err)
+				args = types.TransactionArgs{From: &suite.address, To: &contractAddr, Data: (*hexutil.Bytes)(&input)}
+				traceConfig = &types.TraceConfig{Tracer: "callTracer"}
+			},
+			expPass:     true,
+			expResponse: "{\"type\":\"CALL\",\"from\":\"" + strings.ToLower(suite.address.Hex()) + "\"",
+		},
+		{
+			msg: "state override replaces the contract storage",
+			malleate: func(contractAddr common.Address) {
+				input, err := types.ERC20Contract.ABI.Pack("balanceOf", suite.address)
+				suite.Require().NoError(err)
+				args = types.TransactionArgs{From: &suite.address, To: &contractAddr, Data: (*hexutil.Bytes)(&input)}
+				state := map[common.Hash]common.Hash{}
+				stateOverrides, err = json.Marshal(statedb.StateOverride{
+					contractAddr: statedb.OverrideAccount{State: &state},
+				})
+				suite.Require().NoError(err)
+			},
+			expPass:   true,
+			expReturn: common.Hash{}.Hex()[2:],
+		},
+		{
+			msg: "code and balance overrides",
+			malleate: func(common.Address) {
+				args = types.TransactionArgs{From: &suite.address, To: &target}
+				balance := (*hexutil.Big)(big.NewInt(1000))
+				var err error
+				stateOverrides, err = json.Marshal(statedb.StateOverride{
+					target: statedb.OverrideAccount{Code: &balanceCode, Balance: &balance},
+				})
+				suite.Require().NoError(err)
+			},
+			expPass:   true,
+			expReturn: common.BigToHash(big.NewInt(1000)).Hex()[2:],
+		},
+		{
+			msg: "code and state diff overrides",
+			malleate: func(common.Address) {
+				args = types.TransactionArgs{From: &suite.address, To: &target}
+				diff := map[common.Hash]common.Hash{{}: slotValue}
+				var err error
+				stateOverrides, err = json.Marshal(statedb.StateOverride{
+					target: statedb.OverrideAccount{Code: &storageCode, StateDiff: &diff},
+				})
+				suite.Require().NoError(err)
+			},
+			expPass:   true,
+			expReturn: slotValue.Hex()[2:],
+		},
+		{
+			msg: "invalid overrides - both state and stateDiff",
+			malleate: func(common.Address) {
+				args = types.TransactionArgs{From: &suite.address, To: &target}
+				state := map[common.Hash]common.Hash{}
+				var err error
+				stateOverrides, err = json.Marshal(statedb.StateOverride{
+					target: statedb.OverrideAccount{State: &state, StateDiff: &state},
+				})
+				suite.Require().NoError(err)
+			},
+			expPass: false,
+		},
+		{
+			msg: "invalid overrides - malformed json",
+			malleate: func(common.Address) {
+				args = types.TransactionArgs{From: &suite.address, To: &target}
+				stateOverrides = []byte("invalid")
+			},
+			expPass: false,
+		},
+		{
+			msg: "invalid trace config - Negative Limit",
+			malleate: func(common.Address) {
+				args = types.TransactionArgs{From: &suite.address, To: &target}
+				traceConfig = &types.TraceConfig{Limit: -1}
+			},
+			expPass: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(fmt.Sprintf("Case %s", tc.msg), func() {
+			suite.SetupTest()
+			traceConfig = nil
+			stateOverrides = nil
+			contractAddr := suite.DeployTestContract(suite.T(), suite.address, sdkmath.NewIntWithDecimal(1000, 18).BigInt())
+			suite.Commit()
+
+			tc.malleate(contractAddr)
+			argsBz, err := json.Marshal(&args)
+			suite.Require().NoError(err)
+
+			res, err := suite.queryClient.TraceCall(sdk.WrapSDKContext(suite.ctx), &types.QueryTraceCallRequest{
+				Args:           argsBz,
+				GasCap:         config.DefaultGasCap,
+				TraceConfig:    traceConfig,
+				StateOverrides: stateOverrides,
+				BlockNumber:    suite.ctx.BlockHeight(),
+			})
+
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			if tc.expResponse != "" {
+				suite.Require().True(strings.HasPrefix(string(res.Data), tc.expResponse), string(res.Data))
+				return
+			}
+			var result ethlogger.ExecutionResult
+			suite.Require().NoError(json.Unmarshal(res.Data, &result))
+			suite.Require().False(result.Failed)
+			suite.Require().Positive(result.Gas)
+			suite.Require().Equal(tc.expReturn, result.ReturnValue)
+		})
+	}
+}
+
 func (suite *KeeperTestSuite) TestTraceBlock() {
 	var (
 		txs         []*types.MsgEthereumTx
@@ -1137,6 +1432,7 @@ func (suite *KeeperTestSuite) TestTraceBlock() {
 
 			if tc.expPass {
 				suite.Require().NoError(err)
+				suite.Require().Len(res.Replays, len(txs))
 				// if data is to big, slice the result
 				if len(res.Data) > 150 {
 					suite.Require().Equal(tc.traceResponse, string(res.Data[:150]))
@@ -1154,6 +1450,70 @@ func (suite *KeeperTestSuite) TestTraceBlock() {
 	suite.enableFeemarket = false // reset flag
 }
 
+// traceBlockStream collects the responses sent by TraceBlockStream
+type traceBlockStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*types.QueryTraceBlockStreamResponse
+	sendErr   error
+}
+
+func (s *traceBlockStream) Context() context.Context { return s.ctx }
+
+func (s *traceBlockStream) Send(res *types.QueryTraceBlockStreamResponse) error {
+	if s.sendErr != nil {
+		return s.sendErr
+	}
+	s.responses = append(s.responses, res)
+	return nil
+}
+
+func (suite *KeeperTestSuite) TestTraceBlockStream() {
+	suite.SetupTest()
+	contractAddr := suite.DeployTestContract(suite.T(), suite.address, sdkmath.NewIntWithDecimal(1000, 18).BigInt())
+	suite.Commit()
+	recipient := common.HexToAddress("0x378c50D9264C63F3F92B806d4ee56E9D86FfB3Ec")
+	firstTx := suite.TransferERC20Token(suite.T(), contractAddr, suite.address, recipient, sdkmath.NewIntWithDecimal(1, 18).BigInt())
+	secondTx := suite.TransferERC20Token(suite.T(), contractAddr, suite.address, recipient, sdkmath.NewIntWithDecimal(1, 18).BigInt())
+	suite.Commit()
+
+	traceReq := &types.QueryTraceBlockRequest{
+		Txs:         []*types.MsgEthereumTx{firstTx, secondTx},
+		TraceConfig: &types.TraceConfig{Tracer: "callTracer"},
+		BlockNumber: suite.ctx.BlockHeight(),
+	}
+
+	res, err := suite.queryClient.TraceBlock(sdk.WrapSDKContext(suite.ctx), traceReq)
+	suite.Require().NoError(err)
+	var expResults []json.RawMessage
+	suite.Require().NoError(json.Unmarshal(res.Data, &expResults))
+	suite.Require().Len(expResults, 2)
+
+	stream := &traceBlockStream{ctx: context.Background()}
+	suite.Require().NoError(suite.app.EvmKeeper.TraceBlockStream(traceReq, stream))
+	suite.Require().Len(stream.responses, 2)
+	for i, streamed := range stream.responses {
+		suite.Require().Equal(uint64(i), streamed.Index)
+		suite.Require().JSONEq(string(expResults[i]), string(streamed.Data))
+		suite.Require().Equal(res.Replays[i], streamed.Replay)
+	}
+
+	// a failed send stops the tracing
+	stream = &traceBlockStream{ctx: context.Background(), sendErr: errors.New("client gone")}
+	suite.Require().ErrorContains(suite.app.EvmKeeper.TraceBlockStream(traceReq, stream), "client gone")
+
+	// invalid height header
+	stream = &traceBlockStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpctypes.GRPCBlockHeightHeader, "-1"))}
+	err = suite.app.EvmKeeper.TraceBlockStream(traceReq, stream)
+	suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+
+	// the node doesn't create the query context of the streaming queries
+	k := *suite.app.EvmKeeper
+	k.SetQueryContextCreator(nil)
+	err = k.TraceBlockStream(traceReq, &traceBlockStream{ctx: context.Background()})
+	suite.Require().Equal(codes.Unimplemented, status.Code(err))
+}
+
 func (suite *KeeperTestSuite) TestNonceInQuery() {
 	address := tests.GenerateAddress()
 	suite.Require().Equal(uint64(0), suite.app.EvmKeeper.GetNonce(suite.ctx, address))
@@ -1188,6 +1548,44 @@ func (suite *KeeperTestSuite) TestNonceInQuery() {
 	suite.Require().NoError(err)
 }
 
+func (suite *KeeperTestSuite) TestEstimateGasStorageFee() {
+	keeperParams := suite.app.EvmKeeper.GetParams(suite.ctx)
+	keeperParams.StorageFeePerSlot = sdkmath.NewInt(10)
+	suite.Require().NoError(suite.app.EvmKeeper.SetParams(suite.ctx, keeperParams))
+
+	ctorArgs, err := types.ERC20Contract.ABI.Pack("", suite.address, big.NewInt(1000))
+	suite.Require().NoError(err)
+	data := append(types.ERC20Contract.Bin, ctorArgs...)
+	args, err := json.Marshal(&types.TransactionArgs{
+		From: &suite.address,
+		Data: (*hexutil.Bytes)(&data),
+	})
+	suite.Require().NoError(err)
+	req := &types.EthCallRequest{
+		Args:            args,
+		GasCap:          uint64(config.DefaultGasCap),
+		ProposerAddress: suite.ctx.BlockHeader().ProposerAddress,
+	}
+
+	// the simulations fail like the delivered tx when the sender can't afford the storage fee
+	suite.Require().NoError(suite.app.EvmKeeper.SetBalance(suite.ctx, suite.address, big.NewInt(0)))
+	_, err = suite.queryClient.EstimateGas(sdk.WrapSDKContext(suite.ctx), req)
+	suite.Require().Error(err)
+	res, err := suite.queryClient.EthCall(sdk.WrapSDKContext(suite.ctx), req)
+	suite.Require().NoError(err)
+	suite.Require().Contains(res.VmError, types.ErrInsufficientStorageFee.Error())
+
+	suite.Require().NoError(suite.app.EvmKeeper.SetBalance(suite.ctx, suite.address, big.NewInt(1000)))
+	_, err = suite.queryClient.EstimateGas(sdk.WrapSDKContext(suite.ctx), req)
+	suite.Require().NoError(err)
+	res, err = suite.queryClient.EthCall(sdk.WrapSDKContext(suite.ctx), req)
+	suite.Require().NoError(err)
+	suite.Require().False(res.Failed(), res.VmError)
+
+	// the simulations don't charge the fee
+	suite.Require().Equal(int64(1000), suite.app.EvmKeeper.GetBalance(suite.ctx, suite.address).Int64())
+}
+
 func (suite *KeeperTestSuite) TestQueryBaseFee() {
 	var (
 		aux    sdkmath.Int
@@ -1335,6 +1733,115 @@ func (suite *KeeperTestSuite) TestEthCall() {
 	}
 }
 
+func (suite *KeeperTestSuite) TestQueryLimits() {
+	address := tests.GenerateAddress()
+	supply := sdkmath.NewIntWithDecimal(1000, 18).BigInt()
+	ctorArgs, err := types.ERC20Contract.ABI.Pack("", address, supply)
+	suite.Require().NoError(err)
+	data := append(types.ERC20Contract.Bin, ctorArgs...)
+
+	args, err := json.Marshal(&types.TransactionArgs{
+		From: &address,
+		Data: (*hexutil.Bytes)(&data),
+	})
+	suite.Require().NoError(err)
+	req := &types.EthCallRequest{Args: args, GasCap: config.DefaultGasCap}
+
+	testCases := []struct {
+		name     string
+		timeout  time.Duration
+		gasCap   uint64
+		query    func() error
+		expCode  codes.Code
+		expError string
+	}{
+		{
+			"eth call - no limit",
+			0,
+			0,
+			func() error {
+				_, err := suite.queryClient.EthCall(suite.ctx, req)
+				return err
+			},
+			codes.OK,
+			"",
+		},
+		{
+			"eth call - deadline exceeded",
+			time.Nanosecond,
+			0,
+			func() error {
+				_, err := suite.queryClient.EthCall(suite.ctx, req)
+				return err
+			},
+			codes.DeadlineExceeded,
+			"execution aborted",
+		},
+		{
+			"eth call - gas capped",
+			0,
+			ethparams.TxGas,
+			func() error {
+				_, err := suite.queryClient.EthCall(suite.ctx, req)
+				return err
+			},
+			codes.Internal,
+			"intrinsic gas too low",
+		},
+		{
+			"estimate gas - deadline exceeded",
+			time.Nanosecond,
+			0,
+			func() error {
+				_, err := suite.queryClient.EstimateGas(suite.ctx, req)
+				return err
+			},
+			codes.DeadlineExceeded,
+			"execution aborted",
+		},
+		{
+			"account range - deadline exceeded",
+			time.Nanosecond,
+			0,
+			func() error {
+				_, err := suite.queryClient.AccountRange(suite.ctx, &types.QueryAccountRangeRequest{})
+				return err
+			},
+			codes.DeadlineExceeded,
+			"execution aborted",
+		},
+		{
+			"estimate gas - gas capped",
+			0,
+			100000,
+			func() error {
+				_, err := suite.queryClient.EstimateGas(suite.ctx, req)
+				return err
+			},
+			codes.Unknown,
+			"gas required exceeds allowance (100000)",
+		},
+	}
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			suite.app.EvmKeeper.SetQueryLimits(tc.timeout, tc.gasCap)
+			defer suite.app.EvmKeeper.SetQueryLimits(0, 0)
+
+			err := tc.query()
+			if tc.expError == "" {
+				suite.Require().NoError(err)
+				return
+			}
+			suite.Require().Error(err)
+			suite.Require().Contains(err.Error(), tc.expError)
+			if tc.expCode != codes.OK {
+				suite.Require().Equal(tc.expCode, status.Code(err))
+			}
+		})
+	}
+}
+
 func (suite *KeeperTestSuite) TestEmptyRequest() {
 	k := suite.app.EvmKeeper
 
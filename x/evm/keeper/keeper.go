@@ -16,7 +16,9 @@
 package keeper
 
 import (
+	"bytes"
 	"math/big"
+	"time"
 
 	errorsmod "cosmossdk.io/errors"
 	"github.com/cometbft/cometbft/libs/log"
@@ -78,6 +80,19 @@ type Keeper struct {
 	evmConstructor evm.Constructor
 	// Legacy subspace
 	ss paramstypes.Subspace
+
+	// per-block read cache for contract code and accounts
+	stateCache *stateCache
+
+	// deadline and gas cap of the gRPC queries executing the EVM, zero means no limit
+	queryTimeout time.Duration
+	queryGasCap  uint64
+
+	// creates the context of the server streaming gRPC queries, which are not served by the query router
+	queryContextFn func(height int64, prove bool) (sdk.Context, error)
+
+	// traces the delivered transactions into the block trace files, nil if disabled
+	blockTrace *blockTraceConfig
 }
 
 // NewKeeper generates new evm module keeper
@@ -104,11 +119,13 @@ func NewKeeper(
 		panic(err)
 	}
 
+	cache := newStateCache()
+
 	// NOTE: we pass in the parameter space to the CommitStateDB in order to use custom denominations for the EVM operations
 	return &Keeper{
 		cdc:               cdc,
 		authority:         authority,
-		accountKeeper:     ak,
+		accountKeeper:     cacheInvalidatingAccountKeeper{AccountKeeper: ak, cache: cache},
 		bankKeeper:        bankKeeper,
 		stakingKeeper:     sk,
 		feeMarketKeeper:   fmk,
@@ -118,6 +135,7 @@ func NewKeeper(
 		evmConstructor:    evmConstructor,
 		tracer:            tracer,
 		ss:                ss,
+		stateCache:        cache,
 	}
 }
 
@@ -165,6 +183,12 @@ func (k Keeper) GetAuthority() sdk.AccAddress {
 	return k.authority
 }
 
+// AccountKeeper returns the account keeper of the EVM keeper, the account writes made through it invalidate the
+// cached accounts so the other modules writing EVM accounts (e.g. the ante handlers) must use it.
+func (k Keeper) AccountKeeper() types.AccountKeeper {
+	return k.accountKeeper
+}
+
 // GetBlockBloomTransient returns bloom bytes for the current block height
 func (k Keeper) GetBlockBloomTransient(ctx sdk.Context) *big.Int {
 	store := prefix.NewStore(ctx.TransientStore(k.transientKey), types.KeyPrefixTransientBloom)
@@ -259,6 +283,21 @@ func (k *Keeper) SetHooks(eh types.EvmHooks) *Keeper {
 	return k
 }
 
+// SetQueryLimits sets the node level deadline and gas cap enforced on the gRPC queries executing the EVM,
+// a zero value disables the corresponding limit.
+func (k *Keeper) SetQueryLimits(timeout time.Duration, gasCap uint64) *Keeper {
+	k.queryTimeout = timeout
+	k.queryGasCap = gasCap
+	return k
+}
+
+// SetQueryContextCreator sets the function creating the query context at a given height, it is used
+// by the server streaming gRPC queries.
+func (k *Keeper) SetQueryContextCreator(fn func(height int64, prove bool) (sdk.Context, error)) *Keeper {
+	k.queryContextFn = fn
+	return k
+}
+
 // PostTxProcessing delegate the call to the hooks. If no hook has been registered, this function returns with a `nil` error
 func (k *Keeper) PostTxProcessing(ctx sdk.Context, msg core.Message, receipt *ethtypes.Receipt) error {
 	if k.hooks == nil {
@@ -275,6 +314,24 @@ func (k Keeper) Tracer(ctx sdk.Context, msg core.Message, ethCfg *params.ChainCo
 // GetAccountWithoutBalance load nonce and codehash without balance,
 // more efficient in cases where balance is not needed.
 func (k *Keeper) GetAccountWithoutBalance(ctx sdk.Context, addr common.Address) *statedb.Account {
+	if acct, found := k.stateCache.getAccount(ctx, addr); found {
+		return &acct
+	}
+
+	// only the contract accounts found in the root deliver store are cached, the other ones may have been
+	// created or updated in the branch of ctx
+	if rootCtx, ok := k.stateCache.accountContext(ctx, addr); ok {
+		if account := k.getAccountWithoutBalance(rootCtx, addr); account != nil &&
+			!bytes.Equal(account.CodeHash, types.EmptyCodeHash) {
+			k.stateCache.setAccount(ctx, addr, *account)
+			return account
+		}
+	}
+
+	return k.getAccountWithoutBalance(ctx, addr)
+}
+
+func (k *Keeper) getAccountWithoutBalance(ctx sdk.Context, addr common.Address) *statedb.Account {
 	cosmosAddr := sdk.AccAddress(addr.Bytes())
 	acct := k.accountKeeper.GetAccount(ctx, cosmosAddr)
 	if acct == nil {
@@ -0,0 +1,151 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package keeper
+
+import (
+	"context"
+	"math/big"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/evmos/ethermint/x/evm/statedb"
+	"github.com/evmos/ethermint/x/evm/types"
+)
+
+// pendingAccountsKey is the key of the accounts written while applying the pending transactions in the go context.
+type pendingAccountsKey struct{}
+
+// withPendingAccounts returns a context recording the accounts written by the keeper in the returned set.
+func withPendingAccounts(ctx sdk.Context) (sdk.Context, map[common.Address]struct{}) {
+	accounts := make(map[common.Address]struct{})
+	return ctx.WithContext(context.WithValue(ctx.Context(), pendingAccountsKey{}, accounts)), accounts
+}
+
+// recordPendingAccount adds the account to the set of the context, it's a no-op outside of the pending state.
+func recordPendingAccount(ctx sdk.Context, addr common.Address) {
+	if accounts, ok := ctx.Context().Value(pendingAccountsKey{}).(map[common.Address]struct{}); ok {
+		accounts[addr] = struct{}{}
+	}
+}
+
+// recordBankEventAccounts adds the accounts whose balances are changed by the bank events to the set, it covers the
+// transfers that don't go through the state of the EVM such as the fees.
+func recordBankEventAccounts(events sdk.Events, accounts map[common.Address]struct{}) {
+	for _, event := range events {
+		var key string
+		switch event.Type {
+		case banktypes.EventTypeCoinSpent:
+			key = banktypes.AttributeKeySpender
+		case banktypes.EventTypeCoinReceived:
+			key = banktypes.AttributeKeyReceiver
+		default:
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if attr.Key != key {
+				continue
+			}
+			if addr, err := sdk.AccAddressFromBech32(attr.Value); err == nil {
+				accounts[common.BytesToAddress(addr)] = struct{}{}
+			}
+		}
+	}
+}
+
+// pendingAccount returns the balance and nonce of the account in the state of the context.
+func (k *Keeper) pendingAccount(ctx sdk.Context, addr common.Address) types.PendingAccount {
+	acct := k.GetAccountOrEmpty(ctx, addr)
+	return types.PendingAccount{
+		Address: addr.Hex(),
+		Balance: acct.Balance.String(),
+		Nonce:   acct.Nonce,
+	}
+}
+
+// applyPendingTxs applies the pending transactions on top of the state of the context as if they were delivered
+// in the next block: the sender nonce is checked and increased, the fee is deducted and the leftover gas refunded.
+// The transactions that can't be included are skipped without side effects. It returns the applied transactions
+// and the gas they used.
+func (k *Keeper) applyPendingTxs(
+	ctx sdk.Context,
+	cfg *statedb.EVMConfig,
+	txs []*types.MsgEthereumTx,
+) ([]common.Hash, uint64, error) {
+	signer := ethtypes.MakeSigner(cfg.ChainConfig, big.NewInt(ctx.BlockHeight()))
+	txConfig := statedb.NewEmptyTxConfig(common.BytesToHash(ctx.HeaderHash()))
+
+	var (
+		applied []common.Hash
+		gasUsed uint64
+	)
+	for _, tx := range txs {
+		ethTx := tx.AsTransaction()
+		msg, err := ethTx.AsMessage(signer, cfg.BaseFee)
+		if err != nil {
+			continue
+		}
+
+		// the changes of a transaction are dropped when it can't be included
+		cacheCtx, commit := ctx.CacheContext()
+
+		acc := k.accountKeeper.GetAccount(cacheCtx, msg.From().Bytes())
+		if acc == nil || acc.GetSequence() != msg.Nonce() {
+			continue
+		}
+
+		fee := new(big.Int).Mul(msg.GasPrice(), new(big.Int).SetUint64(msg.Gas()))
+		if fee.Sign() > 0 {
+			fees := sdk.Coins{sdk.NewCoin(cfg.Params.EvmDenom, sdkmath.NewIntFromBigInt(fee))}
+			if err := k.DeductTxCostsFromUserBalance(cacheCtx, fees, msg.From()); err != nil {
+				continue
+			}
+		}
+
+		// the nonce of the contract creations is increased during the execution
+		if msg.To() != nil {
+			acc = k.accountKeeper.GetAccount(cacheCtx, msg.From().Bytes())
+			if err := acc.SetSequence(msg.Nonce() + 1); err != nil {
+				continue
+			}
+			k.accountKeeper.SetAccount(cacheCtx, acc)
+		}
+
+		txConfig.TxHash = ethTx.Hash()
+		txConfig.TxIndex = uint(len(applied))
+		res, err := k.ApplyMessageWithConfig(cacheCtx, msg, types.NewNoOpTracer(), true, cfg, txConfig)
+		if err := k.checkQueryAborted(ctx); err != nil {
+			return nil, 0, err
+		}
+		if err != nil {
+			continue
+		}
+		if err := k.RefundGas(cacheCtx, msg, msg.Gas()-res.GasUsed, cfg.Params.EvmDenom); err != nil {
+			continue
+		}
+
+		commit()
+		recordPendingAccount(ctx, msg.From())
+		txConfig.LogIndex += uint(len(res.Logs))
+		applied = append(applied, ethTx.Hash())
+		gasUsed += res.GasUsed
+	}
+
+	return applied, gasUsed, nil
+}
@@ -0,0 +1,107 @@
+package keeper_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/evmos/ethermint/tests"
+	"github.com/evmos/ethermint/x/evm/types"
+)
+
+// signedTx returns a signed legacy transaction of the suite address with a gas price of one
+func (suite *KeeperTestSuite) signedTx(nonce uint64, to *common.Address, value *big.Int, gasLimit uint64, data []byte) *types.MsgEthereumTx {
+	chainID := suite.app.EvmKeeper.ChainID()
+	tx := types.NewTx(chainID, nonce, to, value, gasLimit, big.NewInt(1), nil, nil, data, nil)
+	tx.From = suite.address.Hex()
+	suite.Require().NoError(tx.Sign(ethtypes.LatestSignerForChainID(chainID), suite.signer))
+	return tx
+}
+
+func (suite *KeeperTestSuite) TestPendingState() {
+	suite.SetupTest()
+
+	suite.Require().NoError(suite.app.EvmKeeper.SetBalance(suite.ctx, suite.address, big.NewInt(1e18)))
+	recipient := tests.GenerateAddress()
+	nonce := suite.app.EvmKeeper.GetNonce(suite.ctx, suite.address)
+	balance := suite.app.EvmKeeper.GetBalance(suite.ctx, suite.address)
+	value := big.NewInt(100)
+
+	transfer := suite.signedTx(nonce, &recipient, value, 21000, nil)
+	gap := suite.signedTx(nonce+2, &recipient, value, 21000, nil)
+
+	res, err := suite.queryClient.PendingState(sdk.WrapSDKContext(suite.ctx), &types.QueryPendingStateRequest{
+		Txs:             []*types.MsgEthereumTx{transfer, gap},
+		Addresses:       []string{suite.address.Hex(), recipient.Hex()},
+		ProposerAddress: suite.ctx.BlockHeader().ProposerAddress,
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal([]string{transfer.AsTransaction().Hash().Hex()}, res.TxHashes)
+	suite.Require().Equal(uint64(21000), res.GasUsed)
+
+	expBalance := new(big.Int).Sub(balance, value)
+	expBalance.Sub(expBalance, big.NewInt(21000))
+	suite.Require().Equal(types.PendingAccount{Address: suite.address.Hex(), Balance: expBalance.String(), Nonce: nonce + 1}, res.Accounts[0])
+	suite.Require().Equal(types.PendingAccount{Address: recipient.Hex(), Balance: value.String()}, res.Accounts[1])
+
+	// the accounts written by the transfer are returned without being queried
+	suite.Require().Contains(res.ChangedAccounts, res.Accounts[0])
+	suite.Require().Contains(res.ChangedAccounts, res.Accounts[1])
+	for i := 1; i < len(res.ChangedAccounts); i++ {
+		prev, next := common.HexToAddress(res.ChangedAccounts[i-1].Address), common.HexToAddress(res.ChangedAccounts[i].Address)
+		suite.Require().Negative(bytes.Compare(prev.Bytes(), next.Bytes()))
+	}
+
+	// the latest state is untouched
+	suite.Require().Equal(nonce, suite.app.EvmKeeper.GetNonce(suite.ctx, suite.address))
+	suite.Require().Equal(balance, suite.app.EvmKeeper.GetBalance(suite.ctx, suite.address))
+
+	_, err = suite.queryClient.PendingState(sdk.WrapSDKContext(suite.ctx), &types.QueryPendingStateRequest{
+		Addresses: []string{invalidAddress},
+	})
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestEthCallPendingTxs() {
+	suite.SetupTest()
+
+	contract := suite.DeployTestContract(suite.T(), suite.address, big.NewInt(1000))
+	suite.Require().NoError(suite.app.EvmKeeper.SetBalance(suite.ctx, suite.address, big.NewInt(1e18)))
+	recipient := tests.GenerateAddress()
+	nonce := suite.app.EvmKeeper.GetNonce(suite.ctx, suite.address)
+
+	transferData, err := types.ERC20Contract.ABI.Pack("transfer", recipient, big.NewInt(10))
+	suite.Require().NoError(err)
+	transfer := suite.signedTx(nonce, &contract, nil, 100000, transferData)
+
+	balanceOfData, err := types.ERC20Contract.ABI.Pack("balanceOf", recipient)
+	suite.Require().NoError(err)
+	args, err := json.Marshal(&types.TransactionArgs{To: &contract, Data: (*hexutil.Bytes)(&balanceOfData)})
+	suite.Require().NoError(err)
+
+	testCases := []struct {
+		name       string
+		pendingTxs []*types.MsgEthereumTx
+		expBalance int64
+	}{
+		{"latest state", nil, 0},
+		{"pending state", []*types.MsgEthereumTx{transfer}, 10},
+	}
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			res, err := suite.queryClient.EthCall(sdk.WrapSDKContext(suite.ctx), &types.EthCallRequest{
+				Args:            args,
+				GasCap:          25_000_000,
+				ProposerAddress: suite.ctx.BlockHeader().ProposerAddress,
+				PendingTxs:      tc.pendingTxs,
+			})
+			suite.Require().NoError(err)
+			suite.Require().Equal(tc.expBalance, new(big.Int).SetBytes(res.Ret).Int64())
+		})
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package keeper
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/evmos/ethermint/x/evm/statedb"
+	"github.com/evmos/ethermint/x/evm/types"
+)
+
+// maxStateCacheCodeBytes bounds the total size of contract code held by the block state cache.
+const maxStateCacheCodeBytes = 64 * 1024 * 1024
+
+// stateCache is a per-block read cache for contract code and contract accounts.
+//
+// The cache is only consulted while delivering transactions of the block it was reset for, and only
+// when the context does not charge gas for KV reads (which is the case for EVM transactions), so a hit
+// and a miss are indistinguishable from the consensus point of view.
+//
+// The entries are only filled from reads of the root deliver store of the block, never from a branched context
+// that may be discarded later. Any address written during the block, in any branch and through any account keeper
+// method, is never cached again until the next block, so the root value of the other addresses is the one of
+// every branch.
+type stateCache struct {
+	mu sync.RWMutex
+
+	// height of the block being delivered, zero when the cache is disabled
+	height int64
+	// root deliver store of the block, the cached entries are read from it
+	root sdk.MultiStore
+
+	code      map[common.Hash][]byte
+	codeBytes int
+
+	accounts map[common.Address]statedb.Account
+	// addresses written during the current block
+	dirty map[common.Address]struct{}
+}
+
+func newStateCache() *stateCache {
+	c := &stateCache{}
+	c.reset(0, nil)
+	return c
+}
+
+// reset drops all cached entries and enables the cache for the given height and root deliver store,
+// a zero height disables the cache.
+func (c *stateCache) reset(height int64, root sdk.MultiStore) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.height = height
+	c.root = root
+	c.code = make(map[common.Hash][]byte)
+	c.codeBytes = 0
+	c.accounts = make(map[common.Address]statedb.Account)
+	c.dirty = make(map[common.Address]struct{})
+}
+
+// enabled returns true if the cache can be used for the given context.
+// Caller must hold the lock.
+func (c *stateCache) enabled(ctx sdk.Context) bool {
+	if c.height == 0 || c.root == nil || c.height != ctx.BlockHeight() {
+		return false
+	}
+	if ctx.IsCheckTx() || ctx.IsReCheckTx() {
+		return false
+	}
+	gasConfig := ctx.KVGasConfig()
+	return gasConfig.ReadCostFlat == 0 && gasConfig.ReadCostPerByte == 0 && gasConfig.IterNextCostFlat == 0
+}
+
+func (c *stateCache) getCode(ctx sdk.Context, codeHash common.Hash) ([]byte, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.enabled(ctx) {
+		return nil, false
+	}
+	code, found := c.code[codeHash]
+	recordStateCacheAccess("code", found)
+	return code, found
+}
+
+// rootContext returns the context reading the root deliver store, it returns false when the cache is disabled
+// for ctx.
+func (c *stateCache) rootContext(ctx sdk.Context) (sdk.Context, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.enabled(ctx) {
+		return ctx, false
+	}
+	return ctx.WithMultiStore(c.root), true
+}
+
+// accountContext returns the context reading the root deliver store, it returns false when the cache is disabled
+// for ctx or when the address was written during the block.
+func (c *stateCache) accountContext(ctx sdk.Context, addr common.Address) (sdk.Context, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.enabled(ctx) {
+		return ctx, false
+	}
+	if _, written := c.dirty[addr]; written {
+		return ctx, false
+	}
+	return ctx.WithMultiStore(c.root), true
+}
+
+// setCode caches the code read from the root deliver store.
+func (c *stateCache) setCode(ctx sdk.Context, codeHash common.Hash, code []byte) {
+	// only non-empty code is cached, the code store is content addressed so the entry never goes stale
+	if len(code) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.enabled(ctx) {
+		return
+	}
+	if _, found := c.code[codeHash]; found {
+		return
+	}
+	if c.codeBytes+len(code) > maxStateCacheCodeBytes {
+		return
+	}
+	c.code[codeHash] = code
+	c.codeBytes += len(code)
+}
+
+func (c *stateCache) invalidateCode(codeHash common.Hash) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if code, found := c.code[codeHash]; found {
+		c.codeBytes -= len(code)
+		delete(c.code, codeHash)
+	}
+}
+
+func (c *stateCache) getAccount(ctx sdk.Context, addr common.Address) (statedb.Account, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.enabled(ctx) {
+		return statedb.Account{}, false
+	}
+	acct, found := c.accounts[addr]
+	recordStateCacheAccess("account", found)
+	return acct, found
+}
+
+// setAccount caches the account loaded without balance from the root deliver store. Only contract accounts
+// are cached, the nonce of externally owned accounts is updated by every transaction.
+func (c *stateCache) setAccount(ctx sdk.Context, addr common.Address, acct statedb.Account) {
+	if bytes.Equal(acct.CodeHash, types.EmptyCodeHash) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.enabled(ctx) {
+		return
+	}
+	if _, written := c.dirty[addr]; written {
+		return
+	}
+	c.accounts[addr] = acct
+}
+
+func (c *stateCache) invalidateAccount(addr common.Address) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.accounts, addr)
+	if c.height != 0 {
+		c.dirty[addr] = struct{}{}
+	}
+}
+
+// cacheInvalidatingAccountKeeper invalidates the cached account on every account write, it wraps the account
+// keeper of the EVM keeper and the ante handlers.
+type cacheInvalidatingAccountKeeper struct {
+	types.AccountKeeper
+	cache *stateCache
+}
+
+func (ak cacheInvalidatingAccountKeeper) SetAccount(ctx sdk.Context, account authtypes.AccountI) {
+	ak.cache.invalidateAccount(common.BytesToAddress(account.GetAddress()))
+	ak.AccountKeeper.SetAccount(ctx, account)
+}
+
+func (ak cacheInvalidatingAccountKeeper) RemoveAccount(ctx sdk.Context, account authtypes.AccountI) {
+	ak.cache.invalidateAccount(common.BytesToAddress(account.GetAddress()))
+	ak.AccountKeeper.RemoveAccount(ctx, account)
+}
+
+func recordStateCacheAccess(kind string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	telemetry.IncrCounter(1, types.ModuleName, "state_cache", kind, result)
+}
@@ -23,6 +23,7 @@ import (
 	tmtypes "github.com/cometbft/cometbft/types"
 
 	errorsmod "cosmossdk.io/errors"
+	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	ethermint "github.com/evmos/ethermint/types"
@@ -179,8 +180,16 @@ func (k *Keeper) ApplyTransaction(ctx sdk.Context, msgEth *types.MsgEthereumTx)
 		tmpCtx, commit = ctx.CacheContext()
 	}
 
+	// trace the transaction into the block trace files if enabled, the node config rejects
+	// the debug tracer along with the block tracer, so it doesn't replace it silently
+	var tracer vm.EVMLogger
+	blockTracer := k.newBlockTxTracer(ctx, txConfig)
+	if blockTracer != nil {
+		tracer = blockTracer
+	}
+
 	// pass true to commit the StateDB
-	res, err := k.ApplyMessageWithConfig(tmpCtx, msg, nil, true, cfg, txConfig)
+	res, err := k.ApplyMessageWithConfig(tmpCtx, msg, tracer, true, cfg, txConfig)
 	if err != nil {
 		// when a transaction contains multiple msg, as long as one of the msg fails
 		// all gas will be deducted. so is not msg.Gas()
@@ -255,6 +264,10 @@ func (k *Keeper) ApplyTransaction(ctx sdk.Context, msgEth *types.MsgEthereumTx)
 		k.SetLogSizeTransient(ctx, uint64(txConfig.LogIndex)+uint64(len(receipt.Logs)))
 	}
 
+	if blockTracer != nil {
+		k.setBlockTxTrace(ctx, blockTracer, msg, txConfig, res)
+	}
+
 	k.SetTxIndexTransient(ctx, uint64(txConfig.TxIndex)+1)
 
 	totalGasUsed, err := k.AddTransientGasUsed(ctx, res.GasUsed)
@@ -337,7 +350,31 @@ func (k *Keeper) ApplyMessageWithConfig(ctx sdk.Context,
 	}
 
 	stateDB := statedb.New(ctx, k, txConfig)
+	if len(cfg.StateOverrides) > 0 {
+		if commit {
+			return nil, errorsmod.Wrap(types.ErrInvalidState, "state overrides can't be committed")
+		}
+		if err := cfg.StateOverrides.Apply(stateDB); err != nil {
+			return nil, errorsmod.Wrap(err, "failed to apply state overrides")
+		}
+	}
 	evm := k.NewEVM(ctx, msg, cfg, tracer, stateDB)
+	// EIP-6780: SELFDESTRUCT only deletes the contracts created in the same transaction
+	stateDB.SetEIP6780(cfg.ChainConfig.IsCancun(evm.Context().BlockNumber))
+
+	// abort the execution once the context is done, e.g. when the deadline of a query is exceeded,
+	// the context of the transactions delivered by consensus is never done.
+	if done := ctx.Context().Done(); done != nil {
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			select {
+			case <-done:
+				evm.Cancel()
+			case <-stop:
+			}
+		}()
+	}
 
 	leftoverGas := msg.Gas()
 
@@ -373,6 +410,9 @@ func (k *Keeper) ApplyMessageWithConfig(ctx sdk.Context,
 		stateDB.PrepareAccessList(msg.From(), msg.To(), evm.ActivePrecompiles(rules), msg.AccessList())
 	}
 
+	// snapshot to revert the execution when the storage fee can't be paid
+	snapshot := stateDB.Snapshot()
+
 	if contractCreation {
 		// take over the nonce management from evm:
 		// - reset sender's nonce to msg.Nonce() before calling evm.
@@ -384,6 +424,22 @@ func (k *Keeper) ApplyMessageWithConfig(ctx sdk.Context,
 		ret, leftoverGas, vmErr = k.proxiedEvmCall(ctx, evm, stateDB, sender, *msg.To(), msg.Data(), leftoverGas, msg.Value())
 	}
 
+	// charge the storage fee of the net new storage slots, simulations are charged too so eth_call and
+	// eth_estimateGas fail like the delivered tx when the fee can't be paid
+	if vmErr == nil {
+		slots, err := k.chargeStorageFee(stateDB, cfg.Params, sender.Address())
+		if err != nil {
+			stateDB.RevertToSnapshot(snapshot)
+			if contractCreation {
+				stateDB.SetNonce(sender.Address(), msg.Nonce()+1)
+			}
+			ret = nil
+			vmErr = err
+		} else if commit && slots > 0 {
+			telemetry.IncrCounter(float32(slots), types.ModuleName, "storage", "fee", "slots")
+		}
+	}
+
 	refundQuotient := params.RefundQuotient
 
 	// After EIP-3529: refunds are capped to gasUsed / 5
@@ -467,9 +523,12 @@ func (k *Keeper) proxiedEvmCall(ctx sdk.Context, evm evm.EVM, stateDB vm.StateDB
 		vfContract := k.GetVirtualFrontierContract(ctx, addr)
 
 		// simulate the top call frame when tracing a VFC call
+		var vfcTracer types.VFCTracer
 		vmCfg := evm.Config()
 		if vmCfg.Debug {
 			vmCfg.Tracer.CaptureStart(evm.(*geth.EVM).EVM, caller.Address(), addr, false, input, gas, value)
+			// the balance changes and logs are reported to the tracers capturing them
+			vfcTracer, _ = vmCfg.Tracer.(types.VFCTracer)
 		}
 
 		if vfContract == nil {
@@ -479,7 +538,7 @@ func (k *Keeper) proxiedEvmCall(ctx sdk.Context, evm evm.EVM, stateDB vm.StateDB
 		} else {
 			switch vfContract.Type {
 			case types.VFC_TYPE_BANK:
-				vfcExecResult = k.evmCallVirtualFrontierBankContract(ctx, stateDB, caller.Address(), vfContract, input, gas, value)
+				vfcExecResult = k.evmCallVirtualFrontierBankContract(ctx, stateDB, vfcTracer, caller.Address(), vfContract, input, gas, value)
 
 				break
 			default:
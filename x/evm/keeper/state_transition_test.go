@@ -17,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/evmos/ethermint/tests"
 	"github.com/evmos/ethermint/x/evm/keeper"
@@ -535,6 +536,42 @@ func (suite *KeeperTestSuite) TestContractDeployment() {
 	suite.Require().Greater(db.GetCodeSize(contractAddress), 0)
 }
 
+func (suite *KeeperTestSuite) TestStorageFee() {
+	keeperParams := suite.app.EvmKeeper.GetParams(suite.ctx)
+	keeperParams.StorageFeePerSlot = sdkmath.NewInt(10)
+	suite.Require().NoError(suite.app.EvmKeeper.SetParams(suite.ctx, keeperParams))
+
+	ctorArgs, err := types.ERC20Contract.ABI.Pack("", suite.address, big.NewInt(1000))
+	suite.Require().NoError(err)
+	data := append(types.ERC20Contract.Bin, ctorArgs...)
+
+	deploy := func() (*types.MsgEthereumTxResponse, common.Address) {
+		nonce := suite.app.EvmKeeper.GetNonce(suite.ctx, suite.address)
+		msg := ethtypes.NewMessage(suite.address, nil, nonce, big.NewInt(0), 10_000_000, big.NewInt(0), nil, nil, data, nil, true)
+		res, err := suite.app.EvmKeeper.ApplyMessage(suite.ctx, msg, nil, true)
+		suite.Require().NoError(err)
+		suite.Require().Equal(nonce+1, suite.app.EvmKeeper.GetNonce(suite.ctx, suite.address))
+		return res, crypto.CreateAddress(suite.address, nonce)
+	}
+
+	// the sender can't afford the storage fee
+	suite.Require().NoError(suite.app.EvmKeeper.SetBalance(suite.ctx, suite.address, big.NewInt(0)))
+	res, contract := deploy()
+	suite.Require().True(res.Failed())
+	suite.Require().Contains(res.VmError, types.ErrInsufficientStorageFee.Error())
+	suite.Require().False(suite.app.EvmKeeper.GetAccountOrEmpty(suite.ctx, contract).IsContract())
+	suite.Require().Zero(suite.app.EvmKeeper.GetStorageStats(suite.ctx, contract).Slots)
+
+	// the storage fee is charged for every new slot
+	suite.Require().NoError(suite.app.EvmKeeper.SetBalance(suite.ctx, suite.address, big.NewInt(1000)))
+	res, contract = deploy()
+	suite.Require().False(res.Failed(), res.VmError)
+	stats := suite.app.EvmKeeper.GetStorageStats(suite.ctx, contract)
+	suite.Require().NotZero(stats.Slots)
+	suite.Require().Equal(stats.Slots*64, stats.Bytes)
+	suite.Require().Equal(int64(1000-10*stats.Slots), suite.app.EvmKeeper.GetBalance(suite.ctx, suite.address).Int64())
+}
+
 func (suite *KeeperTestSuite) TestApplyMessage() {
 	expectedGasUsed := params.TxGas
 	var msg core.Message
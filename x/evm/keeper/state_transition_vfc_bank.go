@@ -17,6 +17,7 @@ import (
 func (k *Keeper) evmCallVirtualFrontierBankContract(
 	ctx sdk.Context,
 	stateDB vm.StateDB,
+	tracer types.VFCTracer,
 	sender common.Address, virtualFrontierContract *types.VirtualFrontierContract, calldata []byte, gas uint64, value *big.Int,
 ) *types.VFCExecutionResult {
 	compiledVFContract := types.VFBankContract20
@@ -278,13 +279,18 @@ func (k *Keeper) evmCallVirtualFrontierBankContract(
 
 		// The rest code are state changed, can not be reverted
 
+		var receiverBalance sdk.Coin
+		if tracer != nil {
+			receiverBalance = k.bankKeeper.GetBalance(ctx, receiver, bankContractMetadata.MinDenom)
+		}
+
 		// transfer the amount
 		if err := k.bankKeeper.SendCoins(ctx, sender.Bytes(), receiver, sdk.NewCoins(sendAmount)); err != nil {
 			return types.NewExecVFCRevert(opGasCost, errors.Wrap(err, "failed to transfer"))
 		}
 
 		// Fire the ERC-20 Transfer event
-		stateDB.AddLog(&ethtypes.Log{
+		transferLog := &ethtypes.Log{
 			Address: virtualFrontierContract.ContractAddress(),
 			Topics: []common.Hash{
 				common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"), // keccak256 of `Transfer(address,address,uint256)`
@@ -293,7 +299,30 @@ func (k *Keeper) evmCallVirtualFrontierBankContract(
 			},
 			Data:        bzData,
 			BlockNumber: uint64(ctx.BlockHeight()),
-		})
+		}
+		stateDB.AddLog(transferLog)
+
+		// report the balance changes made outside of the EVM to the tracer
+		if tracer != nil {
+			isEVMDenom := bankContractMetadata.MinDenom == k.GetParams(ctx).EvmDenom
+			for _, change := range []struct {
+				account common.Address
+				prev    sdk.Coin
+			}{
+				{sender, senderBalance},
+				{to, receiverBalance},
+			} {
+				tracer.CaptureVFCBalanceChange(types.VFCBalanceChange{
+					Contract:   virtualFrontierContract.ContractAddress(),
+					Account:    change.account,
+					Denom:      bankContractMetadata.MinDenom,
+					IsEVMDenom: isEVMDenom,
+					Prev:       change.prev.Amount.BigInt(),
+					Post:       k.bankKeeper.GetBalance(ctx, change.account.Bytes(), bankContractMetadata.MinDenom).Amount.BigInt(),
+				})
+			}
+			tracer.CaptureVFCLog(transferLog)
+		}
 
 		return types.NewExecVFCSuccessWithRetBool(true, opGasCost)
 	case types.VFBCmApprove_NotSupported, types.VFBCmTransferFrom_NotSupported, types.VFBCmAllowance_NotSupported:
@@ -1,6 +1,8 @@
 package keeper_test
 
 import (
+	"encoding/json"
+
 	sdkmath "cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
@@ -9,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/evmos/ethermint/utils"
 	"github.com/evmos/ethermint/x/evm/types"
@@ -1009,3 +1012,121 @@ func (suite *KeeperTestSuite) TestApplyMessageWithConfig_VFC_Ops() {
 		})
 	}
 }
+
+func (suite *KeeperTestSuite) TestApplyMessageWithConfig_VFC_Tracers() {
+	vfbcContractAddrOfNative, found := suite.app.EvmKeeper.GetVirtualFrontierBankContractAddressByDenom(suite.ctx, suite.denom)
+	suite.Require().True(found, "require setup for virtual frontier bank contract of evm native denom")
+
+	randomVFBCSenderAddress := common.BytesToAddress([]byte{0x01, 0x01, 0x39, 0x40})
+	randomVFBCReceiverAddress := common.BytesToAddress([]byte{0x02, 0x02, 0x39, 0x40})
+	vfbcSenderInitialBalance := new(big.Int).SetUint64(math.MaxUint64)
+
+	callDataTransfer := append([]byte{0xa9, 0x05, 0x9c, 0xbb}, common.BytesToHash(randomVFBCReceiverAddress.Bytes()).Bytes()...)
+	callDataTransfer = append(callDataTransfer, common.BigToHash(common.Big1).Bytes()...)
+
+	tests := []struct {
+		name      string
+		tracer    string
+		config    string
+		expResult func(json.RawMessage)
+	}{
+		{
+			name:   "prestateTracer reports the previous balances",
+			tracer: types.TracerPrestate,
+			expResult: func(res json.RawMessage) {
+				var prestate map[common.Address]struct {
+					Balance string                      `json:"balance"`
+					Storage map[common.Hash]common.Hash `json:"storage"`
+				}
+				suite.Require().NoError(json.Unmarshal(res, &prestate))
+				suite.Require().Contains(prestate, randomVFBCSenderAddress)
+				suite.Require().Contains(prestate, randomVFBCReceiverAddress)
+				suite.Require().Equal("0x0", prestate[randomVFBCReceiverAddress].Balance)
+
+				suite.Require().Contains(prestate, vfbcContractAddrOfNative)
+				storage := prestate[vfbcContractAddrOfNative].Storage
+				suite.Require().Equal(common.BigToHash(vfbcSenderInitialBalance), storage[types.VFBCBalanceSlot(randomVFBCSenderAddress)])
+				suite.Require().Equal(common.Hash{}, storage[types.VFBCBalanceSlot(randomVFBCReceiverAddress)])
+				suite.Require().Contains(storage, types.VFBCBalanceSlot(randomVFBCReceiverAddress))
+			},
+		},
+		{
+			name:   "callTracer reports the Transfer log",
+			tracer: types.TracerCall,
+			config: `{"withLog":true}`,
+			expResult: func(res json.RawMessage) {
+				var frame struct {
+					To   common.Address `json:"to"`
+					Logs []struct {
+						Address common.Address `json:"address"`
+						Topics  []common.Hash  `json:"topics"`
+					} `json:"logs"`
+				}
+				suite.Require().NoError(json.Unmarshal(res, &frame))
+				suite.Require().Equal(vfbcContractAddrOfNative, frame.To)
+				suite.Require().Len(frame.Logs, 1)
+				suite.Require().Equal(vfbcContractAddrOfNative, frame.Logs[0].Address)
+				suite.Require().Equal(randomVFBCReceiverAddress.Hash(), frame.Logs[0].Topics[2])
+			},
+		},
+		{
+			name:   "callTracer without logs",
+			tracer: types.TracerCall,
+			expResult: func(res json.RawMessage) {
+				suite.Require().NotContains(string(res), "logs")
+			},
+		},
+		{
+			name:   "4byteTracer reports the method",
+			tracer: "4byteTracer",
+			expResult: func(res json.RawMessage) {
+				suite.Require().JSONEq(`{"0xa9059cbb-64":1}`, string(res))
+			},
+		},
+	}
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+
+			coins := sdk.NewCoins(sdk.NewCoin(suite.denom, sdkmath.NewIntFromBigInt(vfbcSenderInitialBalance)))
+			suite.app.BankKeeper.MintCoins(suite.ctx, minttypes.ModuleName, coins)
+			suite.app.BankKeeper.SendCoinsFromModuleToAccount(suite.ctx, minttypes.ModuleName, randomVFBCSenderAddress.Bytes(), coins)
+
+			proposerAddress := suite.ctx.BlockHeader().ProposerAddress
+			config, err := suite.app.EvmKeeper.EVMConfig(suite.ctx, proposerAddress, big.NewInt(9000))
+			suite.Require().NoError(err)
+
+			txConfig := suite.app.EvmKeeper.TxConfig(suite.ctx, common.Hash{})
+
+			msg := ethtypes.NewMessage(
+				randomVFBCSenderAddress,
+				&vfbcContractAddrOfNative,
+				suite.app.EvmKeeper.GetNonce(suite.ctx, randomVFBCSenderAddress),
+				common.Big0,
+				40_000,
+				big.NewInt(1),
+				big.NewInt(1),
+				big.NewInt(1),
+				callDataTransfer,
+				nil,
+				false,
+			)
+
+			var tracerConfig json.RawMessage
+			if tc.config != "" {
+				tracerConfig = json.RawMessage(tc.config)
+			}
+			tracer, err := tracers.New(tc.tracer, &tracers.Context{}, tracerConfig)
+			suite.Require().NoError(err)
+			tracer = types.NewVFCEffectsTracer(tracer, tc.tracer, tracerConfig)
+
+			res, err := suite.app.EvmKeeper.ApplyMessageWithConfig(suite.ctx, msg, tracer, true, config, txConfig)
+			suite.Require().NoError(err)
+			suite.Require().False(res.Failed())
+
+			result, err := tracer.GetResult()
+			suite.Require().NoError(err)
+			tc.expResult(result)
+		})
+	}
+}
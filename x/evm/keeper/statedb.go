@@ -62,6 +62,19 @@ func (k *Keeper) GetState(ctx sdk.Context, addr common.Address, key common.Hash)
 
 // GetCode loads contract code from database, implements `statedb.Keeper` interface.
 func (k *Keeper) GetCode(ctx sdk.Context, codeHash common.Hash) []byte {
+	if code, found := k.stateCache.getCode(ctx, codeHash); found {
+		return code
+	}
+
+	// the code is cached when it's found in the root deliver store, a branch may hold the code of a new contract
+	if rootCtx, ok := k.stateCache.rootContext(ctx); ok {
+		code := prefix.NewStore(rootCtx.KVStore(k.storeKey), types.KeyPrefixCode).Get(codeHash.Bytes())
+		if len(code) > 0 {
+			k.stateCache.setCode(ctx, codeHash, code)
+			return code
+		}
+	}
+
 	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixCode)
 	return store.Get(codeHash.Bytes())
 }
@@ -124,6 +137,8 @@ func (k *Keeper) SetBalance(ctx sdk.Context, addr common.Address, amount *big.In
 
 // SetAccount updates nonce/balance/codeHash together.
 func (k *Keeper) SetAccount(ctx sdk.Context, addr common.Address, account statedb.Account) error {
+	recordPendingAccount(ctx, addr)
+
 	// update account
 	cosmosAddr := sdk.AccAddress(addr.Bytes())
 	acct := k.accountKeeper.GetAccount(ctx, cosmosAddr)
@@ -172,7 +187,8 @@ func (k *Keeper) SetAccount(ctx sdk.Context, addr common.Address, account stated
 }
 
 // SetState update contract storage, delete if value is empty.
-func (k *Keeper) SetState(ctx sdk.Context, addr common.Address, key common.Hash, value []byte) {
+// prev is the value currently stored in the slot, it's used to update the storage stats of the contract.
+func (k *Keeper) SetState(ctx sdk.Context, addr common.Address, key common.Hash, prev, value []byte) {
 	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.AddressStoragePrefix(addr))
 	action := "updated"
 	if len(value) == 0 {
@@ -181,6 +197,7 @@ func (k *Keeper) SetState(ctx sdk.Context, addr common.Address, key common.Hash,
 	} else {
 		store.Set(key.Bytes(), value)
 	}
+	k.updateStorageStats(ctx, addr, key, prev, value)
 	k.Logger(ctx).Debug(
 		fmt.Sprintf("state %s", action),
 		"ethereum-address", addr.Hex(),
@@ -196,6 +213,7 @@ func (k *Keeper) SetCode(ctx sdk.Context, codeHash, code []byte) {
 	action := "updated"
 	if len(code) == 0 {
 		store.Delete(codeHash)
+		k.stateCache.invalidateCode(common.BytesToHash(codeHash))
 		action = "deleted"
 	} else {
 		store.Set(codeHash, code)
@@ -206,12 +224,15 @@ func (k *Keeper) SetCode(ctx sdk.Context, codeHash, code []byte) {
 	)
 }
 
-// DeleteAccount handles contract's suicide call:
+// DeleteAccount handles contract's suicide call, after EIP-6780 it's only called
+// for the contracts created in the same transaction:
 // - clear balance
 // - remove code
 // - remove states
 // - remove auth account
 func (k *Keeper) DeleteAccount(ctx sdk.Context, addr common.Address) error {
+	recordPendingAccount(ctx, addr)
+
 	cosmosAddr := sdk.AccAddress(addr.Bytes())
 	acct := k.accountKeeper.GetAccount(ctx, cosmosAddr)
 	if acct == nil {
@@ -230,10 +251,11 @@ func (k *Keeper) DeleteAccount(ctx sdk.Context, addr common.Address) error {
 	}
 
 	// clear storage
-	k.ForEachStorage(ctx, addr, func(key, _ common.Hash) bool {
-		k.SetState(ctx, addr, key, nil)
+	k.ForEachStorage(ctx, addr, func(key, value common.Hash) bool {
+		k.SetState(ctx, addr, key, value.Bytes(), nil)
 		return true
 	})
+	k.SetStorageStats(ctx, addr, types.StorageStats{})
 
 	// remove auth account
 	k.accountKeeper.RemoveAccount(ctx, acct)
@@ -6,11 +6,15 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	abci "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/evmos/ethermint/tests"
+	"github.com/evmos/ethermint/utils"
+	"github.com/evmos/ethermint/x/evm/statedb"
 )
 
 func BenchmarkCreateAccountNew(b *testing.B) {
@@ -191,3 +195,76 @@ func BenchmarkSuicide(b *testing.B) {
 		vmdb.Suicide(addr)
 	}
 }
+
+// setupStateCacheBenchmark stores a contract account with its code and returns a context
+// in which the block state cache is enabled or not.
+func setupStateCacheBenchmark(b *testing.B, suite *KeeperTestSuite, cached bool) (sdk.Context, common.Address, common.Hash) {
+	suite.SetupTestWithT(b)
+
+	ctx := utils.UseZeroGasConfig(suite.ctx)
+	if cached {
+		suite.app.EvmKeeper.BeginBlock(ctx, abci.RequestBeginBlock{})
+	}
+
+	code := make([]byte, 24*1024)
+	codeHash := crypto.Keccak256Hash(code)
+	contract := tests.GenerateAddress()
+
+	suite.app.EvmKeeper.SetCode(ctx, codeHash.Bytes(), code)
+	err := suite.app.EvmKeeper.SetAccount(ctx, contract, statedb.Account{
+		Nonce:    1,
+		Balance:  big.NewInt(0),
+		CodeHash: codeHash.Bytes(),
+	})
+	require.NoError(b, err)
+
+	if cached {
+		// the contract was written in this block, start a new block so it can be cached
+		ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+		suite.app.EvmKeeper.BeginBlock(ctx, abci.RequestBeginBlock{})
+	}
+
+	return ctx, contract, codeHash
+}
+
+func benchmarkGetCode(b *testing.B, cached bool) {
+	suite := KeeperTestSuite{}
+	ctx, _, codeHash := setupStateCacheBenchmark(b, &suite, cached)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		code := suite.app.EvmKeeper.GetCode(ctx, codeHash)
+		require.NotEmpty(b, code)
+	}
+}
+
+func BenchmarkGetCode(b *testing.B) {
+	benchmarkGetCode(b, false)
+}
+
+func BenchmarkGetCodeCached(b *testing.B) {
+	benchmarkGetCode(b, true)
+}
+
+func benchmarkGetAccount(b *testing.B, cached bool) {
+	suite := KeeperTestSuite{}
+	ctx, contract, _ := setupStateCacheBenchmark(b, &suite, cached)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		acct := suite.app.EvmKeeper.GetAccount(ctx, contract)
+		require.NotNil(b, acct)
+	}
+}
+
+func BenchmarkGetAccount(b *testing.B) {
+	benchmarkGetAccount(b, false)
+}
+
+func BenchmarkGetAccountCached(b *testing.B) {
+	benchmarkGetAccount(b, true)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/big"
 
+	abci "github.com/cometbft/cometbft/abci/types"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	"github.com/cosmos/cosmos-sdk/store/prefix"
@@ -18,6 +19,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/evmos/ethermint/crypto/ethsecp256k1"
 	"github.com/evmos/ethermint/tests"
+	"github.com/evmos/ethermint/utils"
 	"github.com/evmos/ethermint/x/evm/statedb"
 	"github.com/evmos/ethermint/x/evm/types"
 )
@@ -945,3 +947,98 @@ func (suite *KeeperTestSuite) TestDeleteAccount() {
 		})
 	}
 }
+
+func (suite *KeeperTestSuite) TestStateCache() {
+	keeper := suite.app.EvmKeeper
+	ctx := utils.UseZeroGasConfig(suite.ctx)
+
+	code := []byte("contract code")
+	codeHash := crypto.Keccak256Hash(code)
+	contract := tests.GenerateAddress()
+	other := tests.GenerateAddress()
+
+	keeper.SetCode(ctx, codeHash.Bytes(), code)
+	for _, addr := range []common.Address{contract, other} {
+		suite.Require().NoError(keeper.SetAccount(ctx, addr, statedb.Account{
+			Nonce:    1,
+			Balance:  big.NewInt(0),
+			CodeHash: codeHash.Bytes(),
+		}))
+	}
+
+	// enable the cache for the next block
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	keeper.BeginBlock(ctx, abci.RequestBeginBlock{})
+
+	suite.Require().Equal(code, keeper.GetCode(ctx, codeHash))
+	suite.Require().Equal(uint64(1), keeper.GetAccount(ctx, contract).Nonce)
+
+	// writes in a discarded branch must not leave stale entries behind
+	cacheCtx, _ := ctx.CacheContext()
+	suite.Require().NoError(keeper.SetAccount(cacheCtx, contract, statedb.Account{
+		Nonce:    2,
+		Balance:  big.NewInt(0),
+		CodeHash: codeHash.Bytes(),
+	}))
+	suite.Require().Equal(uint64(2), keeper.GetAccount(cacheCtx, contract).Nonce)
+	suite.Require().Equal(uint64(1), keeper.GetAccount(ctx, contract).Nonce)
+
+	// committed writes are visible
+	suite.Require().NoError(keeper.SetAccount(ctx, contract, statedb.Account{
+		Nonce:    3,
+		Balance:  big.NewInt(0),
+		CodeHash: codeHash.Bytes(),
+	}))
+	suite.Require().Equal(uint64(3), keeper.GetAccount(ctx, contract).Nonce)
+
+	// the code of a contract created in a discarded branch is not cached
+	newCode := []byte("new contract code")
+	newCodeHash := crypto.Keccak256Hash(newCode)
+	cacheCtx, _ = ctx.CacheContext()
+	keeper.SetCode(cacheCtx, newCodeHash.Bytes(), newCode)
+	suite.Require().Equal(newCode, keeper.GetCode(cacheCtx, newCodeHash))
+	suite.Require().Empty(keeper.GetCode(ctx, newCodeHash))
+
+	// account writes outside of the EVM invalidate the cached account
+	suite.Require().Equal(uint64(1), keeper.GetAccount(ctx, other).Nonce)
+	acc := keeper.AccountKeeper().GetAccount(ctx, other.Bytes())
+	suite.Require().NoError(acc.SetSequence(4))
+	keeper.AccountKeeper().SetAccount(ctx, acc)
+	suite.Require().Equal(uint64(4), keeper.GetAccount(ctx, other).Nonce)
+
+	suite.Require().NoError(keeper.DeleteAccount(ctx, contract))
+	suite.Require().Nil(keeper.GetAccount(ctx, contract))
+
+	// code deletion invalidates the cached code
+	keeper.SetCode(ctx, codeHash.Bytes(), nil)
+	suite.Require().Empty(keeper.GetCode(ctx, codeHash))
+
+	keeper.EndBlock(ctx, abci.RequestEndBlock{})
+}
+
+func (suite *KeeperTestSuite) TestStorageStats() {
+	keeper := suite.app.EvmKeeper
+	contract := tests.GenerateAddress()
+	key1 := common.BigToHash(big.NewInt(1))
+	key2 := common.BigToHash(big.NewInt(2))
+	value := common.BigToHash(big.NewInt(100)).Bytes()
+
+	keeper.SetState(suite.ctx, contract, key1, nil, value)
+	keeper.SetState(suite.ctx, contract, key2, nil, value)
+	suite.Require().Equal(types.StorageStats{Slots: 2, Bytes: 128}, keeper.GetStorageStats(suite.ctx, contract))
+
+	// overwriting a slot doesn't change the stats
+	keeper.SetState(suite.ctx, contract, key1, value, common.BigToHash(big.NewInt(200)).Bytes())
+	suite.Require().Equal(types.StorageStats{Slots: 2, Bytes: 128}, keeper.GetStorageStats(suite.ctx, contract))
+
+	// empty values free the slot
+	keeper.SetState(suite.ctx, contract, key1, common.BigToHash(big.NewInt(200)).Bytes(), common.Hash{}.Bytes())
+	suite.Require().Equal(types.StorageStats{Slots: 1, Bytes: 64}, keeper.GetStorageStats(suite.ctx, contract))
+
+	res, err := suite.queryClient.StorageStats(sdk.WrapSDKContext(suite.ctx), &types.QueryStorageStatsRequest{Address: contract.Hex()})
+	suite.Require().NoError(err)
+	suite.Require().Equal(types.StorageStats{Slots: 1, Bytes: 64}, res.Stats)
+
+	keeper.SetState(suite.ctx, contract, key2, value, nil)
+	suite.Require().Equal(types.StorageStats{}, keeper.GetStorageStats(suite.ctx, contract))
+}
@@ -0,0 +1,117 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package keeper
+
+import (
+	"math/big"
+
+	errorsmod "cosmossdk.io/errors"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/evmos/ethermint/x/evm/statedb"
+	"github.com/evmos/ethermint/x/evm/types"
+)
+
+// GetStorageStats returns the storage usage of the contract, zero values if the contract has no storage.
+func (k Keeper) GetStorageStats(ctx sdk.Context, addr common.Address) types.StorageStats {
+	var stats types.StorageStats
+
+	bz := ctx.KVStore(k.storeKey).Get(types.StorageStatsKey(addr))
+	if len(bz) == 0 {
+		return stats
+	}
+
+	k.cdc.MustUnmarshal(bz, &stats)
+	return stats
+}
+
+// SetStorageStats stores the storage usage of the contract, the record is removed when there is no slot left.
+func (k Keeper) SetStorageStats(ctx sdk.Context, addr common.Address, stats types.StorageStats) {
+	store := ctx.KVStore(k.storeKey)
+	if stats.Slots == 0 {
+		store.Delete(types.StorageStatsKey(addr))
+		return
+	}
+
+	store.Set(types.StorageStatsKey(addr), k.cdc.MustMarshal(&stats))
+}
+
+// updateStorageStats applies the change of a single storage slot to the storage stats of the contract.
+func (k Keeper) updateStorageStats(ctx sdk.Context, addr common.Address, key common.Hash, prev, value []byte) {
+	prevOccupied := !isEmptySlot(prev)
+	occupied := !isEmptySlot(value)
+	if !prevOccupied && !occupied {
+		return
+	}
+
+	stats := k.GetStorageStats(ctx, addr)
+	if prevOccupied {
+		if stats.Slots > 0 {
+			stats.Slots--
+		}
+		size := uint64(len(key) + len(prev))
+		if stats.Bytes >= size {
+			stats.Bytes -= size
+		} else {
+			stats.Bytes = 0
+		}
+	}
+	if occupied {
+		stats.Slots++
+		stats.Bytes += uint64(len(key) + len(value))
+	}
+	k.SetStorageStats(ctx, addr, stats)
+
+	switch {
+	case occupied && !prevOccupied:
+		telemetry.IncrCounter(1, types.ModuleName, "storage", "slots", "created")
+	case prevOccupied && !occupied:
+		telemetry.IncrCounter(1, types.ModuleName, "storage", "slots", "deleted")
+	}
+}
+
+// chargeStorageFee burns the storage fee for the net new storage slots created by the state transition
+// from the sender balance and returns the number of charged slots. It returns an error if the storage fee is enabled
+// and the sender can't afford it.
+func (k *Keeper) chargeStorageFee(stateDB *statedb.StateDB, params types.Params, sender common.Address) (int64, error) {
+	feePerSlot := params.StorageFeePerSlot
+	if feePerSlot.IsNil() || !feePerSlot.IsPositive() {
+		return 0, nil
+	}
+
+	slots := stateDB.NetNewStorageSlots()
+	if slots <= 0 {
+		return 0, nil
+	}
+
+	fee := new(big.Int).Mul(feePerSlot.BigInt(), big.NewInt(slots))
+	if balance := stateDB.GetBalance(sender); balance.Cmp(fee) < 0 {
+		return 0, errorsmod.Wrapf(
+			types.ErrInsufficientStorageFee,
+			"balance %s < storage fee %s for %d new slots", balance, fee, slots,
+		)
+	}
+
+	stateDB.SubBalance(sender, fee)
+	return slots, nil
+}
+
+// isEmptySlot returns true if the stored value represents an empty storage slot.
+func isEmptySlot(value []byte) bool {
+	return common.BytesToHash(value) == common.Hash{}
+}
@@ -463,7 +463,7 @@ func (suite *VfcITSuite) TestExecVirtualFrontierBankContract() {
 
 			input := hexutil.Bytes(inputCallData)
 
-			bz, err := eth.NewPublicAPI(log.NewNopLogger(), suite.CITS.RpcBackendAt(0)).Call(evmtypes.TransactionArgs{
+			bz, err := eth.NewPublicAPI(log.NewNopLogger(), suite.CITS.RpcBackendAt(0), nil).Call(evmtypes.TransactionArgs{
 				To: &normalErc20ContractAddress,
 				Gas: func() *hexutil.Uint64 {
 					gas := hexutil.Uint64(100_000)
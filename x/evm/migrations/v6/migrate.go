@@ -0,0 +1,80 @@
+package v6
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/evmos/ethermint/x/evm/types"
+)
+
+// statsBatchSize is the number of contracts whose storage stats are held in memory before being written.
+const statsBatchSize = 1000
+
+type contractStorageStats struct {
+	address common.Address
+	stats   types.StorageStats
+}
+
+// MigrateStore migrates the x/evm module state from the consensus version 5 to
+// version 6. Specifically, it iterates over the storage of all the contracts
+// and records the number of non-empty slots and their size for each contract,
+// which are maintained incrementally from this version.
+func MigrateStore(
+	ctx sdk.Context,
+	storeKey storetypes.StoreKey,
+	cdc codec.BinaryCodec,
+) error {
+	store := ctx.KVStore(storeKey)
+
+	// the stats are written in batches after closing the iterator, the iteration resumes from the first slot of
+	// the next contract
+	start := types.KeyPrefixStorage
+	for start != nil {
+		var records []contractStorageStats
+		records, start = collectStorageStats(store, start)
+
+		for _, record := range records {
+			store.Set(types.StorageStatsKey(record.address), cdc.MustMarshal(&record.stats))
+		}
+	}
+
+	return nil
+}
+
+// collectStorageStats returns the storage stats of at most statsBatchSize contracts from the start key and the
+// key to resume from, nil when the whole storage has been iterated.
+func collectStorageStats(store sdk.KVStore, start []byte) ([]contractStorageStats, []byte) {
+	var records []contractStorageStats
+
+	// the storage is iterated in key order, so the slots of a contract are contiguous
+	iterator := store.Iterator(start, sdk.PrefixEndBytes(types.KeyPrefixStorage))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()[len(types.KeyPrefixStorage):]
+		if len(key) <= common.AddressLength {
+			continue
+		}
+
+		value := iterator.Value()
+		if common.BytesToHash(value) == (common.Hash{}) {
+			continue
+		}
+
+		address := common.BytesToAddress(key[:common.AddressLength])
+		if len(records) == 0 || records[len(records)-1].address != address {
+			if len(records) == statsBatchSize {
+				return records, append([]byte(nil), iterator.Key()...)
+			}
+			records = append(records, contractStorageStats{address: address})
+		}
+
+		record := &records[len(records)-1]
+		record.stats.Slots++
+		record.stats.Bytes += uint64(len(key) - common.AddressLength + len(value))
+	}
+
+	return records, nil
+}
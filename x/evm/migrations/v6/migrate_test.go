@@ -0,0 +1,74 @@
+package v6_test
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/evmos/ethermint/app"
+	"github.com/evmos/ethermint/encoding"
+	"github.com/evmos/ethermint/tests"
+	v6 "github.com/evmos/ethermint/x/evm/migrations/v6"
+	"github.com/evmos/ethermint/x/evm/types"
+)
+
+func TestMigrate(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleBasics)
+	cdc := encCfg.Codec
+
+	storeKey := sdk.NewKVStoreKey(types.ModuleName)
+	tKey := sdk.NewTransientStoreKey("transient_test")
+	ctx := testutil.DefaultContext(storeKey, tKey)
+	kvStore := ctx.KVStore(storeKey)
+
+	contract1 := tests.GenerateAddress()
+	contract2 := tests.GenerateAddress()
+	value := common.BigToHash(common.Big1).Bytes()
+
+	kvStore.Set(types.StateKey(contract1, common.BigToHash(common.Big1).Bytes()), value)
+	kvStore.Set(types.StateKey(contract1, common.BigToHash(common.Big2).Bytes()), value)
+	// empty slots are not accounted
+	kvStore.Set(types.StateKey(contract1, common.BigToHash(common.Big3).Bytes()), common.Hash{}.Bytes())
+	kvStore.Set(types.StateKey(contract2, common.BigToHash(common.Big1).Bytes()), value)
+
+	err := v6.MigrateStore(ctx, storeKey, cdc)
+	require.NoError(t, err)
+
+	var stats types.StorageStats
+	cdc.MustUnmarshal(kvStore.Get(types.StorageStatsKey(contract1)), &stats)
+	require.Equal(t, types.StorageStats{Slots: 2, Bytes: 128}, stats)
+
+	cdc.MustUnmarshal(kvStore.Get(types.StorageStatsKey(contract2)), &stats)
+	require.Equal(t, types.StorageStats{Slots: 1, Bytes: 64}, stats)
+}
+
+func TestMigrateBatches(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleBasics)
+	cdc := encCfg.Codec
+
+	storeKey := sdk.NewKVStoreKey(types.ModuleName)
+	tKey := sdk.NewTransientStoreKey("transient_test")
+	ctx := testutil.DefaultContext(storeKey, tKey)
+	kvStore := ctx.KVStore(storeKey)
+
+	// more contracts than the stats written at once
+	contracts := make([]common.Address, 2500)
+	value := common.BigToHash(common.Big1).Bytes()
+	for i := range contracts {
+		contracts[i] = tests.GenerateAddress()
+		kvStore.Set(types.StateKey(contracts[i], common.BigToHash(common.Big1).Bytes()), value)
+		kvStore.Set(types.StateKey(contracts[i], common.BigToHash(common.Big2).Bytes()), value)
+	}
+
+	err := v6.MigrateStore(ctx, storeKey, cdc)
+	require.NoError(t, err)
+
+	for _, contract := range contracts {
+		var stats types.StorageStats
+		cdc.MustUnmarshal(kvStore.Get(types.StorageStatsKey(contract)), &stats)
+		require.Equal(t, types.StorageStats{Slots: 2, Bytes: 128}, stats)
+	}
+}
@@ -59,4 +59,6 @@ type EVMConfig struct {
 	ChainConfig *params.ChainConfig
 	CoinBase    common.Address
 	BaseFee     *big.Int
+	// StateOverrides are applied to the state before the message execution, query only
+	StateOverrides StateOverride
 }
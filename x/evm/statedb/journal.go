@@ -129,6 +129,9 @@ type (
 	refundChange struct {
 		prev uint64
 	}
+	lastCreditChange struct {
+		prev *balanceCredit
+	}
 	addLogChange struct{}
 
 	// Changes to the access list
@@ -209,6 +212,14 @@ func (ch refundChange) Dirtied() *common.Address {
 	return nil
 }
 
+func (ch lastCreditChange) Revert(s *StateDB) {
+	s.lastCredit = ch.prev
+}
+
+func (ch lastCreditChange) Dirtied() *common.Address {
+	return nil
+}
+
 func (ch addLogChange) Revert(s *StateDB) {
 	s.logs = s.logs[:len(s.logs)-1]
 }
@@ -81,7 +81,7 @@ func (k MockKeeper) SetAccount(ctx sdk.Context, addr common.Address, account sta
 	return nil
 }
 
-func (k MockKeeper) SetState(ctx sdk.Context, addr common.Address, key common.Hash, value []byte) {
+func (k MockKeeper) SetState(ctx sdk.Context, addr common.Address, key common.Hash, _, value []byte) {
 	if acct, ok := k.accounts[addr]; ok {
 		if len(value) == 0 {
 			delete(acct.states, key)
@@ -80,6 +80,10 @@ type stateObject struct {
 	// flags
 	dirtyCode bool
 	suicided  bool
+	// created by `CreateAccount` in the current transaction
+	newContract bool
+	// the storage is replaced by a state override, the committed storage is not loaded from keeper
+	storageOverridden bool
 }
 
 // newObject creates a state object.
@@ -217,6 +221,9 @@ func (s *stateObject) GetCommittedState(key common.Hash) common.Hash {
 	if value, cached := s.originStorage[key]; cached {
 		return value
 	}
+	if s.storageOverridden {
+		return common.Hash{}
+	}
 	// If no live objects are available, load it from keeper
 	value := s.db.keeper.GetState(s.db.ctx, s.Address(), key)
 	s.originStorage[key] = value
@@ -250,3 +257,13 @@ func (s *stateObject) SetState(key common.Hash, value common.Hash) {
 func (s *stateObject) setState(key, value common.Hash) {
 	s.dirtyStorage[key] = value
 }
+
+// overrideStorage replaces the whole storage of the account, it's not journaled.
+func (s *stateObject) overrideStorage(storage Storage) {
+	s.originStorage = make(Storage, len(storage))
+	for key, value := range storage {
+		s.originStorage[key] = value
+	}
+	s.dirtyStorage = make(Storage)
+	s.storageOverridden = true
+}
@@ -66,6 +66,19 @@ type StateDB struct {
 
 	// Per-transaction access list
 	accessList *accessList
+
+	// EIP-6780: only destroy the contracts created in the current transaction on SELFDESTRUCT
+	eip6780 bool
+
+	// The last balance credit, SELFDESTRUCT credits the beneficiary right before calling Suicide.
+	// It's journaled so a reverted credit is never taken as the SELFDESTRUCT transfer.
+	lastCredit *balanceCredit
+}
+
+// balanceCredit is an amount added to the balance of an account
+type balanceCredit struct {
+	addr   common.Address
+	amount *big.Int
 }
 
 // New creates a new state from a given trie.
@@ -81,6 +94,12 @@ func New(ctx sdk.Context, keeper Keeper, txConfig TxConfig) *StateDB {
 	}
 }
 
+// SetEIP6780 toggles the SELFDESTRUCT semantics introduced by EIP-6780 (Cancun),
+// it should be called before the execution of the transaction.
+func (s *StateDB) SetEIP6780(enabled bool) {
+	s.eip6780 = enabled
+}
+
 // Keeper returns the underlying `Keeper`
 func (s *StateDB) Keeper() Keeper {
 	return s.keeper
@@ -270,11 +289,15 @@ func (s *StateDB) createObject(addr common.Address) (newobj, prev *stateObject)
 // 2. tx_create(sha(account ++ nonce)) (note that this gets the address of 1)
 //
 // Carrying over the balance ensures that Ether doesn't disappear.
+//
+// The account is flagged as created in the current transaction, the flag is reverted
+// together with the state object when the creation is reverted.
 func (s *StateDB) CreateAccount(addr common.Address) {
 	newObj, prev := s.createObject(addr)
 	if prev != nil {
 		newObj.setBalance(prev.account.Balance)
 	}
+	newObj.newContract = true
 }
 
 // ForEachStorage iterate the contract storage, the iteration order is not defined.
@@ -283,6 +306,18 @@ func (s *StateDB) ForEachStorage(addr common.Address, cb func(key, value common.
 	if so == nil {
 		return nil
 	}
+	if so.storageOverridden {
+		for _, key := range so.originStorage.SortedKeys() {
+			value := so.originStorage[key]
+			if dirty, ok := so.dirtyStorage[key]; ok {
+				value = dirty
+			}
+			if !cb(key, value) {
+				return nil
+			}
+		}
+		return nil
+	}
 	s.keeper.ForEachStorage(s.ctx, addr, func(key, value common.Hash) bool {
 		if value, dirty := so.dirtyStorage[key]; dirty {
 			return cb(key, value)
@@ -308,9 +343,15 @@ func (s *StateDB) AddBalance(addr common.Address, amount *big.Int) {
 	stateObject := s.getOrNewStateObject(addr)
 	if stateObject != nil {
 		stateObject.AddBalance(amount)
+		s.setLastCredit(&balanceCredit{addr: addr, amount: new(big.Int).Set(amount)})
 	}
 }
 
+func (s *StateDB) setLastCredit(credit *balanceCredit) {
+	s.journal.append(lastCreditChange{prev: s.lastCredit})
+	s.lastCredit = credit
+}
+
 // SubBalance subtracts amount from the account associated with addr.
 func (s *StateDB) SubBalance(addr common.Address, amount *big.Int) {
 	stateObject := s.getOrNewStateObject(addr)
@@ -348,11 +389,25 @@ func (s *StateDB) SetState(addr common.Address, key, value common.Hash) {
 //
 // The account's state object is still available until the state is committed,
 // getStateObject will return a non-nil account after Suicide.
+//
+// After EIP-6780, only the accounts created in the current transaction are marked as suicided,
+// the balance of the other accounts is transferred but the account, code and storage are kept.
 func (s *StateDB) Suicide(addr common.Address) bool {
 	stateObject := s.getStateObject(addr)
 	if stateObject == nil {
 		return false
 	}
+	if s.eip6780 && !stateObject.newContract {
+		// the balance has already been credited to the beneficiary by the interpreter, only the transferred
+		// amount is removed, so a contract being its own beneficiary keeps its balance.
+		if credit := s.lastCredit; credit != nil && credit.addr == addr {
+			stateObject.SubBalance(credit.amount)
+		} else {
+			stateObject.SetBalance(new(big.Int))
+		}
+		s.setLastCredit(nil)
+		return true
+	}
 	s.journal.append(suicideChange{
 		account:     &addr,
 		prev:        stateObject.suicided,
@@ -449,6 +504,28 @@ func (s *StateDB) RevertToSnapshot(revid int) {
 	s.validRevisions = s.validRevisions[:idx]
 }
 
+// NetNewStorageSlots returns the number of storage slots turned from empty to non-empty minus the number
+// of slots turned from non-empty to empty by the dirty states, the storage of suicided accounts is ignored.
+func (s *StateDB) NetNewStorageSlots() int64 {
+	var slots int64
+	for _, addr := range s.journal.sortedDirties() {
+		obj := s.stateObjects[addr]
+		if obj == nil || obj.suicided {
+			continue
+		}
+		for key, value := range obj.dirtyStorage {
+			origin := obj.GetCommittedState(key)
+			switch {
+			case origin == (common.Hash{}) && value != (common.Hash{}):
+				slots++
+			case origin != (common.Hash{}) && value == (common.Hash{}):
+				slots--
+			}
+		}
+	}
+	return slots
+}
+
 // Commit writes the dirty states to keeper
 // the StateDB object should be discarded after committed.
 func (s *StateDB) Commit() error {
@@ -475,11 +552,12 @@ func (s *StateDB) Commit() error {
 			}
 			for _, key := range obj.dirtyStorage.SortedKeys() {
 				value := obj.dirtyStorage[key]
+				origin := obj.GetCommittedState(key)
 				// Skip noop changes, persist actual changes
-				if value == obj.originStorage[key] {
+				if value == origin {
 					continue
 				}
-				s.keeper.SetState(s.ctx, obj.Address(), key, value.Bytes())
+				s.keeper.SetState(s.ctx, obj.Address(), key, origin.Bytes(), value.Bytes())
 			}
 
 		}
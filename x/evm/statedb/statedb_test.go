@@ -7,6 +7,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -108,6 +109,194 @@ func (suite *StateDBTestSuite) TestAccount() {
 	}
 }
 
+func (suite *StateDBTestSuite) TestSuicideEIP6780() {
+	key1 := common.BigToHash(big.NewInt(1))
+	value1 := common.BigToHash(big.NewInt(2))
+	testCases := []struct {
+		name     string
+		malleate func(db *statedb.StateDB)
+	}{
+		{"contract created in previous transaction is not deleted", func(db *statedb.StateDB) {
+			db.CreateAccount(address)
+			db.SetCode(address, []byte("hello world"))
+			db.AddBalance(address, big.NewInt(100))
+			db.SetState(address, key1, value1)
+			suite.Require().NoError(db.Commit())
+
+			db = statedb.New(sdk.Context{}, db.Keeper(), emptyTxConfig)
+			db.SetEIP6780(true)
+			suite.Require().True(db.Suicide(address))
+
+			// only the balance is swept
+			suite.Require().False(db.HasSuicided(address))
+			suite.Require().Equal(big.NewInt(0), db.GetBalance(address))
+			suite.Require().NoError(db.Commit())
+
+			db = statedb.New(sdk.Context{}, db.Keeper(), emptyTxConfig)
+			suite.Require().True(db.Exist(address))
+			suite.Require().Equal(big.NewInt(0), db.GetBalance(address))
+			suite.Require().Equal([]byte("hello world"), db.GetCode(address))
+			suite.Require().Equal(value1, db.GetState(address, key1))
+		}},
+		{"contract created in previous transaction being its own beneficiary keeps its balance", func(db *statedb.StateDB) {
+			db.CreateAccount(address)
+			db.SetCode(address, []byte("hello world"))
+			db.AddBalance(address, big.NewInt(100))
+			suite.Require().NoError(db.Commit())
+
+			db = statedb.New(sdk.Context{}, db.Keeper(), emptyTxConfig)
+			db.SetEIP6780(true)
+			// SELFDESTRUCT credits the beneficiary with the balance before calling Suicide
+			db.AddBalance(address, db.GetBalance(address))
+			suite.Require().True(db.Suicide(address))
+
+			suite.Require().False(db.HasSuicided(address))
+			suite.Require().Equal(big.NewInt(100), db.GetBalance(address))
+			suite.Require().NoError(db.Commit())
+
+			db = statedb.New(sdk.Context{}, db.Keeper(), emptyTxConfig)
+			suite.Require().Equal(big.NewInt(100), db.GetBalance(address))
+		}},
+		{"contract created in previous transaction transfers its balance to the beneficiary", func(db *statedb.StateDB) {
+			db.CreateAccount(address)
+			db.SetCode(address, []byte("hello world"))
+			db.AddBalance(address, big.NewInt(100))
+			suite.Require().NoError(db.Commit())
+
+			db = statedb.New(sdk.Context{}, db.Keeper(), emptyTxConfig)
+			db.SetEIP6780(true)
+			db.AddBalance(address2, db.GetBalance(address))
+			suite.Require().True(db.Suicide(address))
+
+			suite.Require().Equal(big.NewInt(0), db.GetBalance(address))
+			suite.Require().Equal(big.NewInt(100), db.GetBalance(address2))
+		}},
+		{"reverted credit is not taken as the transfer to the beneficiary", func(db *statedb.StateDB) {
+			db.CreateAccount(address)
+			db.SetCode(address, []byte("hello world"))
+			db.AddBalance(address, big.NewInt(100))
+			suite.Require().NoError(db.Commit())
+
+			db = statedb.New(sdk.Context{}, db.Keeper(), emptyTxConfig)
+			db.SetEIP6780(true)
+			db.AddBalance(address2, db.GetBalance(address))
+			snapshot := db.Snapshot()
+			db.AddBalance(address, big.NewInt(10))
+			db.RevertToSnapshot(snapshot)
+			suite.Require().True(db.Suicide(address))
+
+			suite.Require().Equal(big.NewInt(0), db.GetBalance(address))
+			suite.Require().Equal(big.NewInt(100), db.GetBalance(address2))
+		}},
+		{"contract created in the same transaction is deleted", func(db *statedb.StateDB) {
+			db.SetEIP6780(true)
+			db.CreateAccount(address)
+			db.SetCode(address, []byte("hello world"))
+			db.AddBalance(address, big.NewInt(100))
+			db.SetState(address, key1, value1)
+			suite.Require().NoError(db.Commit())
+
+			db = statedb.New(sdk.Context{}, db.Keeper(), emptyTxConfig)
+			db.SetEIP6780(true)
+			db.CreateAccount(address2)
+			db.SetCode(address2, []byte("hello world"))
+			db.AddBalance(address2, big.NewInt(100))
+			suite.Require().True(db.Suicide(address2))
+			suite.Require().True(db.HasSuicided(address2))
+			suite.Require().NoError(db.Commit())
+
+			db = statedb.New(sdk.Context{}, db.Keeper(), emptyTxConfig)
+			suite.Require().False(db.Exist(address2))
+			suite.Require().True(db.Exist(address))
+		}},
+		{"reverted creation is not considered as created in the transaction", func(db *statedb.StateDB) {
+			db.CreateAccount(address)
+			db.SetCode(address, []byte("hello world"))
+			suite.Require().NoError(db.Commit())
+
+			db = statedb.New(sdk.Context{}, db.Keeper(), emptyTxConfig)
+			db.SetEIP6780(true)
+			rev := db.Snapshot()
+			db.CreateAccount(address)
+			db.RevertToSnapshot(rev)
+			suite.Require().True(db.Suicide(address))
+			suite.Require().False(db.HasSuicided(address))
+			suite.Require().NoError(db.Commit())
+
+			db = statedb.New(sdk.Context{}, db.Keeper(), emptyTxConfig)
+			suite.Require().True(db.Exist(address))
+		}},
+	}
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			keeper := NewMockKeeper()
+			db := statedb.New(sdk.Context{}, keeper, emptyTxConfig)
+			tc.malleate(db)
+		})
+	}
+}
+
+func (suite *StateDBTestSuite) TestStateOverride() {
+	key1 := common.BigToHash(big.NewInt(1))
+	value1 := common.BigToHash(big.NewInt(2))
+	key2 := common.BigToHash(big.NewInt(3))
+	value2 := common.BigToHash(big.NewInt(4))
+
+	nonce := hexutil.Uint64(5)
+	code := hexutil.Bytes("hello world")
+	balance := (*hexutil.Big)(big.NewInt(100))
+
+	testCases := []struct {
+		name     string
+		override statedb.OverrideAccount
+		expPass  bool
+		check    func(db *statedb.StateDB)
+	}{
+		{"nonce, code and balance", statedb.OverrideAccount{Nonce: &nonce, Code: &code, Balance: &balance}, true, func(db *statedb.StateDB) {
+			suite.Require().Equal(uint64(5), db.GetNonce(address))
+			suite.Require().Equal([]byte("hello world"), db.GetCode(address))
+			suite.Require().Equal(big.NewInt(100), db.GetBalance(address))
+			suite.Require().Equal(value1, db.GetState(address, key1))
+		}},
+		{"state replaces the whole storage", statedb.OverrideAccount{State: &map[common.Hash]common.Hash{key2: value2}}, true, func(db *statedb.StateDB) {
+			suite.Require().Equal(common.Hash{}, db.GetState(address, key1))
+			suite.Require().Equal(value2, db.GetState(address, key2))
+			storage := statedb.Storage{}
+			suite.Require().NoError(db.ForEachStorage(address, func(key, value common.Hash) bool {
+				storage[key] = value
+				return true
+			}))
+			suite.Require().Equal(statedb.Storage{key2: value2}, storage)
+		}},
+		{"stateDiff is applied on top of the storage", statedb.OverrideAccount{StateDiff: &map[common.Hash]common.Hash{key2: value2}}, true, func(db *statedb.StateDB) {
+			suite.Require().Equal(value1, db.GetState(address, key1))
+			suite.Require().Equal(value2, db.GetState(address, key2))
+		}},
+		{"state and stateDiff can't be both set", statedb.OverrideAccount{
+			State:     &map[common.Hash]common.Hash{},
+			StateDiff: &map[common.Hash]common.Hash{},
+		}, false, nil},
+	}
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			keeper := NewMockKeeper()
+			db := statedb.New(sdk.Context{}, keeper, emptyTxConfig)
+			db.CreateAccount(address)
+			db.SetState(address, key1, value1)
+			suite.Require().NoError(db.Commit())
+
+			db = statedb.New(sdk.Context{}, keeper, emptyTxConfig)
+			err := statedb.StateOverride{address: tc.override}.Apply(db)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+			suite.Require().NoError(err)
+			tc.check(db)
+		})
+	}
+}
+
 func (suite *StateDBTestSuite) TestAccountOverride() {
 	keeper := NewMockKeeper()
 	db := statedb.New(sdk.Context{}, keeper, emptyTxConfig)
@@ -18,9 +18,13 @@ package types
 import (
 	"errors"
 	"fmt"
+	"strconv"
 
 	errorsmod "cosmossdk.io/errors"
 	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -49,6 +53,8 @@ const (
 	codeErrGasOverflow
 	codeErrInvalidAccount
 	codeErrInvalidGasLimit
+	codeErrInsufficientStorageFee
+	codeErrStatePruned
 	codeErrProhibitedAccessingVirtualFrontierContract = uint32(40)
 )
 
@@ -118,6 +124,12 @@ var (
 	// ErrInvalidGasLimit returns an error if gas limit value is invalid
 	ErrInvalidGasLimit = errorsmod.Register(ModuleName, codeErrInvalidGasLimit, "invalid gas limit")
 
+	// ErrInsufficientStorageFee returns an error if the sender can not pay the storage fee of the new storage slots
+	ErrInsufficientStorageFee = errorsmod.Register(ModuleName, codeErrInsufficientStorageFee, "insufficient funds for storage fee")
+
+	// ErrStatePruned returns an error if the state of the queried height has been pruned
+	ErrStatePruned = errorsmod.Register(ModuleName, codeErrStatePruned, "state of the queried height has been pruned")
+
 	// ErrProhibitedAccessingVirtualFrontierContract returns an error if tries to access a virtual frontier contract
 	ErrProhibitedAccessingVirtualFrontierContract = errorsmod.Register(ModuleName, codeErrProhibitedAccessingVirtualFrontierContract, "prohibited accessing virtual frontier contract")
 )
@@ -154,3 +166,81 @@ func (e *RevertError) ErrorCode() int {
 func (e *RevertError) ErrorData() interface{} {
 	return e.reason
 }
+
+// StatePrunedReason is the reason of the error details of the evm queries failing because the state of the
+// queried height has been pruned.
+const StatePrunedReason = "STATE_PRUNED"
+
+// NewStatePrunedStatusError returns the gRPC error of an evm query at a height whose state has been pruned,
+// it's identified by the domain and reason of its error details rather than by its message.
+func NewStatePrunedStatusError(height int64) error {
+	st := status.Newf(codes.FailedPrecondition, "%s: height %d", ErrStatePruned.Error(), height)
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   StatePrunedReason,
+		Domain:   ModuleName,
+		Metadata: map[string]string{"height": strconv.FormatInt(height, 10)},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// ConvertPrunedStateError converts the gRPC error returned by the node for an evm query at a pruned height,
+// see NewStatePrunedStatusError, into ErrStatePruned, other errors are returned unchanged.
+func ConvertPrunedStateError(err error) error {
+	if err == nil || errors.Is(err, ErrStatePruned) {
+		return err
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if ok && info.Domain == ModuleName && info.Reason == StatePrunedReason {
+			return errorsmod.Wrap(ErrStatePruned, st.Message())
+		}
+	}
+	return err
+}
+
+// IsPrunedStateError returns true if the error is caused by querying a height whose state has been pruned,
+// the query client converts these errors into ErrStatePruned.
+func IsPrunedStateError(err error) bool {
+	return errors.Is(err, ErrStatePruned)
+}
+
+// PrunedStateError is an API error returned when the state of the queried height has been pruned,
+// the earliest height with available state is returned as error data so clients can fall back to an archive node.
+type PrunedStateError struct {
+	height   int64
+	earliest int64
+}
+
+// NewPrunedStateError returns a PrunedStateError for the given queried and earliest available heights.
+func NewPrunedStateError(height, earliest int64) *PrunedStateError {
+	return &PrunedStateError{
+		height:   height,
+		earliest: earliest,
+	}
+}
+
+func (e *PrunedStateError) Error() string {
+	return fmt.Sprintf(
+		"missing trie node: state at height %d is not available, earliest available height is %d",
+		e.height, e.earliest,
+	)
+}
+
+// ErrorCode returns the JSON error code for a missing state, which is the same as the generic server error used by geth.
+func (e *PrunedStateError) ErrorCode() int {
+	return -32000
+}
+
+// ErrorData returns the earliest height with available state.
+func (e *PrunedStateError) ErrorData() interface{} {
+	return map[string]interface{}{
+		"earliestHeight": hexutil.Uint64(e.earliest),
+	}
+}
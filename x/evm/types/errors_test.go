@@ -1,11 +1,18 @@
 package types
 
 import (
+	"errors"
 	"testing"
 
+	errorsmod "cosmossdk.io/errors"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/status-im/keycard-go/hexutils"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var revertSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
@@ -51,3 +58,41 @@ func TestNewExecErrorWithReason(t *testing.T) {
 		require.Equal(t, 3, errWithReason.ErrorCode())
 	}
 }
+
+func TestConvertPrunedStateError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		err    error
+		pruned bool
+	}{
+		{"nil error", nil, false},
+		{"generic error", errors.New("rpc error: code = Unknown desc = key not found"), false},
+		{"future height", errorsmod.Wrap(sdkerrors.ErrInvalidHeight, "cannot query with height in the future; please provide a valid height"), false},
+		{"pruned height", NewStatePrunedStatusError(1), true},
+		{
+			"message of a pruned height",
+			status.Error(codes.InvalidArgument, "failed to load state at height 1; version does not exist (latest height: 100): invalid request"),
+			false,
+		},
+		{"status without details", status.Error(codes.FailedPrecondition, ErrStatePruned.Error()), false},
+		{"already converted", errorsmod.Wrap(ErrStatePruned, "height 1"), true},
+	}
+
+	for _, tc := range testCases {
+		err := ConvertPrunedStateError(tc.err)
+		require.Equal(t, tc.pruned, IsPrunedStateError(err), tc.name)
+		if !tc.pruned {
+			require.Equal(t, tc.err, err, tc.name)
+		}
+	}
+
+	// only the converted errors are recognized
+	require.False(t, IsPrunedStateError(NewStatePrunedStatusError(1)))
+}
+
+func TestPrunedStateError(t *testing.T) {
+	err := NewPrunedStateError(1, 10)
+	require.Equal(t, "missing trie node: state at height 1 is not available, earliest available height is 10", err.Error())
+	require.Equal(t, -32000, err.ErrorCode())
+	require.Equal(t, map[string]interface{}{"earliestHeight": hexutil.Uint64(10)}, err.ErrorData())
+}
@@ -40,6 +40,10 @@ type Params struct {
 	// allow_unprotected_txs defines if replay-protected (i.e non EIP155
 	// signed) transactions can be executed on the state machine.
 	AllowUnprotectedTxs bool `protobuf:"varint,6,opt,name=allow_unprotected_txs,json=allowUnprotectedTxs,proto3" json:"allow_unprotected_txs,omitempty"`
+	// storage_fee_per_slot defines the amount of EVM denomination charged to the
+	// sender of a transaction for every net new contract storage slot it creates.
+	// An empty or zero value disables the storage fee.
+	StorageFeePerSlot github_com_cosmos_cosmos_sdk_types.Int `protobuf:"bytes,7,opt,name=storage_fee_per_slot,json=storageFeePerSlot,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Int" json:"storage_fee_per_slot" yaml:"storage_fee_per_slot"`
 }
 
 func (m *Params) Reset()         { *m = Params{} }
@@ -117,6 +121,62 @@ func (m *Params) GetAllowUnprotectedTxs() bool {
 	return false
 }
 
+// StorageStats defines the storage usage of a contract, maintained
+// incrementally on every storage write.
+type StorageStats struct {
+	// slots is the number of non-empty storage slots of the contract
+	Slots uint64 `protobuf:"varint,1,opt,name=slots,proto3" json:"slots,omitempty"`
+	// bytes is the total size of the keys and values of the non-empty storage slots
+	Bytes uint64 `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+}
+
+func (m *StorageStats) Reset()         { *m = StorageStats{} }
+func (m *StorageStats) String() string { return proto.CompactTextString(m) }
+func (*StorageStats) ProtoMessage()    {}
+func (*StorageStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d21ecc92c8c8583e, []int{1}
+}
+func (m *StorageStats) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StorageStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StorageStats.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StorageStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageStats.Merge(m, src)
+}
+func (m *StorageStats) XXX_Size() int {
+	return m.Size()
+}
+func (m *StorageStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageStats proto.InternalMessageInfo
+
+func (m *StorageStats) GetSlots() uint64 {
+	if m != nil {
+		return m.Slots
+	}
+	return 0
+}
+
+func (m *StorageStats) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
 // ChainConfig defines the Ethereum ChainConfig parameters using *sdk.Int values
 // instead of *big.Int.
 type ChainConfig struct {
@@ -165,7 +225,7 @@ func (m *ChainConfig) Reset()         { *m = ChainConfig{} }
 func (m *ChainConfig) String() string { return proto.CompactTextString(m) }
 func (*ChainConfig) ProtoMessage()    {}
 func (*ChainConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d21ecc92c8c8583e, []int{1}
+	return fileDescriptor_d21ecc92c8c8583e, []int{2}
 }
 func (m *ChainConfig) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -220,7 +280,7 @@ func (m *State) Reset()         { *m = State{} }
 func (m *State) String() string { return proto.CompactTextString(m) }
 func (*State) ProtoMessage()    {}
 func (*State) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d21ecc92c8c8583e, []int{2}
+	return fileDescriptor_d21ecc92c8c8583e, []int{3}
 }
 func (m *State) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -277,7 +337,7 @@ func (m *TransactionLogs) Reset()         { *m = TransactionLogs{} }
 func (m *TransactionLogs) String() string { return proto.CompactTextString(m) }
 func (*TransactionLogs) ProtoMessage()    {}
 func (*TransactionLogs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d21ecc92c8c8583e, []int{3}
+	return fileDescriptor_d21ecc92c8c8583e, []int{4}
 }
 func (m *TransactionLogs) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -353,7 +413,7 @@ func (m *Log) Reset()         { *m = Log{} }
 func (m *Log) String() string { return proto.CompactTextString(m) }
 func (*Log) ProtoMessage()    {}
 func (*Log) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d21ecc92c8c8583e, []int{4}
+	return fileDescriptor_d21ecc92c8c8583e, []int{5}
 }
 func (m *Log) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -468,7 +528,7 @@ func (m *TxResult) Reset()         { *m = TxResult{} }
 func (m *TxResult) String() string { return proto.CompactTextString(m) }
 func (*TxResult) ProtoMessage()    {}
 func (*TxResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d21ecc92c8c8583e, []int{5}
+	return fileDescriptor_d21ecc92c8c8583e, []int{6}
 }
 func (m *TxResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -509,7 +569,7 @@ func (m *AccessTuple) Reset()         { *m = AccessTuple{} }
 func (m *AccessTuple) String() string { return proto.CompactTextString(m) }
 func (*AccessTuple) ProtoMessage()    {}
 func (*AccessTuple) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d21ecc92c8c8583e, []int{6}
+	return fileDescriptor_d21ecc92c8c8583e, []int{7}
 }
 func (m *AccessTuple) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -569,7 +629,7 @@ func (m *TraceConfig) Reset()         { *m = TraceConfig{} }
 func (m *TraceConfig) String() string { return proto.CompactTextString(m) }
 func (*TraceConfig) ProtoMessage()    {}
 func (*TraceConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d21ecc92c8c8583e, []int{7}
+	return fileDescriptor_d21ecc92c8c8583e, []int{8}
 }
 func (m *TraceConfig) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -677,6 +737,7 @@ func (m *TraceConfig) GetTracerJsonConfig() string {
 
 func init() {
 	proto.RegisterType((*Params)(nil), "ethermint.evm.v1.Params")
+	proto.RegisterType((*StorageStats)(nil), "ethermint.evm.v1.StorageStats")
 	proto.RegisterType((*ChainConfig)(nil), "ethermint.evm.v1.ChainConfig")
 	proto.RegisterType((*State)(nil), "ethermint.evm.v1.State")
 	proto.RegisterType((*TransactionLogs)(nil), "ethermint.evm.v1.TransactionLogs")
@@ -689,108 +750,112 @@ func init() {
 func init() { proto.RegisterFile("ethermint/evm/v1/evm.proto", fileDescriptor_d21ecc92c8c8583e) }
 
 var fileDescriptor_d21ecc92c8c8583e = []byte{
-	// 1608 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0xdd, 0x6e, 0xe3, 0xc6,
-	0x15, 0xb6, 0x2d, 0xda, 0xa6, 0x46, 0xb2, 0x44, 0x8f, 0xb5, 0x8e, 0xb2, 0x8b, 0x9a, 0x2e, 0x2f,
-	0x0a, 0x17, 0x48, 0xec, 0xd8, 0x81, 0xd1, 0x45, 0x82, 0x16, 0xb5, 0x76, 0x9d, 0xc4, 0xee, 0x36,
-	0x35, 0xc6, 0x0e, 0x0a, 0x14, 0x28, 0x88, 0x11, 0x39, 0xa1, 0x18, 0x93, 0x1c, 0x61, 0x66, 0xa8,
-	0x95, 0xda, 0x3e, 0x40, 0x8b, 0xde, 0xf4, 0x09, 0x8a, 0x3c, 0x4e, 0xd0, 0xab, 0xbd, 0x2c, 0x7a,
-	0x41, 0x14, 0xde, 0x3b, 0x5f, 0xea, 0x09, 0x8a, 0xf9, 0x11, 0xf5, 0x63, 0xa3, 0xad, 0x75, 0xe5,
-	0xf9, 0xce, 0x39, 0xf3, 0x7d, 0x33, 0x67, 0xce, 0x68, 0x0e, 0x0d, 0x9e, 0x13, 0xd1, 0x23, 0x2c,
-	0x8d, 0x33, 0x71, 0x44, 0x06, 0xe9, 0xd1, 0xe0, 0x58, 0xfe, 0x39, 0xec, 0x33, 0x2a, 0x28, 0x74,
-	0x4a, 0xdf, 0xa1, 0x34, 0x0e, 0x8e, 0x9f, 0xb7, 0x22, 0x1a, 0x51, 0xe5, 0x3c, 0x92, 0x23, 0x1d,
-	0xe7, 0xfd, 0xa5, 0x02, 0x36, 0xae, 0x30, 0xc3, 0x29, 0x87, 0xc7, 0xa0, 0x4a, 0x06, 0xa9, 0x1f,
-	0x92, 0x8c, 0xa6, 0xed, 0xd5, 0xfd, 0xd5, 0x83, 0x6a, 0xa7, 0x35, 0x2e, 0x5c, 0x67, 0x84, 0xd3,
-	0xe4, 0x33, 0xaf, 0x74, 0x79, 0xc8, 0x26, 0x83, 0xf4, 0xb5, 0x1c, 0xc2, 0x9f, 0x83, 0x2d, 0x92,
-	0xe1, 0x6e, 0x42, 0xfc, 0x80, 0x11, 0x2c, 0x48, 0x7b, 0x6d, 0x7f, 0xf5, 0xc0, 0xee, 0xb4, 0xc7,
-	0x85, 0xdb, 0x32, 0xd3, 0x66, 0xdd, 0x1e, 0xaa, 0x6b, 0xfc, 0x4a, 0x41, 0xf8, 0x33, 0x50, 0x9b,
-	0xf8, 0x71, 0x92, 0xb4, 0x2b, 0x6a, 0xf2, 0xee, 0xb8, 0x70, 0xe1, 0xfc, 0x64, 0x9c, 0x24, 0x1e,
-	0x02, 0x66, 0x2a, 0x4e, 0x12, 0x78, 0x06, 0x00, 0x19, 0x0a, 0x86, 0x7d, 0x12, 0xf7, 0x79, 0xdb,
-	0xda, 0xaf, 0x1c, 0x54, 0x3a, 0xde, 0x5d, 0xe1, 0x56, 0xcf, 0xa5, 0xf5, 0xfc, 0xe2, 0x8a, 0x8f,
-	0x0b, 0x77, 0xdb, 0x90, 0x94, 0x81, 0x1e, 0xaa, 0x2a, 0x70, 0x1e, 0xf7, 0x39, 0xfc, 0x3d, 0xa8,
-	0x07, 0x3d, 0x1c, 0x67, 0x7e, 0x40, 0xb3, 0x6f, 0xe3, 0xa8, 0xbd, 0xbe, 0xbf, 0x7a, 0x50, 0x3b,
-	0xf9, 0xd1, 0xe1, 0x62, 0xde, 0x0e, 0x5f, 0xc9, 0xa8, 0x57, 0x2a, 0xa8, 0xf3, 0xe2, 0x87, 0xc2,
-	0x5d, 0x19, 0x17, 0xee, 0x8e, 0xa6, 0x9e, 0x25, 0xf0, 0x50, 0x2d, 0x98, 0x46, 0xc2, 0x13, 0xf0,
-	0x0c, 0x27, 0x09, 0x7d, 0xeb, 0xe7, 0x99, 0x4c, 0x34, 0x09, 0x04, 0x09, 0x7d, 0x31, 0xe4, 0xed,
-	0x0d, 0xb9, 0x49, 0xb4, 0xa3, 0x9c, 0xdf, 0x4c, 0x7d, 0x37, 0x43, 0xee, 0xfd, 0x7d, 0x1b, 0xd4,
-	0x66, 0xd4, 0x60, 0x0a, 0x9a, 0x3d, 0x9a, 0x12, 0x2e, 0x08, 0x0e, 0xfd, 0x6e, 0x42, 0x83, 0x5b,
-	0x73, 0x2c, 0xaf, 0xff, 0x55, 0xb8, 0x3f, 0x89, 0x62, 0xd1, 0xcb, 0xbb, 0x87, 0x01, 0x4d, 0x8f,
-	0x02, 0xca, 0x53, 0xca, 0xcd, 0x9f, 0x8f, 0x79, 0x78, 0x7b, 0x24, 0x46, 0x7d, 0xc2, 0x0f, 0x2f,
-	0x32, 0x31, 0x2e, 0xdc, 0x5d, 0xbd, 0xd8, 0x05, 0x2a, 0x0f, 0x35, 0x4a, 0x4b, 0x47, 0x1a, 0xe0,
-	0x08, 0x34, 0x42, 0x4c, 0xfd, 0x6f, 0x29, 0xbb, 0x35, 0x6a, 0x6b, 0x4a, 0xed, 0xfa, 0xff, 0x57,
-	0xbb, 0x2b, 0xdc, 0xfa, 0xeb, 0xb3, 0xdf, 0x7c, 0x41, 0xd9, 0xad, 0xe2, 0x1c, 0x17, 0xee, 0x33,
-	0xad, 0x3e, 0xcf, 0xec, 0xa1, 0x7a, 0x88, 0x69, 0x19, 0x06, 0x7f, 0x0b, 0x9c, 0x32, 0x80, 0xe7,
-	0xfd, 0x3e, 0x65, 0xc2, 0x54, 0xc3, 0xc7, 0x77, 0x85, 0xdb, 0x30, 0x94, 0xd7, 0xda, 0x33, 0x2e,
-	0xdc, 0x0f, 0x16, 0x48, 0xcd, 0x1c, 0x0f, 0x35, 0x0c, 0xad, 0x09, 0x85, 0x1c, 0xd4, 0x49, 0xdc,
-	0x3f, 0x3e, 0xfd, 0xc4, 0xec, 0xc8, 0x52, 0x3b, 0xba, 0x7a, 0xd2, 0x8e, 0x6a, 0xe7, 0x17, 0x57,
-	0xc7, 0xa7, 0x9f, 0x4c, 0x36, 0x64, 0xce, 0x7e, 0x96, 0xd6, 0x43, 0x35, 0x0d, 0xf5, 0x6e, 0x2e,
-	0x80, 0x81, 0x7e, 0x0f, 0xf3, 0x9e, 0xaa, 0xac, 0x6a, 0xe7, 0xe0, 0xae, 0x70, 0x81, 0x66, 0xfa,
-	0x0a, 0xf3, 0xde, 0xf4, 0x5c, 0xba, 0xa3, 0x3f, 0xe0, 0x4c, 0xc4, 0x79, 0x3a, 0xe1, 0x02, 0x7a,
-	0xb2, 0x8c, 0x2a, 0xd7, 0x7f, 0x6a, 0xd6, 0xbf, 0xb1, 0xf4, 0xfa, 0x4f, 0x1f, 0x5b, 0xff, 0xe9,
-	0xfc, 0xfa, 0x75, 0x4c, 0x29, 0xfa, 0xd2, 0x88, 0x6e, 0x2e, 0x2d, 0xfa, 0xf2, 0x31, 0xd1, 0x97,
-	0xf3, 0xa2, 0x3a, 0x46, 0x16, 0xfb, 0x42, 0x26, 0xda, 0xf6, 0xf2, 0xc5, 0xfe, 0x20, 0xa9, 0x8d,
-	0xd2, 0xa2, 0xe5, 0xfe, 0x04, 0x5a, 0x01, 0xcd, 0xb8, 0x90, 0xb6, 0x8c, 0xf6, 0x13, 0x62, 0x34,
-	0xab, 0x4a, 0xf3, 0xe2, 0x49, 0x9a, 0x2f, 0xcc, 0xaf, 0xc1, 0x23, 0x7c, 0x1e, 0xda, 0x99, 0x37,
-	0x6b, 0xf5, 0x3e, 0x70, 0xfa, 0x44, 0x10, 0xc6, 0xbb, 0x39, 0x8b, 0x8c, 0x32, 0x50, 0xca, 0xe7,
-	0x4f, 0x52, 0x36, 0xf7, 0x60, 0x91, 0xcb, 0x43, 0xcd, 0xa9, 0x49, 0x2b, 0x7e, 0x07, 0x1a, 0xb1,
-	0x5c, 0x46, 0x37, 0x4f, 0x8c, 0x5e, 0x4d, 0xe9, 0xbd, 0x7a, 0x92, 0x9e, 0xb9, 0xcc, 0xf3, 0x4c,
-	0x1e, 0xda, 0x9a, 0x18, 0xb4, 0x56, 0x0e, 0x60, 0x9a, 0xc7, 0xcc, 0x8f, 0x12, 0x1c, 0xc4, 0x84,
-	0x19, 0xbd, 0xba, 0xd2, 0xfb, 0xf2, 0x49, 0x7a, 0x1f, 0x6a, 0xbd, 0x87, 0x6c, 0x1e, 0x72, 0xa4,
-	0xf1, 0x4b, 0x6d, 0xd3, 0xb2, 0x21, 0xa8, 0x77, 0x09, 0x4b, 0xe2, 0xcc, 0x08, 0x6e, 0x29, 0xc1,
-	0xb3, 0x27, 0x09, 0x9a, 0x3a, 0x9d, 0xe5, 0xf1, 0x50, 0x4d, 0xc3, 0x52, 0x25, 0xa1, 0x59, 0x48,
-	0x27, 0x2a, 0xdb, 0xcb, 0xab, 0xcc, 0xf2, 0x78, 0xa8, 0xa6, 0xa1, 0x56, 0x19, 0x82, 0x1d, 0xcc,
-	0x18, 0x7d, 0xbb, 0x90, 0x43, 0xa8, 0xc4, 0xbe, 0x7a, 0x92, 0xd8, 0x73, 0x2d, 0xf6, 0x08, 0x9d,
-	0x87, 0xb6, 0x95, 0x75, 0x2e, 0x8b, 0x39, 0x80, 0x11, 0xc3, 0xa3, 0x05, 0xe1, 0xd6, 0xf2, 0x87,
-	0xf7, 0x90, 0xcd, 0x43, 0x8e, 0x34, 0xce, 0xc9, 0xfe, 0x11, 0xb4, 0x52, 0xc2, 0x22, 0xe2, 0x67,
-	0x44, 0xf0, 0x7e, 0x12, 0x0b, 0x23, 0xfc, 0x6c, 0xf9, 0xfb, 0xf8, 0x18, 0x9f, 0x87, 0xa0, 0x32,
-	0x7f, 0x6d, 0xac, 0xe5, 0xe5, 0xe0, 0x3d, 0x9c, 0x45, 0x3d, 0x1c, 0x1b, 0xd9, 0xdd, 0xe5, 0x2f,
-	0xc7, 0x3c, 0x93, 0x87, 0xb6, 0x26, 0x86, 0xb2, 0x7e, 0x02, 0x9c, 0x05, 0xf9, 0xa4, 0x7e, 0x3e,
-	0x58, 0xbe, 0x7e, 0x66, 0x79, 0x64, 0xfb, 0xa1, 0xa0, 0x52, 0xb9, 0xb4, 0xec, 0x86, 0xd3, 0xbc,
-	0xb4, 0xec, 0xa6, 0xe3, 0x5c, 0x5a, 0xb6, 0xe3, 0x6c, 0x5f, 0x5a, 0xf6, 0x8e, 0xd3, 0x42, 0x5b,
-	0x23, 0x9a, 0x50, 0x7f, 0xf0, 0xa9, 0x9e, 0x84, 0x6a, 0xe4, 0x2d, 0xe6, 0xe6, 0x37, 0x12, 0x35,
-	0x02, 0x2c, 0x70, 0x32, 0xe2, 0x26, 0x55, 0xc8, 0xd1, 0x09, 0x9c, 0x79, 0xb5, 0x8f, 0xc0, 0xfa,
-	0xb5, 0x90, 0x8d, 0x9b, 0x03, 0x2a, 0xb7, 0x64, 0xa4, 0xbb, 0x11, 0x24, 0x87, 0xb0, 0x05, 0xd6,
-	0x07, 0x38, 0xc9, 0x75, 0x07, 0x58, 0x45, 0x1a, 0x78, 0x57, 0xa0, 0x79, 0xc3, 0x70, 0xc6, 0x71,
-	0x20, 0x62, 0x9a, 0xbd, 0xa1, 0x11, 0x87, 0x10, 0x58, 0xea, 0x55, 0xd4, 0x73, 0xd5, 0x18, 0xfe,
-	0x14, 0x58, 0x09, 0x8d, 0x78, 0x7b, 0x6d, 0xbf, 0x72, 0x50, 0x3b, 0x79, 0xf6, 0xb0, 0x07, 0x7b,
-	0x43, 0x23, 0xa4, 0x42, 0xbc, 0x7f, 0xac, 0x81, 0xca, 0x1b, 0x1a, 0xc1, 0x36, 0xd8, 0xc4, 0x61,
-	0xc8, 0x08, 0xe7, 0x86, 0x69, 0x02, 0xe1, 0x2e, 0xd8, 0x10, 0xb4, 0x1f, 0x07, 0x9a, 0xae, 0x8a,
-	0x0c, 0x92, 0xc2, 0x21, 0x16, 0x58, 0xf5, 0x15, 0x75, 0xa4, 0xc6, 0xf0, 0x04, 0xd4, 0xd5, 0xce,
-	0xfc, 0x2c, 0x4f, 0xbb, 0x84, 0xa9, 0xf6, 0xc0, 0xea, 0x34, 0xef, 0x0b, 0xb7, 0xa6, 0xec, 0x5f,
-	0x2b, 0x33, 0x9a, 0x05, 0xf0, 0x23, 0xb0, 0x29, 0x86, 0xb3, 0x2f, 0xfb, 0xce, 0x7d, 0xe1, 0x36,
-	0xc5, 0x74, 0x9b, 0xf2, 0xe1, 0x46, 0x1b, 0x62, 0xa8, 0x1e, 0xf0, 0x23, 0x60, 0x8b, 0xa1, 0x1f,
-	0x67, 0x21, 0x19, 0xaa, 0xc7, 0xdb, 0xea, 0xb4, 0xee, 0x0b, 0xd7, 0x99, 0x09, 0xbf, 0x90, 0x3e,
-	0xb4, 0x29, 0x86, 0x6a, 0x00, 0x3f, 0x02, 0x40, 0x2f, 0x49, 0x29, 0xe8, 0xa7, 0x77, 0xeb, 0xbe,
-	0x70, 0xab, 0xca, 0xaa, 0xb8, 0xa7, 0x43, 0xe8, 0x81, 0x75, 0xcd, 0x6d, 0x2b, 0xee, 0xfa, 0x7d,
-	0xe1, 0xda, 0x09, 0x8d, 0x34, 0xa7, 0x76, 0xc9, 0x54, 0x31, 0x92, 0xd2, 0x01, 0x09, 0xd5, 0xeb,
-	0x66, 0xa3, 0x09, 0xf4, 0xfe, 0xba, 0x06, 0xec, 0x9b, 0x21, 0x22, 0x3c, 0x4f, 0x04, 0xfc, 0x02,
-	0x38, 0x01, 0xcd, 0x04, 0xc3, 0x81, 0xf0, 0xe7, 0x52, 0xdb, 0x79, 0x31, 0x7d, 0x69, 0x16, 0x23,
-	0x3c, 0xd4, 0x9c, 0x98, 0xce, 0x4c, 0xfe, 0x5b, 0x60, 0xbd, 0x9b, 0x50, 0x9a, 0xaa, 0x4a, 0xa8,
-	0x23, 0x0d, 0x20, 0x52, 0x59, 0x53, 0xa7, 0x5c, 0x51, 0x9d, 0xf6, 0x8f, 0x1f, 0x9e, 0xf2, 0x42,
-	0xa9, 0x74, 0x76, 0x4d, 0xb7, 0xdd, 0xd0, 0xda, 0x66, 0xbe, 0x27, 0x73, 0xab, 0x4a, 0xc9, 0x01,
-	0x15, 0x46, 0x84, 0x3a, 0xb4, 0x3a, 0x92, 0x43, 0xf8, 0x1c, 0xd8, 0x8c, 0x0c, 0x08, 0x13, 0x24,
-	0x54, 0x87, 0x63, 0xa3, 0x12, 0xc3, 0x0f, 0x81, 0x1d, 0x61, 0xee, 0xe7, 0x9c, 0x84, 0xfa, 0x24,
-	0xd0, 0x66, 0x84, 0xf9, 0x37, 0x9c, 0x84, 0x9f, 0x59, 0x7f, 0xfe, 0xde, 0x5d, 0xf1, 0x30, 0xa8,
-	0x9d, 0x05, 0x01, 0xe1, 0xfc, 0x26, 0xef, 0x27, 0xe4, 0xbf, 0x54, 0xd8, 0x09, 0xa8, 0x73, 0x41,
-	0x19, 0x8e, 0x88, 0x7f, 0x4b, 0x46, 0xa6, 0xce, 0x74, 0xd5, 0x18, 0xfb, 0xaf, 0xc8, 0x88, 0xa3,
-	0x59, 0x60, 0x24, 0xbe, 0xb7, 0x40, 0xed, 0x86, 0xe1, 0x80, 0x98, 0x0e, 0x5f, 0xd6, 0xaa, 0x84,
-	0xcc, 0x48, 0x18, 0x24, 0xb5, 0x45, 0x9c, 0x12, 0x9a, 0x0b, 0x73, 0x9f, 0x26, 0x50, 0xce, 0x60,
-	0x84, 0x0c, 0x49, 0xa0, 0xd2, 0x68, 0x21, 0x83, 0xe0, 0x29, 0xd8, 0x0a, 0x63, 0xae, 0x3e, 0x97,
-	0xb8, 0xc0, 0xc1, 0xad, 0xde, 0x7e, 0xc7, 0xb9, 0x2f, 0xdc, 0xba, 0x71, 0x5c, 0x4b, 0x3b, 0x9a,
-	0x43, 0xf0, 0x73, 0xd0, 0x9c, 0x4e, 0x53, 0xab, 0xd5, 0x1f, 0x28, 0x1d, 0x78, 0x5f, 0xb8, 0x8d,
-	0x32, 0x54, 0x79, 0xd0, 0x02, 0x96, 0x27, 0x1d, 0x92, 0x6e, 0x1e, 0xa9, 0xe2, 0xb3, 0x91, 0x06,
-	0xd2, 0x9a, 0xc4, 0x69, 0x2c, 0x54, 0xb1, 0xad, 0x23, 0x0d, 0xe0, 0xe7, 0xa0, 0x4a, 0x07, 0x84,
-	0xb1, 0x38, 0x24, 0x5c, 0xb5, 0x3a, 0xff, 0xeb, 0x5b, 0x0b, 0x4d, 0xe3, 0xe5, 0xe6, 0xcc, 0xa7,
-	0x60, 0x4a, 0x52, 0xca, 0x46, 0xaa, 0x77, 0x31, 0x9b, 0xd3, 0x8e, 0x5f, 0x2b, 0x3b, 0x9a, 0x43,
-	0xb0, 0x03, 0xa0, 0x99, 0xc6, 0x88, 0xc8, 0x59, 0xe6, 0xab, 0xfb, 0x5f, 0x57, 0x73, 0xd5, 0x2d,
-	0xd4, 0x5e, 0xa4, 0x9c, 0xaf, 0xb1, 0xc0, 0xe8, 0x81, 0x05, 0xfe, 0x02, 0x40, 0x7d, 0x26, 0xfe,
-	0x77, 0x9c, 0x96, 0x1f, 0x8b, 0xba, 0xb5, 0x50, 0xfa, 0xda, 0x6b, 0xd6, 0xec, 0x68, 0x74, 0xc9,
-	0xa9, 0xd9, 0xc5, 0xa5, 0x65, 0x5b, 0xce, 0xfa, 0xa5, 0x65, 0x6f, 0x3a, 0x76, 0x99, 0x3f, 0xb3,
-	0x0b, 0xb4, 0x33, 0xc1, 0x33, 0xcb, 0xeb, 0xfc, 0xf2, 0x87, 0xbb, 0xbd, 0xd5, 0x77, 0x77, 0x7b,
-	0xab, 0xff, 0xbe, 0xdb, 0x5b, 0xfd, 0xdb, 0xfb, 0xbd, 0x95, 0x77, 0xef, 0xf7, 0x56, 0xfe, 0xf9,
-	0x7e, 0x6f, 0xe5, 0x77, 0xb3, 0xef, 0x03, 0x19, 0xc8, 0xe7, 0x61, 0xfa, 0xfd, 0x3f, 0x54, 0xff,
-	0x01, 0x50, 0x6f, 0x44, 0x77, 0x43, 0x7d, 0xd9, 0x7f, 0xfa, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0xe2, 0xe1, 0x18, 0x7a, 0x1f, 0x10, 0x00, 0x00,
+	// 1667 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0x4f, 0x6f, 0xe3, 0xb8,
+	0x15, 0x4f, 0x26, 0x4a, 0x22, 0xd3, 0x8a, 0xad, 0x30, 0x9e, 0xac, 0x77, 0x06, 0x8d, 0xa6, 0x3a,
+	0x14, 0x29, 0xb0, 0x9b, 0x6c, 0xb2, 0x08, 0x3a, 0x98, 0x45, 0x8b, 0xc6, 0x33, 0x99, 0xdd, 0xa4,
+	0xb3, 0xdb, 0x80, 0xc9, 0xa2, 0x40, 0x81, 0x42, 0xa0, 0x25, 0x8e, 0xac, 0x8d, 0x24, 0x1a, 0x24,
+	0xe5, 0xb1, 0xdb, 0xa2, 0xe7, 0x16, 0xbd, 0xf4, 0x13, 0x14, 0xfb, 0x71, 0x16, 0x3d, 0xed, 0xb1,
+	0xe8, 0x41, 0x28, 0x32, 0xb7, 0x1c, 0xf3, 0x09, 0x0a, 0xfe, 0xb1, 0xfc, 0x27, 0x41, 0x31, 0xf1,
+	0xc9, 0x7a, 0xbf, 0xf7, 0xf8, 0xfb, 0xf1, 0x3d, 0x3e, 0x86, 0x64, 0xc0, 0x13, 0x22, 0x7a, 0x84,
+	0x65, 0x49, 0x2e, 0xf6, 0xc9, 0x20, 0xdb, 0x1f, 0x1c, 0xc8, 0x9f, 0xbd, 0x3e, 0xa3, 0x82, 0x42,
+	0xb7, 0xf2, 0xed, 0x49, 0x70, 0x70, 0xf0, 0xa4, 0x15, 0xd3, 0x98, 0x2a, 0xe7, 0xbe, 0xfc, 0xd2,
+	0x71, 0xfe, 0xdf, 0x2c, 0xb0, 0x76, 0x8e, 0x19, 0xce, 0x38, 0x3c, 0x00, 0x35, 0x32, 0xc8, 0x82,
+	0x88, 0xe4, 0x34, 0x6b, 0x2f, 0x3f, 0x5b, 0xde, 0xad, 0x75, 0x5a, 0xb7, 0xa5, 0xe7, 0x8e, 0x70,
+	0x96, 0xbe, 0xf0, 0x2b, 0x97, 0x8f, 0x6c, 0x32, 0xc8, 0x5e, 0xc9, 0x4f, 0xf8, 0x4b, 0xb0, 0x41,
+	0x72, 0xdc, 0x4d, 0x49, 0x10, 0x32, 0x82, 0x05, 0x69, 0x3f, 0x7a, 0xb6, 0xbc, 0x6b, 0x77, 0xda,
+	0xb7, 0xa5, 0xd7, 0x32, 0xc3, 0xa6, 0xdd, 0x3e, 0x72, 0xb4, 0xfd, 0x52, 0x99, 0xf0, 0x17, 0xa0,
+	0x3e, 0xf6, 0xe3, 0x34, 0x6d, 0xaf, 0xa8, 0xc1, 0xdb, 0xb7, 0xa5, 0x07, 0x67, 0x07, 0xe3, 0x34,
+	0xf5, 0x11, 0x30, 0x43, 0x71, 0x9a, 0xc2, 0x63, 0x00, 0xc8, 0x50, 0x30, 0x1c, 0x90, 0xa4, 0xcf,
+	0xdb, 0xd6, 0xb3, 0x95, 0xdd, 0x95, 0x8e, 0x7f, 0x5d, 0x7a, 0xb5, 0x13, 0x89, 0x9e, 0x9c, 0x9e,
+	0xf3, 0xdb, 0xd2, 0xdb, 0x34, 0x24, 0x55, 0xa0, 0x8f, 0x6a, 0xca, 0x38, 0x49, 0xfa, 0x1c, 0xfe,
+	0x01, 0x38, 0x61, 0x0f, 0x27, 0x79, 0x10, 0xd2, 0xfc, 0x6d, 0x12, 0xb7, 0x57, 0x9f, 0x2d, 0xef,
+	0xd6, 0x0f, 0x7f, 0xb2, 0x37, 0x5f, 0xb7, 0xbd, 0x97, 0x32, 0xea, 0xa5, 0x0a, 0xea, 0x3c, 0xfd,
+	0xa1, 0xf4, 0x96, 0x6e, 0x4b, 0x6f, 0x4b, 0x53, 0x4f, 0x13, 0xf8, 0xa8, 0x1e, 0x4e, 0x22, 0xe1,
+	0x21, 0x78, 0x8c, 0xd3, 0x94, 0xbe, 0x0b, 0x8a, 0x5c, 0x16, 0x9a, 0x84, 0x82, 0x44, 0x81, 0x18,
+	0xf2, 0xf6, 0x9a, 0x4c, 0x12, 0x6d, 0x29, 0xe7, 0xb7, 0x13, 0xdf, 0xe5, 0x90, 0xc3, 0xbf, 0x80,
+	0x16, 0x17, 0x94, 0xe1, 0x98, 0x04, 0x6f, 0x09, 0x09, 0xfa, 0x84, 0x05, 0x3c, 0xa5, 0xa2, 0xbd,
+	0xae, 0xd6, 0xe2, 0x6b, 0xa9, 0xfd, 0x9f, 0xd2, 0xfb, 0x59, 0x9c, 0x88, 0x5e, 0xd1, 0xdd, 0x0b,
+	0x69, 0xb6, 0x1f, 0x52, 0x9e, 0x51, 0x6e, 0x7e, 0x3e, 0xe5, 0xd1, 0xd5, 0xbe, 0x18, 0xf5, 0x09,
+	0xdf, 0x3b, 0xcd, 0xc5, 0x6d, 0xe9, 0x3d, 0xd5, 0xb3, 0xbc, 0x8f, 0xd3, 0x47, 0x9b, 0x06, 0x7e,
+	0x4d, 0xc8, 0x39, 0x61, 0x17, 0x12, 0x7b, 0x01, 0x9c, 0x0b, 0x0d, 0x5e, 0x08, 0x2c, 0x38, 0x6c,
+	0x81, 0x55, 0x19, 0xcb, 0x55, 0x33, 0x58, 0x48, 0x1b, 0x12, 0xed, 0x8e, 0x04, 0xe1, 0x6a, 0xad,
+	0x2d, 0xa4, 0x0d, 0xff, 0x9f, 0x9b, 0xa0, 0x3e, 0x55, 0x29, 0x98, 0x81, 0x66, 0x8f, 0x66, 0x84,
+	0x0b, 0x82, 0xa3, 0xa0, 0x9b, 0xd2, 0xf0, 0xca, 0xb4, 0xd4, 0xab, 0x07, 0xa5, 0xb0, 0xad, 0x53,
+	0x98, 0xa3, 0xf2, 0x51, 0xa3, 0x42, 0x3a, 0x12, 0x80, 0x23, 0xd0, 0x88, 0x30, 0x0d, 0xde, 0x52,
+	0x76, 0x65, 0xd4, 0x1e, 0x29, 0xb5, 0x8b, 0x0f, 0x57, 0xbb, 0x2e, 0x3d, 0xe7, 0xd5, 0xf1, 0x6f,
+	0x5f, 0x53, 0x76, 0xa5, 0x38, 0x6f, 0x4b, 0xef, 0xb1, 0x56, 0x9f, 0x65, 0xf6, 0x91, 0x13, 0x61,
+	0x5a, 0x85, 0xc1, 0xdf, 0x01, 0xb7, 0x0a, 0xe0, 0x45, 0xbf, 0x4f, 0x99, 0x30, 0x9d, 0xfc, 0xe9,
+	0x75, 0xe9, 0x35, 0x0c, 0xe5, 0x85, 0xf6, 0xdc, 0x96, 0xde, 0x47, 0x73, 0xa4, 0x66, 0x8c, 0x8f,
+	0x1a, 0x86, 0xd6, 0x84, 0x42, 0x0e, 0x1c, 0x92, 0xf4, 0x0f, 0x8e, 0x3e, 0x33, 0x19, 0x59, 0x2a,
+	0xa3, 0xf3, 0x07, 0x65, 0x54, 0x3f, 0x39, 0x3d, 0x3f, 0x38, 0xfa, 0x6c, 0x9c, 0x90, 0xe9, 0xdb,
+	0x69, 0x5a, 0x1f, 0xd5, 0xb5, 0xa9, 0xb3, 0x39, 0x05, 0xc6, 0x0c, 0x7a, 0x98, 0xf7, 0xd4, 0xae,
+	0xa8, 0x75, 0x76, 0xaf, 0x4b, 0x0f, 0x68, 0xa6, 0xaf, 0x30, 0xef, 0x4d, 0xd6, 0xa5, 0x3b, 0xfa,
+	0x23, 0xce, 0x45, 0x52, 0x64, 0x63, 0x2e, 0xa0, 0x07, 0xcb, 0xa8, 0x6a, 0xfe, 0x47, 0x66, 0xfe,
+	0x6b, 0x0b, 0xcf, 0xff, 0xe8, 0xbe, 0xf9, 0x1f, 0xcd, 0xce, 0x5f, 0xc7, 0x54, 0xa2, 0xcf, 0x8d,
+	0xe8, 0xfa, 0xc2, 0xa2, 0xcf, 0xef, 0x13, 0x7d, 0x3e, 0x2b, 0xaa, 0x63, 0x64, 0xb3, 0xcf, 0x55,
+	0xa2, 0x6d, 0x2f, 0xde, 0xec, 0x77, 0x8a, 0xda, 0xa8, 0x10, 0x2d, 0xf7, 0x67, 0xd0, 0x0a, 0x69,
+	0xce, 0x85, 0xc4, 0x72, 0xda, 0x4f, 0x89, 0xd1, 0xac, 0x29, 0xcd, 0xd3, 0x45, 0xfe, 0x46, 0xdc,
+	0xc7, 0xe7, 0xa3, 0xad, 0x59, 0x58, 0xab, 0xf7, 0x81, 0xdb, 0x27, 0x82, 0x30, 0xde, 0x2d, 0x58,
+	0x6c, 0x94, 0x81, 0x52, 0x3e, 0x79, 0x90, 0xb2, 0xd9, 0x07, 0xf3, 0x5c, 0x3e, 0x6a, 0x4e, 0x20,
+	0xad, 0xf8, 0x1d, 0x68, 0x24, 0x72, 0x1a, 0xdd, 0x22, 0x35, 0x7a, 0x75, 0xa5, 0xf7, 0xf2, 0x41,
+	0x7a, 0x66, 0x33, 0xcf, 0x32, 0xf9, 0x68, 0x63, 0x0c, 0x68, 0xad, 0x02, 0xc0, 0xac, 0x48, 0x58,
+	0x10, 0xa7, 0x38, 0x4c, 0x08, 0x33, 0x7a, 0x8e, 0xd2, 0xfb, 0xf2, 0x41, 0x7a, 0x1f, 0x6b, 0xbd,
+	0xbb, 0x6c, 0x3e, 0x72, 0x25, 0xf8, 0xa5, 0xc6, 0xb4, 0x6c, 0x04, 0x9c, 0x2e, 0x61, 0x69, 0x92,
+	0x1b, 0xc1, 0x0d, 0x25, 0x78, 0xfc, 0x20, 0x41, 0xd3, 0xa7, 0xd3, 0x3c, 0x3e, 0xaa, 0x6b, 0xb3,
+	0x52, 0x49, 0x69, 0x1e, 0xd1, 0xb1, 0xca, 0xe6, 0xe2, 0x2a, 0xd3, 0x3c, 0x3e, 0xaa, 0x6b, 0x53,
+	0xab, 0x0c, 0xc1, 0x16, 0x66, 0x8c, 0xbe, 0x9b, 0xab, 0x21, 0x54, 0x62, 0x5f, 0x3d, 0x48, 0xec,
+	0x89, 0x16, 0xbb, 0x87, 0xce, 0x47, 0x9b, 0x0a, 0x9d, 0xa9, 0x62, 0x01, 0x60, 0xcc, 0xf0, 0x68,
+	0x4e, 0xb8, 0xb5, 0xf8, 0xe2, 0xdd, 0x65, 0xf3, 0x91, 0x2b, 0xc1, 0x19, 0xd9, 0x3f, 0x81, 0x56,
+	0x46, 0x58, 0x4c, 0x82, 0x9c, 0x08, 0xde, 0x4f, 0x13, 0x61, 0x84, 0x1f, 0x2f, 0xbe, 0x1f, 0xef,
+	0xe3, 0xf3, 0x11, 0x54, 0xf0, 0x37, 0x06, 0xad, 0x36, 0x07, 0xef, 0xe1, 0x3c, 0xee, 0xe1, 0xc4,
+	0xc8, 0x6e, 0x2f, 0xbe, 0x39, 0x66, 0x99, 0x7c, 0xb4, 0x31, 0x06, 0xaa, 0xfe, 0x09, 0x71, 0x1e,
+	0x16, 0xe3, 0xfe, 0xf9, 0x68, 0xf1, 0xfe, 0x99, 0xe6, 0x91, 0x57, 0x27, 0x65, 0x2a, 0x95, 0x33,
+	0xcb, 0x6e, 0xb8, 0xcd, 0x33, 0xcb, 0x6e, 0xba, 0xee, 0x99, 0x65, 0xbb, 0xee, 0xe6, 0x99, 0x65,
+	0x6f, 0xb9, 0x2d, 0xb4, 0x31, 0xa2, 0x29, 0x0d, 0x06, 0x9f, 0xeb, 0x41, 0xa8, 0x4e, 0xde, 0x61,
+	0x6e, 0xfe, 0x46, 0xa2, 0x46, 0x88, 0x05, 0x4e, 0x47, 0xdc, 0x94, 0x0a, 0xb9, 0xba, 0x80, 0x53,
+	0xa7, 0xf6, 0x3e, 0x58, 0x95, 0xb7, 0x1a, 0x02, 0x5d, 0xb0, 0x72, 0x45, 0x46, 0xfa, 0x36, 0x82,
+	0xe4, 0xa7, 0xbc, 0xd1, 0x0c, 0x70, 0x5a, 0xe8, 0xdb, 0x6b, 0x0d, 0x69, 0xc3, 0x3f, 0x07, 0xcd,
+	0x4b, 0x86, 0x73, 0x8e, 0x43, 0x91, 0xd0, 0xfc, 0x0d, 0x8d, 0x39, 0x84, 0xc0, 0x52, 0xa7, 0xa2,
+	0x1e, 0xab, 0xbe, 0xe1, 0xcf, 0x81, 0x95, 0xd2, 0x58, 0xde, 0x86, 0x56, 0x76, 0xeb, 0x87, 0x8f,
+	0xef, 0xde, 0x1f, 0xdf, 0xd0, 0x18, 0xa9, 0x10, 0xff, 0x5f, 0x8f, 0xc0, 0xca, 0x1b, 0x1a, 0xc3,
+	0x36, 0x58, 0xc7, 0x51, 0xc4, 0x08, 0xe7, 0x86, 0x69, 0x6c, 0xc2, 0x6d, 0xb0, 0x26, 0x68, 0x3f,
+	0x09, 0x35, 0x5d, 0x0d, 0x19, 0x4b, 0x0a, 0x47, 0x58, 0x60, 0x75, 0xaf, 0x70, 0x90, 0xfa, 0x86,
+	0x87, 0xc0, 0x51, 0x99, 0x05, 0x79, 0x91, 0x75, 0x09, 0x53, 0xd7, 0x03, 0xab, 0xd3, 0xbc, 0x29,
+	0xbd, 0xba, 0xc2, 0xbf, 0x51, 0x30, 0x9a, 0x36, 0xe0, 0x27, 0x60, 0x5d, 0x0c, 0xa7, 0x4f, 0xf6,
+	0xad, 0x9b, 0xd2, 0x6b, 0x8a, 0x49, 0x9a, 0xf2, 0xe0, 0x46, 0x6b, 0x62, 0x28, 0x7f, 0xe1, 0x3e,
+	0xb0, 0xc5, 0x30, 0x48, 0xf2, 0x88, 0x0c, 0xd5, 0xe1, 0x6d, 0x75, 0x5a, 0x37, 0xa5, 0xe7, 0x4e,
+	0x85, 0x9f, 0x4a, 0x1f, 0x5a, 0x17, 0x43, 0xf5, 0x01, 0x3f, 0x01, 0x40, 0x4f, 0x49, 0x29, 0xe8,
+	0xa3, 0x77, 0xe3, 0xa6, 0xf4, 0x6a, 0x0a, 0x55, 0xdc, 0x93, 0x4f, 0xe8, 0x83, 0x55, 0xcd, 0x6d,
+	0x2b, 0x6e, 0xe7, 0xa6, 0xf4, 0xec, 0x94, 0xc6, 0x9a, 0x53, 0xbb, 0x64, 0xa9, 0x18, 0xc9, 0xe8,
+	0x80, 0x44, 0xea, 0x74, 0xb3, 0xd1, 0xd8, 0xf4, 0xff, 0xfe, 0x08, 0xd8, 0x97, 0x43, 0x44, 0x78,
+	0x91, 0x0a, 0xf8, 0x1a, 0xb8, 0x21, 0xcd, 0x05, 0xc3, 0xa1, 0x08, 0x66, 0x4a, 0xdb, 0x79, 0x3a,
+	0x39, 0x69, 0xe6, 0x23, 0x7c, 0xd4, 0x1c, 0x43, 0xc7, 0xa6, 0xfe, 0xf2, 0x6e, 0x9b, 0x52, 0x9a,
+	0xa9, 0x4e, 0x70, 0x90, 0x36, 0x20, 0x52, 0x55, 0x53, 0xab, 0xbc, 0xa2, 0x5e, 0x09, 0x3f, 0xbd,
+	0xbb, 0xca, 0x73, 0xad, 0xd2, 0xd9, 0x36, 0x2f, 0x85, 0x86, 0xd6, 0x36, 0xe3, 0x7d, 0x59, 0x5b,
+	0xd5, 0x4a, 0x2e, 0x58, 0x61, 0x44, 0xa8, 0x45, 0x73, 0x90, 0xfc, 0x84, 0x4f, 0x80, 0xcd, 0xc8,
+	0x80, 0x30, 0x41, 0x22, 0xb5, 0x38, 0x36, 0xaa, 0x6c, 0xf8, 0x31, 0xb0, 0x63, 0xcc, 0x83, 0x82,
+	0x93, 0x48, 0xaf, 0x04, 0x5a, 0x8f, 0x31, 0xff, 0x96, 0x93, 0xe8, 0x85, 0xf5, 0xd7, 0xef, 0xbd,
+	0x25, 0x1f, 0x83, 0xfa, 0x71, 0x18, 0x12, 0xce, 0x2f, 0x8b, 0x7e, 0x4a, 0xfe, 0x4f, 0x87, 0x1d,
+	0x02, 0x67, 0xfc, 0x1e, 0xb8, 0x22, 0x23, 0xd3, 0x67, 0xba, 0x6b, 0x0c, 0xfe, 0x1b, 0x32, 0xe2,
+	0x68, 0xda, 0x30, 0x12, 0xdf, 0x5b, 0xa0, 0x7e, 0xc9, 0x70, 0x48, 0xcc, 0x0d, 0x5f, 0xf6, 0xaa,
+	0x34, 0x99, 0x91, 0x30, 0x96, 0xd4, 0x16, 0x49, 0x46, 0x68, 0x21, 0xcc, 0x7e, 0x1a, 0x9b, 0x72,
+	0x04, 0x23, 0x64, 0x48, 0x42, 0x55, 0x46, 0x0b, 0x19, 0x0b, 0x1e, 0x81, 0x8d, 0x28, 0xe1, 0xea,
+	0xa9, 0xc7, 0x05, 0x0e, 0xaf, 0x74, 0xfa, 0x1d, 0xf7, 0xa6, 0xf4, 0x1c, 0xe3, 0xb8, 0x90, 0x38,
+	0x9a, 0xb1, 0xe0, 0x17, 0xa0, 0x39, 0x19, 0xa6, 0x66, 0xab, 0x1f, 0x57, 0x1d, 0x78, 0x53, 0x7a,
+	0x8d, 0x2a, 0x54, 0x79, 0xd0, 0x9c, 0x2d, 0x57, 0x3a, 0x22, 0xdd, 0x22, 0x56, 0xcd, 0x67, 0x23,
+	0x6d, 0x48, 0x34, 0x4d, 0xb2, 0x44, 0xa8, 0x66, 0x5b, 0x45, 0xda, 0x80, 0x5f, 0x80, 0x1a, 0x1d,
+	0x10, 0xc6, 0x92, 0x88, 0xf0, 0x36, 0xf8, 0x80, 0x77, 0x22, 0x9a, 0xc4, 0xcb, 0xe4, 0xcc, 0x33,
+	0x36, 0x23, 0x19, 0x65, 0xa3, 0x76, 0x7d, 0x92, 0x9c, 0x76, 0x7c, 0xad, 0x70, 0x34, 0x63, 0xc1,
+	0x0e, 0x80, 0x66, 0x18, 0x23, 0xa2, 0x60, 0x79, 0xa0, 0xf6, 0xbf, 0xa3, 0xc6, 0xaa, 0x5d, 0xa8,
+	0xbd, 0x48, 0x39, 0x5f, 0x61, 0x81, 0xd1, 0x1d, 0x04, 0xfe, 0x0a, 0x40, 0xbd, 0x26, 0xc1, 0x77,
+	0x9c, 0x56, 0x0f, 0x5d, 0x7d, 0xb5, 0x50, 0xfa, 0xda, 0x6b, 0xe6, 0xec, 0x6a, 0xeb, 0x8c, 0x53,
+	0x93, 0xc5, 0x99, 0x65, 0x5b, 0xee, 0xea, 0x99, 0x65, 0xaf, 0xbb, 0x76, 0x55, 0x3f, 0x93, 0x05,
+	0xda, 0x1a, 0xdb, 0x53, 0xd3, 0xeb, 0xfc, 0xfa, 0x87, 0xeb, 0x9d, 0xe5, 0x1f, 0xaf, 0x77, 0x96,
+	0xff, 0x7b, 0xbd, 0xb3, 0xfc, 0x8f, 0xf7, 0x3b, 0x4b, 0x3f, 0xbe, 0xdf, 0x59, 0xfa, 0xf7, 0xfb,
+	0x9d, 0xa5, 0xdf, 0x4f, 0x9f, 0x0f, 0x64, 0x20, 0x8f, 0x87, 0xc9, 0xff, 0x2e, 0x86, 0x12, 0xd1,
+	0x67, 0x44, 0x77, 0x4d, 0xfd, 0x57, 0xe2, 0xf3, 0xff, 0x0d, 0x00, 0xc6, 0xa5, 0x88, 0x0f, 0xdb,
+	0x10, 0x00, 0x00,
 }
 
 func (m *Params) Marshal() (dAtA []byte, err error) {
@@ -813,6 +878,16 @@ func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	{
+		size := m.StorageFeePerSlot.Size()
+		i -= size
+		if _, err := m.StorageFeePerSlot.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintEvm(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x3a
 	if m.AllowUnprotectedTxs {
 		i--
 		if m.AllowUnprotectedTxs {
@@ -882,6 +957,39 @@ func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *StorageStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StorageStats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StorageStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Bytes != 0 {
+		i = encodeVarintEvm(dAtA, i, uint64(m.Bytes))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Slots != 0 {
+		i = encodeVarintEvm(dAtA, i, uint64(m.Slots))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *ChainConfig) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1567,6 +1675,23 @@ func (m *Params) Size() (n int) {
 	if m.AllowUnprotectedTxs {
 		n += 2
 	}
+	l = m.StorageFeePerSlot.Size()
+	n += 1 + l + sovEvm(uint64(l))
+	return n
+}
+
+func (m *StorageStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Slots != 0 {
+		n += 1 + sovEvm(uint64(m.Slots))
+	}
+	if m.Bytes != 0 {
+		n += 1 + sovEvm(uint64(m.Bytes))
+	}
 	return n
 }
 
@@ -2063,6 +2188,128 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.AllowUnprotectedTxs = bool(v != 0)
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StorageFeePerSlot", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvm
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.StorageFeePerSlot.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvm(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvm
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StorageStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvm
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StorageStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StorageStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Slots", wireType)
+			}
+			m.Slots = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Slots |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bytes", wireType)
+			}
+			m.Bytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Bytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvm(dAtA[iNdEx:])
@@ -16,6 +16,7 @@
 package types
 
 import (
+	"context"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"math/big"
 
@@ -42,6 +43,7 @@ type AccountKeeper interface {
 	SetAccount(ctx sdk.Context, account authtypes.AccountI)
 	RemoveAccount(ctx sdk.Context, account authtypes.AccountI)
 	GetParams(ctx sdk.Context) (params authtypes.Params)
+	Accounts(ctx context.Context, req *authtypes.QueryAccountsRequest) (*authtypes.QueryAccountsResponse, error)
 }
 
 // BankKeeper defines the expected interface needed to retrieve account balances.
@@ -47,6 +47,7 @@ const (
 	prefixParams
 	prefixVirtualFrontierContract
 	prefixVirtualFrontierBankContractAddressByDenom
+	prefixStorageStats
 )
 
 // prefix bytes for the EVM transient store
@@ -55,6 +56,7 @@ const (
 	prefixTransientTxIndex
 	prefixTransientLogSize
 	prefixTransientGasUsed
+	prefixTransientTxTrace
 )
 
 // VirtualFrontierContractDeployerAddress is address for deploying virtual frontier contract
@@ -71,6 +73,7 @@ var (
 	KeyPrefixParams                                    = []byte{prefixParams}
 	KeyPrefixVirtualFrontierContract                   = []byte{prefixVirtualFrontierContract}
 	KeyPrefixVirtualFrontierBankContractAddressByDenom = []byte{prefixVirtualFrontierBankContractAddressByDenom}
+	KeyPrefixStorageStats                              = []byte{prefixStorageStats}
 )
 
 // Transient Store key prefixes
@@ -79,6 +82,7 @@ var (
 	KeyPrefixTransientTxIndex = []byte{prefixTransientTxIndex}
 	KeyPrefixTransientLogSize = []byte{prefixTransientLogSize}
 	KeyPrefixTransientGasUsed = []byte{prefixTransientGasUsed}
+	KeyPrefixTransientTxTrace = []byte{prefixTransientTxTrace}
 )
 
 // AddressStoragePrefix returns a prefix to iterate over a given account storage.
@@ -91,6 +95,11 @@ func StateKey(address common.Address, key []byte) []byte {
 	return append(AddressStoragePrefix(address), key...)
 }
 
+// StorageStatsKey returns the key under which the storage stats of a contract are stored.
+func StorageStatsKey(address common.Address) []byte {
+	return append(KeyPrefixStorageStats, address.Bytes()...)
+}
+
 // VirtualFrontierContractKey returns a key for specific virtual frontier contract
 func VirtualFrontierContractKey(contractAddress common.Address) []byte {
 	return append(KeyPrefixVirtualFrontierContract, contractAddress.Bytes()...)
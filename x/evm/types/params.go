@@ -34,6 +34,8 @@ var (
 	DefaultEnableCreate = true
 	// DefaultEnableCall enables contract calls (i.e true)
 	DefaultEnableCall = true
+	// DefaultStorageFeePerSlot disables the storage fee (i.e 0)
+	DefaultStorageFeePerSlot = sdk.ZeroInt()
 )
 
 // AvailableExtraEIPs define the list of all EIPs that can be enabled by the
@@ -65,6 +67,7 @@ func DefaultParams() Params {
 		ChainConfig:         DefaultChainConfig(),
 		ExtraEIPs:           nil,
 		AllowUnprotectedTxs: DefaultAllowUnprotectedTxs,
+		StorageFeePerSlot:   DefaultStorageFeePerSlot,
 	}
 }
 
@@ -90,6 +93,10 @@ func (p Params) Validate() error {
 		return err
 	}
 
+	if err := validateStorageFeePerSlot(p.StorageFeePerSlot); err != nil {
+		return err
+	}
+
 	return validateChainConfig(p.ChainConfig)
 }
 
@@ -134,6 +141,20 @@ func validateEIPs(i interface{}) error {
 	return nil
 }
 
+func validateStorageFeePerSlot(i interface{}) error {
+	fee, ok := i.(sdk.Int)
+	if !ok {
+		return fmt.Errorf("invalid storage fee per slot type: %T", i)
+	}
+
+	// an empty value disables the storage fee
+	if !fee.IsNil() && fee.IsNegative() {
+		return fmt.Errorf("storage fee per slot cannot be negative: %s", fee)
+	}
+
+	return nil
+}
+
 func validateChainConfig(i interface{}) error {
 	cfg, ok := i.(ChainConfig)
 	if !ok {
@@ -16,6 +16,7 @@
 package types
 
 import (
+	"encoding/json"
 	"math/big"
 	"os"
 	"time"
@@ -23,6 +24,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/params"
@@ -60,8 +62,58 @@ func NewTracer(tracer string, msg core.Message, cfg *params.ChainConfig, height
 
 // TxTraceResult is the result of a single transaction trace during a block trace.
 type TxTraceResult struct {
-	Result interface{} `json:"result,omitempty"` // Trace results produced by the tracer
-	Error  string      `json:"error,omitempty"`  // Trace failure produced by the tracer
+	Result     interface{}      `json:"result,omitempty"`     // Trace results produced by the tracer
+	Error      string           `json:"error,omitempty"`      // Trace failure produced by the tracer
+	Divergence *TraceDivergence `json:"divergence,omitempty"` // Set if the replay doesn't match the receipt
+}
+
+// TraceDivergence reports a replayed transaction whose outcome doesn't match the committed receipt.
+// The tracer only replays the EVM transactions of a block, so the state changes made by the Cosmos
+// messages executed before the transaction (e.g. bank sends, IBC transfers) are missing from the replay.
+type TraceDivergence struct {
+	ReceiptGasUsed hexutil.Uint64 `json:"receiptGasUsed"`
+	ReceiptFailed  bool           `json:"receiptFailed"`
+	ReplayGasUsed  hexutil.Uint64 `json:"replayGasUsed"`
+	ReplayFailed   bool           `json:"replayFailed"`
+	// CosmosMsgs is the number of non-EVM messages included in the block before the transaction
+	CosmosMsgs uint64 `json:"cosmosMsgs"`
+}
+
+// NewTraceDivergence compares the replay outcome with the committed receipt,
+// it returns nil if they match.
+func NewTraceDivergence(receiptGasUsed uint64, receiptFailed bool, replay TraceReplayResult, cosmosMsgs uint64) *TraceDivergence {
+	if receiptGasUsed == replay.GasUsed && receiptFailed == replay.Failed {
+		return nil
+	}
+	return &TraceDivergence{
+		ReceiptGasUsed: hexutil.Uint64(receiptGasUsed),
+		ReceiptFailed:  receiptFailed,
+		ReplayGasUsed:  hexutil.Uint64(replay.GasUsed),
+		ReplayFailed:   replay.Failed,
+		CosmosMsgs:     cosmosMsgs,
+	}
+}
+
+// BlockTxTrace is the execution trace of a transaction delivered in a block, as written to the block trace files.
+type BlockTxTrace struct {
+	BlockHeight int64           `json:"blockHeight"`
+	BlockHash   common.Hash     `json:"blockHash"`
+	TxIndex     uint64          `json:"txIndex"`
+	TxHash      common.Hash     `json:"txHash"`
+	From        common.Address  `json:"from"`
+	To          *common.Address `json:"to"`
+	GasUsed     hexutil.Uint64  `json:"gasUsed"`
+	Status      hexutil.Uint64  `json:"status"`
+	VmError     string          `json:"vmError,omitempty"`
+	Result      json.RawMessage `json:"result,omitempty"` // Trace results produced by the tracer
+	Error       string          `json:"error,omitempty"`  // Trace failure produced by the tracer
+}
+
+// BlockTraceWriter receives the execution traces of the transactions delivered in a block,
+// they are only persisted once the block is committed.
+type BlockTraceWriter interface {
+	// AddBlock sets the JSON encoded traces of the block being delivered, replacing the previous ones of the height.
+	AddBlock(height int64, traces [][]byte)
 }
 
 var _ vm.EVMLogger = &NoOpTracer{}
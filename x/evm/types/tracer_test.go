@@ -9,3 +9,17 @@ import (
 func TestNewNoOpTracer(t *testing.T) {
 	require.Equal(t, &NoOpTracer{}, NewNoOpTracer())
 }
+
+func TestNewTraceDivergence(t *testing.T) {
+	require.Nil(t, NewTraceDivergence(21000, false, TraceReplayResult{GasUsed: 21000}, 1))
+	require.Equal(t, &TraceDivergence{
+		ReceiptGasUsed: 21000,
+		ReplayGasUsed:  21000,
+		ReplayFailed:   true,
+		CosmosMsgs:     1,
+	}, NewTraceDivergence(21000, false, TraceReplayResult{GasUsed: 21000, Failed: true}, 1))
+	require.Equal(t, &TraceDivergence{
+		ReceiptGasUsed: 30000,
+		ReplayGasUsed:  21000,
+	}, NewTraceDivergence(30000, false, TraceReplayResult{GasUsed: 21000}, 0))
+}
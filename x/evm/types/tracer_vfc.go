@@ -0,0 +1,236 @@
+// Copyright 2021 Evmos Foundation
+// This file is part of Evmos' Ethermint library.
+//
+// The Ethermint library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The Ethermint library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the Ethermint library. If not, see https://github.com/evmos/ethermint/blob/main/LICENSE
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+)
+
+const (
+	// TracerPrestate is the name of the native prestate tracer
+	TracerPrestate = "prestateTracer"
+	// TracerCall is the name of the native call tracer
+	TracerCall = "callTracer"
+)
+
+// VFCTracer is implemented by the tracers capturing the effects of the Virtual Frontier Contract calls.
+// The calls are executed by the keeper instead of the EVM, so their bank balance changes and logs
+// are not observed through the vm.EVMLogger hooks.
+type VFCTracer interface {
+	// CaptureVFCBalanceChange is called after a Virtual Frontier Contract call changed a bank balance
+	CaptureVFCBalanceChange(change VFCBalanceChange)
+	// CaptureVFCLog is called after a Virtual Frontier Contract call emitted a log
+	CaptureVFCLog(log *ethtypes.Log)
+}
+
+// VFCBalanceChange is a bank balance change made by a Virtual Frontier Bank Contract call.
+type VFCBalanceChange struct {
+	Contract common.Address
+	Account  common.Address
+	Denom    string
+	// IsEVMDenom is set if the denom is the EVM denom, then the change is an account balance change as well
+	IsEVMDenom bool
+	Prev       *big.Int
+	Post       *big.Int
+}
+
+// VFBCBalanceSlot returns the synthetic storage slot holding the balance of the account in a
+// Virtual Frontier Bank Contract. It's the slot of `balances[account]` of an ERC-20 contract
+// declaring `mapping(address => uint256) balances` as its first state variable.
+func VFBCBalanceSlot(account common.Address) common.Hash {
+	return crypto.Keccak256Hash(common.BytesToHash(account.Bytes()).Bytes(), common.Hash{}.Bytes())
+}
+
+var (
+	_ tracers.Tracer = &VFCEffectsTracer{}
+	_ VFCTracer      = &VFCEffectsTracer{}
+)
+
+// VFCEffectsTracer wraps a tracer to merge the effects of the Virtual Frontier Contract calls into its result:
+//   - prestateTracer: the accounts whose balance changed are added, and the contract storage holds
+//     the previous balances at the synthetic balance slots (see VFBCBalanceSlot).
+//   - callTracer: the logs are added to the top call frame when `withLog` is enabled.
+//
+// The other tracers observe the Virtual Frontier Contract calls as a top call frame without execution steps.
+type VFCEffectsTracer struct {
+	tracers.Tracer
+
+	name    string
+	withLog bool
+	env     *vm.EVM
+
+	// previous state of the accounts whose balance changed, in order of the first change
+	accounts []common.Address
+	prestate map[common.Address]*vfcAccountState
+	// previous balances of the accounts in the contracts, in order of the first change
+	slots []vfcSlot
+	logs  []*ethtypes.Log
+}
+
+type vfcAccountState struct {
+	Balance string                      `json:"balance"`
+	Nonce   uint64                      `json:"nonce"`
+	Code    string                      `json:"code"`
+	Storage map[common.Hash]common.Hash `json:"storage"`
+}
+
+type vfcSlot struct {
+	contract common.Address
+	slot     common.Hash
+	value    common.Hash
+}
+
+type vfcCallLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+// NewVFCEffectsTracer wraps the tracer, name and config are the ones used to create the tracer.
+func NewVFCEffectsTracer(tracer tracers.Tracer, name string, config json.RawMessage) *VFCEffectsTracer {
+	t := &VFCEffectsTracer{
+		Tracer:   tracer,
+		name:     name,
+		prestate: make(map[common.Address]*vfcAccountState),
+	}
+	if name == TracerCall && len(config) > 0 {
+		var cfg struct {
+			WithLog bool `json:"withLog"`
+		}
+		if err := json.Unmarshal(config, &cfg); err == nil {
+			t.withLog = cfg.WithLog
+		}
+	}
+	return t
+}
+
+// CaptureStart implements vm.EVMLogger interface
+func (t *VFCEffectsTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.env = env
+	t.Tracer.CaptureStart(env, from, to, create, input, gas, value)
+}
+
+// CaptureVFCBalanceChange implements VFCTracer interface
+func (t *VFCEffectsTracer) CaptureVFCBalanceChange(change VFCBalanceChange) {
+	if _, found := t.prestate[change.Account]; !found && t.env != nil {
+		balance := t.env.StateDB.GetBalance(change.Account)
+		if change.IsEVMDenom {
+			// the bank balance is changed outside of the state db
+			balance = change.Prev
+		}
+		t.accounts = append(t.accounts, change.Account)
+		t.prestate[change.Account] = &vfcAccountState{
+			Balance: hexutil.EncodeBig(balance),
+			Nonce:   t.env.StateDB.GetNonce(change.Account),
+			Code:    hexutil.Encode(t.env.StateDB.GetCode(change.Account)),
+			Storage: make(map[common.Hash]common.Hash),
+		}
+	}
+
+	slot := VFBCBalanceSlot(change.Account)
+	for _, s := range t.slots {
+		if s.contract == change.Contract && s.slot == slot {
+			return
+		}
+	}
+	t.slots = append(t.slots, vfcSlot{
+		contract: change.Contract,
+		slot:     slot,
+		value:    common.BigToHash(change.Prev),
+	})
+}
+
+// CaptureVFCLog implements VFCTracer interface
+func (t *VFCEffectsTracer) CaptureVFCLog(log *ethtypes.Log) {
+	t.logs = append(t.logs, log)
+}
+
+// GetResult implements tracers.Tracer interface, it merges the captured effects into the result of the wrapped tracer.
+func (t *VFCEffectsTracer) GetResult() (json.RawMessage, error) {
+	res, err := t.Tracer.GetResult()
+	if err != nil || len(res) == 0 {
+		return res, err
+	}
+
+	switch {
+	case t.name == TracerPrestate && len(t.slots) > 0:
+		return t.mergePrestate(res)
+	case t.name == TracerCall && t.withLog && len(t.logs) > 0:
+		return t.mergeCallLogs(res)
+	default:
+		return res, nil
+	}
+}
+
+func (t *VFCEffectsTracer) mergePrestate(res json.RawMessage) (json.RawMessage, error) {
+	prestate := make(map[common.Address]*vfcAccountState)
+	if err := json.Unmarshal(res, &prestate); err != nil {
+		return nil, err
+	}
+
+	// the accounts captured by the wrapped tracer already hold their previous state
+	for _, addr := range t.accounts {
+		if _, found := prestate[addr]; !found {
+			prestate[addr] = t.prestate[addr]
+		}
+	}
+	for _, s := range t.slots {
+		contract, found := prestate[s.contract]
+		if !found {
+			continue
+		}
+		if contract.Storage == nil {
+			contract.Storage = make(map[common.Hash]common.Hash)
+		}
+		if _, found := contract.Storage[s.slot]; !found {
+			contract.Storage[s.slot] = s.value
+		}
+	}
+	return json.Marshal(prestate)
+}
+
+func (t *VFCEffectsTracer) mergeCallLogs(res json.RawMessage) (json.RawMessage, error) {
+	var frame map[string]json.RawMessage
+	if err := json.Unmarshal(res, &frame); err != nil {
+		return nil, err
+	}
+	if _, failed := frame["error"]; failed {
+		return res, nil
+	}
+
+	logs := make([]vfcCallLog, len(t.logs))
+	for i, log := range t.logs {
+		logs[i] = vfcCallLog{
+			Address: log.Address,
+			Topics:  log.Topics,
+			Data:    log.Data,
+		}
+	}
+	bz, err := json.Marshal(logs)
+	if err != nil {
+		return nil, err
+	}
+	frame["logs"] = bz
+	return json.Marshal(frame)
+}